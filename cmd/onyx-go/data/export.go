@@ -0,0 +1,286 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"github.com/OnyxDevTools/onyx-database-go/onyx"
+)
+
+const defaultExportPageSize = 500
+
+// ExportCommand dumps a table to NDJSON, CSV, or JSON one page at a time.
+//
+// Only a single page of records is held in memory. When writing to a file, the
+// cursor for the next page is checkpointed to a state file after every page so an
+// interrupted export can be resumed by re-running the same command; the state
+// file is removed once the export completes. The checkpoint also records the
+// output size, and a resumed export truncates anything written after it, so a
+// page that was only partially written is fetched and written again cleanly.
+//
+// CSV columns come from --select when set, otherwise from the table's schema
+// fields in declaration order. EmbeddedObject and array values are written as
+// compact JSON in a single cell. Dotted column names such as "address.city"
+// select nested values, so embedded objects can be flattened into their own
+// columns explicitly.
+type ExportCommand struct{}
+
+func (c *ExportCommand) Name() string        { return "export" }
+func (c *ExportCommand) Description() string { return "export table records to ndjson, csv, or json" }
+
+// exportState is the checkpoint persisted between pages.
+type exportState struct {
+	Table   string `json:"table"`
+	Format  string `json:"format"`
+	Query   string `json:"query"`
+	Cursor  string `json:"cursor"`
+	Written int    `json:"written"`
+	Offset  int64  `json:"offset"`
+}
+
+func (c *ExportCommand) Run(args []string) int {
+	fs := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	fs.SetOutput(Stderr)
+	databaseID := fs.String("database-id", "", "database id (optional; defaults to env/config)")
+	table := fs.String("table", "", "table to export (required)")
+	format := fs.String("format", formatNDJSON, "output format: ndjson, csv, or json")
+	outPath := fs.String("out", "", "destination file (defaults to stdout)")
+	statePath := fs.String("state", "", "resume checkpoint file (defaults to <out>.cursor when --out is set)")
+	pageSize := fs.Int("page-size", defaultExportPageSize, "records fetched per page")
+	partition := fs.String("partition", "", "partition to export (optional)")
+	var where repeatedFlag
+	var selectFields stringList
+	fs.Var(&where, "where", "filter as field<op>value or a JSON condition; repeat to AND filters")
+	fs.Var(&selectFields, "select", "comma-separated fields to export (defaults to all)")
+
+	fs.Usage = func() {
+		fmt.Fprintf(Stdout, "Usage of %s:\n", c.Name())
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *table == "" {
+		fmt.Fprintln(Stderr, "--table is required")
+		return 2
+	}
+	if !validFormat(*format) {
+		fmt.Fprintf(Stderr, "unsupported format %q (expected ndjson, csv, or json)\n", *format)
+		return 2
+	}
+	if *pageSize <= 0 {
+		*pageSize = defaultExportPageSize
+	}
+	if *statePath == "" && *outPath != "" {
+		*statePath = *outPath + ".cursor"
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	client, err := initDataClient(ctx, *databaseID)
+	if err != nil {
+		fmt.Fprintln(Stderr, err)
+		return 1
+	}
+
+	query := client.From(*table)
+	for _, expr := range where {
+		cond, err := parseFilter(expr)
+		if err != nil {
+			fmt.Fprintf(Stderr, "invalid --where: %v\n", err)
+			return 2
+		}
+		query = query.Where(cond)
+	}
+	if len(selectFields) > 0 {
+		query = query.Select(selectFields...)
+	}
+	if *partition != "" {
+		query = query.InPartition(*partition)
+	}
+	query = query.Limit(*pageSize)
+
+	fingerprint, err := query.MarshalJSON()
+	if err != nil {
+		fmt.Fprintf(Stderr, "failed to encode query: %v\n", err)
+		return 1
+	}
+
+	state := exportState{Table: *table, Format: *format, Query: string(fingerprint)}
+	resumed := false
+	if *outPath != "" {
+		saved, ok, err := loadExportState(*statePath)
+		if err != nil {
+			fmt.Fprintf(Stderr, "failed to read export state: %v\n", err)
+			return 1
+		}
+		if ok {
+			if saved.Table != state.Table || saved.Format != state.Format || saved.Query != state.Query {
+				fmt.Fprintf(Stderr, "export state %s belongs to a different export; remove it to start over\n", *statePath)
+				return 1
+			}
+			state = saved
+			resumed = true
+		}
+	}
+
+	var columns []string
+	if *format == formatCSV {
+		columns, err = exportColumns(ctx, client, *table, selectFields)
+		if err != nil {
+			fmt.Fprintf(Stderr, "failed to resolve columns: %v\n", err)
+			return 1
+		}
+	}
+
+	var (
+		out  io.Writer = Stdout
+		file *os.File
+	)
+	if *outPath != "" {
+		file, err = openExportFile(*outPath, resumed, state.Offset)
+		if err != nil {
+			fmt.Fprintf(Stderr, "failed to open output: %v\n", err)
+			return 1
+		}
+		defer file.Close()
+		out = file
+	}
+
+	w := newRecordWriter(*format, out, columns, state.Written, resumed)
+	if err := w.Begin(); err != nil {
+		fmt.Fprintf(Stderr, "failed to write output: %v\n", err)
+		return 1
+	}
+
+	for {
+		page, err := query.Page(ctx, state.Cursor)
+		if err != nil {
+			if errors.Is(err, context.Canceled) && file != nil {
+				fmt.Fprintf(Stderr, "export interrupted after %d records; re-run the same command to resume\n", state.Written)
+				return 1
+			}
+			fmt.Fprintf(Stderr, "failed to fetch page: %v\n", err)
+			return 1
+		}
+		for _, record := range page.Items {
+			if err := w.Write(record); err != nil {
+				fmt.Fprintf(Stderr, "failed to write record: %v\n", err)
+				return 1
+			}
+		}
+		if err := w.Flush(); err != nil {
+			fmt.Fprintf(Stderr, "failed to write output: %v\n", err)
+			return 1
+		}
+		state.Written += len(page.Items)
+		state.Cursor = page.NextCursor
+		if state.Cursor == "" {
+			break
+		}
+		if file != nil {
+			if state.Offset, err = file.Seek(0, io.SeekCurrent); err != nil {
+				fmt.Fprintf(Stderr, "failed to checkpoint output: %v\n", err)
+				return 1
+			}
+			if err := saveExportState(*statePath, state); err != nil {
+				fmt.Fprintf(Stderr, "failed to write export state: %v\n", err)
+				return 1
+			}
+		}
+	}
+
+	if err := w.End(); err != nil {
+		fmt.Fprintf(Stderr, "failed to write output: %v\n", err)
+		return 1
+	}
+	if file != nil {
+		if err := os.Remove(*statePath); err != nil && !os.IsNotExist(err) {
+			fmt.Fprintf(Stderr, "failed to remove export state: %v\n", err)
+			return 1
+		}
+	}
+
+	if *outPath != "" {
+		fmt.Fprintf(Stdout, "Exported %d records from %s to %s\n", state.Written, *table, *outPath)
+	}
+	return 0
+}
+
+// exportColumns returns CSV headers: explicit selections win, otherwise schema field order.
+func exportColumns(ctx context.Context, client onyx.Client, table string, selected []string) ([]string, error) {
+	if len(selected) > 0 {
+		return append([]string{}, selected...), nil
+	}
+	schema, err := client.GetSchema(ctx, []string{table})
+	if err != nil {
+		return nil, err
+	}
+	t, ok := schema.Table(table)
+	if !ok {
+		return nil, fmt.Errorf("table %s not found in schema", table)
+	}
+	if len(t.Fields) == 0 {
+		return nil, fmt.Errorf("table %s has no fields", table)
+	}
+	columns := make([]string, 0, len(t.Fields))
+	for _, f := range t.Fields {
+		columns = append(columns, f.Name)
+	}
+	return columns, nil
+}
+
+// openExportFile truncates a fresh export, or rewinds a resumed one to its last checkpoint.
+func openExportFile(path string, resumed bool, offset int64) (*os.File, error) {
+	if !resumed {
+		return os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.Truncate(offset); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+func loadExportState(path string) (exportState, bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return exportState{}, false, nil
+		}
+		return exportState{}, false, err
+	}
+	var state exportState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return exportState{}, false, err
+	}
+	return state, true, nil
+}
+
+func saveExportState(path string, state exportState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
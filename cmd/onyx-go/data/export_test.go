@@ -0,0 +1,261 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/OnyxDevTools/onyx-database-go/onyx"
+)
+
+// stubClient implements only what export needs; other methods panic via the nil embed.
+type stubClient struct {
+	onyx.Client
+	schema onyx.Schema
+	query  *stubQuery
+}
+
+func (s *stubClient) From(table string) onyx.Query {
+	s.query.table = table
+	return s.query
+}
+
+func (s *stubClient) GetSchema(ctx context.Context, tables []string) (onyx.Schema, error) {
+	return s.schema, nil
+}
+
+// stubQuery serves records in pages keyed by the cursor (the index of the next record).
+type stubQuery struct {
+	onyx.Query
+	table      string
+	records    []map[string]any
+	pageSize   int
+	conditions []onyx.Condition
+	fields     []string
+	cursors    []string
+	failAt     int
+}
+
+func (q *stubQuery) Where(c onyx.Condition) onyx.Query {
+	q.conditions = append(q.conditions, c)
+	return q
+}
+func (q *stubQuery) Select(fields ...string) onyx.Query { q.fields = fields; return q }
+func (q *stubQuery) Limit(limit int) onyx.Query         { q.pageSize = limit; return q }
+func (q *stubQuery) InPartition(string) onyx.Query      { return q }
+func (q *stubQuery) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]any{"table": q.table, "fields": q.fields, "conditions": q.conditions})
+}
+
+func (q *stubQuery) Page(ctx context.Context, cursor string) (onyx.PageResult, error) {
+	q.cursors = append(q.cursors, cursor)
+	start := 0
+	if cursor != "" {
+		start, _ = strconv.Atoi(cursor)
+	}
+	if q.failAt > 0 && start >= q.failAt {
+		return onyx.PageResult{}, errors.New("boom")
+	}
+	end := start + q.pageSize
+	if end > len(q.records) {
+		end = len(q.records)
+	}
+	page := onyx.PageResult{Items: q.records[start:end]}
+	if end < len(q.records) {
+		page.NextCursor = strconv.Itoa(end)
+	}
+	return page, nil
+}
+
+func userRecords(n int) []map[string]any {
+	out := make([]map[string]any, 0, n)
+	for i := 0; i < n; i++ {
+		out = append(out, map[string]any{
+			"id":      "u" + strconv.Itoa(i),
+			"age":     float64(20 + i),
+			"profile": map[string]any{"city": "c" + strconv.Itoa(i)},
+		})
+	}
+	return out
+}
+
+func withStubClient(t *testing.T, client *stubClient) *bytes.Buffer {
+	t.Helper()
+	orig := initDataClient
+	initDataClient = func(ctx context.Context, databaseID string) (onyx.Client, error) { return client, nil }
+
+	var out bytes.Buffer
+	Stdout, Stderr = &out, &out
+	t.Cleanup(func() {
+		initDataClient = orig
+		Stdout, Stderr = os.Stdout, os.Stderr
+	})
+	return &out
+}
+
+func TestExportNDJSONToStdout(t *testing.T) {
+	client := &stubClient{query: &stubQuery{records: userRecords(5)}}
+	out := withStubClient(t, client)
+
+	if code := (&ExportCommand{}).Run([]string{"--table", "User", "--page-size", "2", "--where", "age>=21"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d (%s)", code, out.String())
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("expected 5 ndjson lines, got %d: %q", len(lines), out.String())
+	}
+	if got := strings.Join(client.query.cursors, ","); got != ",2,4" {
+		t.Fatalf("unexpected cursors: %s", got)
+	}
+	raw, _ := json.Marshal(client.query.conditions[0])
+	if !strings.Contains(string(raw), `"GREATER_THAN_EQUAL"`) || !strings.Contains(string(raw), `"value":21`) {
+		t.Fatalf("unexpected condition: %s", raw)
+	}
+}
+
+func TestExportCSVUsesSchemaColumnsAndFlattens(t *testing.T) {
+	client := &stubClient{
+		query: &stubQuery{records: userRecords(2)},
+		schema: onyx.Schema{Tables: []onyx.Table{{Name: "User", Fields: []onyx.Field{
+			{Name: "id", Type: "String"},
+			{Name: "profile", Type: "EmbeddedObject"},
+			{Name: "age", Type: "Int"},
+		}}}},
+	}
+	out := withStubClient(t, client)
+
+	if code := (&ExportCommand{}).Run([]string{"--table", "User", "--format", "csv"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d (%s)", code, out.String())
+	}
+	want := "id,profile,age\nu0,\"{\"\"city\"\":\"\"c0\"\"}\",20\nu1,\"{\"\"city\"\":\"\"c1\"\"}\",21\n"
+	if out.String() != want {
+		t.Fatalf("unexpected csv:\n%s", out.String())
+	}
+
+	out.Reset()
+	client.query = &stubQuery{records: userRecords(1)}
+	if code := (&ExportCommand{}).Run([]string{"--table", "User", "--format", "csv", "--select", "id,profile.city"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d (%s)", code, out.String())
+	}
+	if out.String() != "id,profile.city\nu0,c0\n" {
+		t.Fatalf("unexpected flattened csv:\n%s", out.String())
+	}
+}
+
+func TestExportJSONResumesFromCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+	outPath := filepath.Join(dir, "users.json")
+	statePath := outPath + ".cursor"
+
+	client := &stubClient{query: &stubQuery{records: userRecords(5), failAt: 4}}
+	out := withStubClient(t, client)
+
+	args := []string{"--table", "User", "--format", "json", "--page-size", "2", "--out", outPath}
+	if code := (&ExportCommand{}).Run(args); code != 1 {
+		t.Fatalf("expected failure exit 1, got %d", code)
+	}
+	if _, err := os.Stat(statePath); err != nil {
+		t.Fatalf("expected checkpoint to be kept: %v", err)
+	}
+
+	// Simulate a partially written page after the checkpoint.
+	f, err := os.OpenFile(outPath, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	_, _ = f.WriteString(",\n{\"id\":\"u4\",\"ag")
+	f.Close()
+
+	client.query = &stubQuery{records: userRecords(5)}
+	out.Reset()
+	if code := (&ExportCommand{}).Run(args); code != 0 {
+		t.Fatalf("expected resume exit 0, got %d (%s)", code, out.String())
+	}
+	if got := strings.Join(client.query.cursors, ","); got != "4" {
+		t.Fatalf("expected resume from cursor 4, got %s", got)
+	}
+	if _, err := os.Stat(statePath); !os.IsNotExist(err) {
+		t.Fatalf("expected checkpoint to be removed, got %v", err)
+	}
+
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	var records []map[string]any
+	if err := json.Unmarshal(data, &records); err != nil {
+		t.Fatalf("output is not a JSON array: %v\n%s", err, data)
+	}
+	if len(records) != 5 || records[4]["id"] != "u4" {
+		t.Fatalf("unexpected records: %+v", records)
+	}
+	if !strings.Contains(out.String(), "Exported 5 records") {
+		t.Fatalf("unexpected summary: %s", out.String())
+	}
+}
+
+func TestExportRejectsForeignCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+	outPath := filepath.Join(dir, "users.ndjson")
+	if err := saveExportState(outPath+".cursor", exportState{Table: "Role", Format: formatNDJSON, Cursor: "2"}); err != nil {
+		t.Fatalf("save state: %v", err)
+	}
+
+	client := &stubClient{query: &stubQuery{records: userRecords(1)}}
+	out := withStubClient(t, client)
+	if code := (&ExportCommand{}).Run([]string{"--table", "User", "--out", outPath}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if !strings.Contains(out.String(), "different export") {
+		t.Fatalf("unexpected output: %s", out.String())
+	}
+}
+
+func TestExportUsageErrors(t *testing.T) {
+	out := withStubClient(t, &stubClient{query: &stubQuery{}})
+	cases := [][]string{
+		{},
+		{"--table", "User", "--format", "xml"},
+		{"--table", "User", "--where", "nonsense"},
+	}
+	for _, args := range cases {
+		if code := (&ExportCommand{}).Run(args); code != 2 {
+			t.Fatalf("expected usage exit for %v, got %d (%s)", args, code, out.String())
+		}
+	}
+}
+
+func TestParseFilter(t *testing.T) {
+	cases := map[string]string{
+		`status=active`:     `{"conditionType":"SingleCondition","criteria":{"field":"status","operator":"EQUAL","value":"active"}}`,
+		`age>=21`:           `{"conditionType":"SingleCondition","criteria":{"field":"age","operator":"GREATER_THAN_EQUAL","value":21}}`,
+		`name~=%bob%`:       `{"conditionType":"SingleCondition","criteria":{"field":"name","operator":"LIKE","value":"%bob%"}}`,
+		`deletedAt=null`:    `{"conditionType":"SingleCondition","criteria":{"field":"deletedAt","operator":"IS_NULL"}}`,
+		`active!=false`:     `{"conditionType":"SingleCondition","criteria":{"field":"active","operator":"NOT_EQUAL","value":false}}`,
+		`{"custom":"cond"}`: `{"custom":"cond"}`,
+	}
+	for expr, want := range cases {
+		cond, err := parseFilter(expr)
+		if err != nil {
+			t.Fatalf("parse %s: %v", expr, err)
+		}
+		raw, err := json.Marshal(cond)
+		if err != nil {
+			t.Fatalf("marshal %s: %v", expr, err)
+		}
+		if string(raw) != want {
+			t.Fatalf("filter %s: got %s want %s", expr, raw, want)
+		}
+	}
+
+	if _, err := parseFilter(`{bad`); err == nil {
+		t.Fatalf("expected invalid JSON error")
+	}
+}
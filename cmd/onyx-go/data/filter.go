@@ -0,0 +1,96 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/OnyxDevTools/onyx-database-go/onyx"
+)
+
+// filterOperators lists two-character operators first so they win over their one-character prefixes.
+var filterOperators = []struct {
+	token string
+	build func(field string, value any) onyx.Condition
+}{
+	{"!=", onyx.Neq},
+	{">=", onyx.Gte},
+	{"<=", onyx.Lte},
+	{"~=", onyx.Like},
+	{"^=", onyx.StartsWith},
+	{"=", onyx.Eq},
+	{">", onyx.Gt},
+	{"<", onyx.Lt},
+}
+
+// parseFilter converts a --where expression into a condition.
+//
+// Two forms are accepted: a raw condition document as produced by the SDK
+// (any value starting with "{"), or a compact "field<op>value" expression where
+// op is one of = != > >= < <= ~= (LIKE) ^= (STARTS_WITH). Values that parse as
+// JSON (numbers, booleans, null, quoted strings) keep their JSON type; anything
+// else is treated as a bare string.
+func parseFilter(expr string) (onyx.Condition, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return nil, fmt.Errorf("empty filter")
+	}
+
+	if strings.HasPrefix(expr, "{") {
+		if !json.Valid([]byte(expr)) {
+			return nil, fmt.Errorf("filter is not valid JSON: %s", expr)
+		}
+		return json.RawMessage(expr), nil
+	}
+
+	for i := 1; i < len(expr); i++ {
+		for _, op := range filterOperators {
+			if !strings.HasPrefix(expr[i:], op.token) {
+				continue
+			}
+			field := strings.TrimSpace(expr[:i])
+			raw := strings.TrimSpace(expr[i+len(op.token):])
+			if raw == "null" && (op.token == "=" || op.token == "!=") {
+				if op.token == "=" {
+					return onyx.IsNull(field), nil
+				}
+				return onyx.NotNull(field), nil
+			}
+			return op.build(field, filterValue(raw)), nil
+		}
+	}
+
+	return nil, fmt.Errorf("unrecognized filter %q (expected field<op>value or a JSON condition)", expr)
+}
+
+func filterValue(raw string) any {
+	var v any
+	if err := json.Unmarshal([]byte(raw), &v); err == nil {
+		return v
+	}
+	return raw
+}
+
+// stringList is a repeatable/comma-separated flag value.
+type stringList []string
+
+func (s *stringList) String() string { return strings.Join(*s, ",") }
+
+func (s *stringList) Set(value string) error {
+	for _, part := range strings.Split(value, ",") {
+		if trimmed := strings.TrimSpace(part); trimmed != "" {
+			*s = append(*s, trimmed)
+		}
+	}
+	return nil
+}
+
+// repeatedFlag collects each occurrence verbatim (filters may contain commas).
+type repeatedFlag []string
+
+func (r *repeatedFlag) String() string { return strings.Join(*r, " AND ") }
+
+func (r *repeatedFlag) Set(value string) error {
+	*r = append(*r, value)
+	return nil
+}
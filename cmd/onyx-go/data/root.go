@@ -0,0 +1,78 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/OnyxDevTools/onyx-database-go/onyx"
+)
+
+// Stdout and Stderr allow commands to direct output; tests can override.
+var (
+	Stdout io.Writer = os.Stdout
+	Stderr io.Writer = os.Stderr
+)
+
+// Command represents a data subcommand.
+type Command interface {
+	Name() string
+	Description() string
+	Run(args []string) int
+}
+
+// Dispatch runs the appropriate data subcommand based on the provided args.
+// Exit codes: 0 success, 1 failure, 2 usage error.
+func Dispatch(args []string) int {
+	cmds := availableCommands()
+	if len(args) == 0 {
+		printRootUsage(cmds)
+		return 2
+	}
+
+	if args[0] == "-h" || args[0] == "--help" {
+		printRootUsage(cmds)
+		return 0
+	}
+
+	for _, c := range cmds {
+		if c.Name() == args[0] {
+			return c.Run(args[1:])
+		}
+	}
+
+	fmt.Fprintf(Stderr, "unknown command %q\n", args[0])
+	printRootUsage(cmds)
+	return 2
+}
+
+func printRootUsage(cmds []Command) {
+	fmt.Fprintln(Stdout, "Usage: onyx-go data <command> [options]")
+	fmt.Fprintln(Stdout)
+	fmt.Fprintln(Stdout, "Available commands:")
+
+	sort.Slice(cmds, func(i, j int) bool {
+		return cmds[i].Name() < cmds[j].Name()
+	})
+
+	for _, c := range cmds {
+		fmt.Fprintf(Stdout, "  %-10s %s\n", c.Name(), c.Description())
+	}
+}
+
+var availableCommands = defaultAvailableCommands
+
+func defaultAvailableCommands() []Command {
+	return []Command{
+		&ExportCommand{},
+	}
+}
+
+var initDataClient = func(ctx context.Context, databaseID string) (onyx.Client, error) {
+	if databaseID != "" {
+		return onyx.InitWithDatabaseID(ctx, databaseID)
+	}
+	return onyx.Init(ctx, onyx.Config{})
+}
@@ -0,0 +1,184 @@
+package data
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	formatNDJSON = "ndjson"
+	formatCSV    = "csv"
+	formatJSON   = "json"
+)
+
+func validFormat(format string) bool {
+	switch format {
+	case formatNDJSON, formatCSV, formatJSON:
+		return true
+	default:
+		return false
+	}
+}
+
+// recordWriter streams records in a single output format.
+// Begin and End emit framing (CSV header, JSON brackets) and are skipped or
+// adjusted when appending to a resumed export.
+type recordWriter interface {
+	Begin() error
+	Write(record map[string]any) error
+	Flush() error
+	End() error
+}
+
+func newRecordWriter(format string, w io.Writer, columns []string, written int, resumed bool) recordWriter {
+	buf := bufio.NewWriter(w)
+	switch format {
+	case formatCSV:
+		return &csvWriter{buf: buf, csv: csv.NewWriter(buf), columns: columns, resumed: resumed}
+	case formatJSON:
+		return &jsonArrayWriter{buf: buf, written: written, resumed: resumed}
+	default:
+		return &ndjsonWriter{buf: buf}
+	}
+}
+
+type ndjsonWriter struct {
+	buf *bufio.Writer
+}
+
+func (w *ndjsonWriter) Begin() error { return nil }
+
+func (w *ndjsonWriter) Write(record map[string]any) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if _, err := w.buf.Write(data); err != nil {
+		return err
+	}
+	return w.buf.WriteByte('\n')
+}
+
+func (w *ndjsonWriter) Flush() error { return w.buf.Flush() }
+func (w *ndjsonWriter) End() error   { return w.buf.Flush() }
+
+type jsonArrayWriter struct {
+	buf     *bufio.Writer
+	written int
+	resumed bool
+}
+
+func (w *jsonArrayWriter) Begin() error {
+	if w.resumed {
+		return nil
+	}
+	_, err := w.buf.WriteString("[")
+	return err
+}
+
+func (w *jsonArrayWriter) Write(record map[string]any) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	sep := ",\n"
+	if w.written == 0 {
+		sep = "\n"
+	}
+	if _, err := w.buf.WriteString(sep); err != nil {
+		return err
+	}
+	if _, err := w.buf.Write(data); err != nil {
+		return err
+	}
+	w.written++
+	return nil
+}
+
+func (w *jsonArrayWriter) Flush() error { return w.buf.Flush() }
+
+func (w *jsonArrayWriter) End() error {
+	if _, err := w.buf.WriteString("\n]\n"); err != nil {
+		return err
+	}
+	return w.buf.Flush()
+}
+
+type csvWriter struct {
+	buf     *bufio.Writer
+	csv     *csv.Writer
+	columns []string
+	resumed bool
+}
+
+func (w *csvWriter) Begin() error {
+	if w.resumed {
+		return nil
+	}
+	return w.csv.Write(w.columns)
+}
+
+func (w *csvWriter) Write(record map[string]any) error {
+	row := make([]string, len(w.columns))
+	for i, col := range w.columns {
+		cell, err := csvCell(lookupPath(record, col))
+		if err != nil {
+			return fmt.Errorf("column %s: %w", col, err)
+		}
+		row[i] = cell
+	}
+	return w.csv.Write(row)
+}
+
+func (w *csvWriter) Flush() error {
+	w.csv.Flush()
+	if err := w.csv.Error(); err != nil {
+		return err
+	}
+	return w.buf.Flush()
+}
+
+func (w *csvWriter) End() error { return w.Flush() }
+
+// lookupPath resolves a column against a record. Exact keys win; otherwise a
+// dotted name walks into embedded objects ("address.city").
+func lookupPath(record map[string]any, path string) any {
+	if v, ok := record[path]; ok {
+		return v
+	}
+	var cur any = record
+	for _, part := range strings.Split(path, ".") {
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return nil
+		}
+		cur = m[part]
+	}
+	return cur
+}
+
+func csvCell(v any) (string, error) {
+	switch val := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return val, nil
+	case bool:
+		return strconv.FormatBool(val), nil
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64), nil
+	case json.Number:
+		return val.String(), nil
+	default:
+		data, err := json.Marshal(val)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+}
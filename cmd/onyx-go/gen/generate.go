@@ -0,0 +1,263 @@
+package gen
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"go/format"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// Options control the generated package.
+type Options struct {
+	// Package is the Go package name of the generated files. Defaults to "onyx".
+	Package string
+	// Tables limits generation to the named tables. Empty means every table.
+	Tables []string
+	// NullablePointers emits nullable scalar attributes as pointers (*string, *time.Time).
+	NullablePointers bool
+	// Timestamps is "time" (time.Time, the default) or "string".
+	Timestamps string
+	// GeneratedAt is written to every file header. Defaults to ONYX_GEN_TIMESTAMP, else the
+	// current UTC time.
+	GeneratedAt string
+}
+
+type tableData struct {
+	Package    string
+	Name       string
+	Plural     string
+	Lower      string
+	PrimaryKey string
+	Fields     []fieldData
+	Resolvers  []fieldData
+}
+
+type fieldData struct {
+	Name   string
+	GoName string
+	GoType string
+	// Descriptor and Constructor name the onyx typed field descriptor; empty for embedded values.
+	Descriptor  string
+	Constructor string
+}
+
+// Generate renders the typed client for the schema. It returns gofmt'd file contents keyed by
+// file name: common.go plus one file per table. Output depends only on the schema and options.
+func Generate(schema contract.Schema, opts Options) (map[string][]byte, error) {
+	if opts.Package == "" {
+		opts.Package = "onyx"
+	}
+	switch opts.Timestamps {
+	case "":
+		opts.Timestamps = "time"
+	case "time", "string":
+	default:
+		return nil, fmt.Errorf("invalid timestamps mode %q: want time or string", opts.Timestamps)
+	}
+	if opts.GeneratedAt == "" {
+		opts.GeneratedAt = generatedAt()
+	}
+
+	tables, err := selectTables(contract.NormalizeSchema(schema), opts.Tables)
+	if err != nil {
+		return nil, err
+	}
+
+	header := fmt.Sprintf("// Code generated by onyx-go gen; DO NOT EDIT.\n// Generated at: %s", opts.GeneratedAt)
+	tmpl, err := template.New("gen").
+		Funcs(template.FuncMap{"header": func() string { return header }}).
+		ParseFS(templateFS, "templates/*.tmpl")
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string][]byte{}
+	var data []tableData
+	for _, t := range tables {
+		td := newTableData(opts, t)
+		data = append(data, td)
+		src, err := render(tmpl, "table.go.tmpl", td)
+		if err != nil {
+			return nil, fmt.Errorf("table %s: %w", t.Name, err)
+		}
+		files[td.Lower+".go"] = src
+	}
+
+	src, err := render(tmpl, "common.go.tmpl", struct {
+		Package string
+		Tables  []tableData
+	}{opts.Package, data})
+	if err != nil {
+		return nil, fmt.Errorf("common.go: %w", err)
+	}
+	files["common.go"] = src
+	return files, nil
+}
+
+func render(tmpl *template.Template, name string, data any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+func generatedAt() string {
+	if ts := os.Getenv("ONYX_GEN_TIMESTAMP"); ts != "" {
+		return ts
+	}
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+func selectTables(schema contract.Schema, names []string) ([]contract.Table, error) {
+	if len(names) == 0 {
+		return schema.Tables, nil
+	}
+	want := map[string]bool{}
+	for _, n := range names {
+		want[n] = true
+	}
+	var out []contract.Table
+	for _, t := range schema.Tables {
+		if want[t.Name] {
+			out = append(out, t)
+			delete(want, t.Name)
+		}
+	}
+	if len(want) > 0 {
+		missing := make([]string, 0, len(want))
+		for n := range want {
+			missing = append(missing, n)
+		}
+		sort.Strings(missing)
+		return nil, fmt.Errorf("unknown tables: %s", strings.Join(missing, ", "))
+	}
+	return out, nil
+}
+
+func newTableData(opts Options, t contract.Table) tableData {
+	name := exportedName(t.Name)
+	td := tableData{
+		Package:    opts.Package,
+		Name:       name,
+		Plural:     plural(name),
+		Lower:      strings.ToLower(name),
+		PrimaryKey: "id",
+	}
+	for _, f := range t.Fields {
+		if f.Primary {
+			td.PrimaryKey = f.Name
+		}
+		fd := fieldData{Name: f.Name, GoName: exportedName(f.Name), GoType: goType(opts, f)}
+		fd.Descriptor, fd.Constructor = descriptor(opts, f)
+		td.Fields = append(td.Fields, fd)
+	}
+	for _, r := range t.Resolvers {
+		td.Resolvers = append(td.Resolvers, fieldData{Name: r.Name, GoName: exportedName(r.Name), GoType: "any"})
+	}
+	return td
+}
+
+func goType(opts Options, f contract.Field) string {
+	var t string
+	switch f.Type {
+	case "String":
+		t = "string"
+	case "Boolean":
+		t = "bool"
+	case "Byte", "Short", "Int", "Long":
+		t = "int64"
+	case "Float", "Double":
+		t = "float64"
+	case "Timestamp", "Date":
+		t = "time.Time"
+		if opts.Timestamps == "string" {
+			t = "string"
+		}
+	case "EmbeddedList":
+		return "[]any"
+	default:
+		return "any"
+	}
+	if f.Nullable && opts.NullablePointers {
+		return "*" + t
+	}
+	return t
+}
+
+// descriptor picks the onyx typed field for f. Primary keys are RefFields so they can be matched
+// against nested queries.
+func descriptor(opts Options, f contract.Field) (string, string) {
+	var kind string
+	switch f.Type {
+	case "String":
+		kind = "String"
+	case "Boolean":
+		kind = "Bool"
+	case "Byte", "Short", "Int", "Long":
+		kind = "Int"
+	case "Float", "Double":
+		kind = "Float"
+	case "Timestamp", "Date":
+		kind = "Time"
+		if opts.Timestamps == "string" {
+			kind = "String"
+		}
+	default:
+		return "", ""
+	}
+
+	typ, ctor := kind+"Field", fmt.Sprintf("New%sField(%q)", kind, f.Name)
+	if f.Primary {
+		base := strings.TrimPrefix(goType(Options{Timestamps: opts.Timestamps}, f), "*")
+		typ, ctor = fmt.Sprintf("RefField[%s]", base), fmt.Sprintf("NewRefField[%s](%q)", base, f.Name)
+	}
+	if f.Nullable {
+		typ, ctor = "Nullable"+typ, ctor+".Nullable()"
+	}
+	return typ, ctor
+}
+
+// exportedName turns a schema name into an exported Go identifier, dropping characters Go
+// does not allow and capitalizing the letter after each one: userId -> UserId, audit_log -> AuditLog.
+func exportedName(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	out := b.String()
+	if out == "" || unicode.IsDigit(rune(out[0])) {
+		out = "T" + out
+	}
+	return out
+}
+
+func plural(name string) string {
+	switch {
+	case strings.HasSuffix(name, "s"), strings.HasSuffix(name, "x"), strings.HasSuffix(name, "ch"), strings.HasSuffix(name, "sh"):
+		return name + "es"
+	case len(name) > 1 && strings.HasSuffix(name, "y") && !strings.ContainsRune("aeiou", rune(name[len(name)-2])):
+		return name[:len(name)-1] + "ies"
+	}
+	return name + "s"
+}
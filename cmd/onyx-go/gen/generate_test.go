@@ -0,0 +1,160 @@
+package gen
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files under examples/gen/onyx")
+
+const (
+	exampleSchema = "../../../examples/api/onyx.schema.json"
+	goldenDir     = "../../../examples/gen/onyx"
+)
+
+func loadExampleSchema(t *testing.T) contract.Schema {
+	t.Helper()
+	data, err := os.ReadFile(exampleSchema)
+	if err != nil {
+		t.Fatalf("read schema: %v", err)
+	}
+	schema, err := contract.ParseSchemaJSON(data)
+	if err != nil {
+		t.Fatalf("parse schema: %v", err)
+	}
+	return schema
+}
+
+// TestGenerateMatchesExamples keeps the generated files in examples/gen/onyx in sync with the
+// generator. Run go test ./cmd/onyx-go/gen -update after changing a template.
+func TestGenerateMatchesExamples(t *testing.T) {
+	t.Setenv("ONYX_GEN_TIMESTAMP", "1970-01-01T00:00:00Z")
+	files, err := Generate(loadExampleSchema(t), Options{Package: "onyx", NullablePointers: true})
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	if *update {
+		for name, src := range files {
+			if err := os.WriteFile(filepath.Join(goldenDir, name), src, 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	onDisk, err := filepath.Glob(filepath.Join(goldenDir, "*.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var want, got []string
+	for _, path := range onDisk {
+		// Hand-written files may sit next to the generated ones.
+		src, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.HasPrefix(src, []byte("// Code generated ")) {
+			want = append(want, filepath.Base(path))
+		}
+	}
+	for name := range files {
+		got = append(got, name)
+	}
+	sort.Strings(got)
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("generated files %v, golden files %v", got, want)
+	}
+
+	for name, src := range files {
+		golden, err := os.ReadFile(filepath.Join(goldenDir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(src, golden) {
+			t.Errorf("%s differs from the golden file; run go test ./cmd/onyx-go/gen -update", name)
+		}
+	}
+}
+
+func TestGenerateIsDeterministic(t *testing.T) {
+	schema := loadExampleSchema(t)
+	a, err := Generate(schema, Options{GeneratedAt: "fixed"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := Generate(schema, Options{GeneratedAt: "fixed"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name := range a {
+		if !bytes.Equal(a[name], b[name]) {
+			t.Fatalf("%s differs between runs", name)
+		}
+	}
+	if !strings.Contains(string(a["common.go"]), "// Generated at: fixed\n") {
+		t.Fatalf("expected the GeneratedAt header, got:\n%s", a["common.go"][:120])
+	}
+}
+
+func TestGenerateOptions(t *testing.T) {
+	files, err := Generate(loadExampleSchema(t), Options{
+		Package:     "models",
+		Tables:      []string{"UserProfile", "Role"},
+		Timestamps:  "string",
+		GeneratedAt: "fixed",
+	})
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if len(files) != 3 || files["role.go"] == nil || files["userprofile.go"] == nil {
+		t.Fatalf("expected common.go, role.go and userprofile.go, got %d files", len(files))
+	}
+
+	profile := string(files["userprofile.go"])
+	for _, want := range []string{
+		"package models",
+		"Age       int64 ",
+		"Bio       string ",
+		"CreatedAt string ",
+		"func (c DB) UserProfiles() UserProfilesClient",
+		`Bio:       onyx.NewStringField("bio").Nullable(),`,
+		`CreatedAt: onyx.NewStringField("createdAt"),`,
+		`Id:        onyx.NewRefField[string]("id"),`,
+	} {
+		if !strings.Contains(profile, want) {
+			t.Fatalf("expected %q in userprofile.go:\n%s", want, profile)
+		}
+	}
+
+	common := string(files["common.go"])
+	if strings.Contains(common, "AuditLog") || !strings.Contains(common, `"Role": {"permissions", "rolePermissions"}`) {
+		t.Fatalf("expected only the selected tables in common.go:\n%s", common)
+	}
+
+	if _, err := Generate(loadExampleSchema(t), Options{Tables: []string{"Nope"}}); err == nil || !strings.Contains(err.Error(), "unknown tables: Nope") {
+		t.Fatalf("expected unknown table error, got %v", err)
+	}
+	if _, err := Generate(loadExampleSchema(t), Options{Timestamps: "unix"}); err == nil {
+		t.Fatal("expected invalid timestamps error")
+	}
+}
+
+func TestNames(t *testing.T) {
+	for in, want := range map[string]string{"userId": "UserId", "audit_log": "AuditLog", "2fa": "T2fa"} {
+		if got := exportedName(in); got != want {
+			t.Fatalf("exportedName(%q) = %q, want %q", in, got, want)
+		}
+	}
+	for in, want := range map[string]string{"User": "Users", "Address": "Addresses", "Category": "Categories", "Key": "Keys", "Box": "Boxes"} {
+		if got := plural(in); got != want {
+			t.Fatalf("plural(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package gen
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+	"github.com/OnyxDevTools/onyx-database-go/onyx"
+)
+
+// Stdout and Stderr allow commands to direct output; tests can override.
+var (
+	Stdout io.Writer = os.Stdout
+	Stderr io.Writer = os.Stderr
+)
+
+// Run generates typed clients from a schema file or the API.
+// Exit codes: 0 success, 1 failure, 2 usage error.
+func Run(args []string) int {
+	fs := flag.NewFlagSet("gen", flag.ContinueOnError)
+	fs.SetOutput(Stderr)
+	fs.Bool("go", true, "generate Go (the only target; accepted for compatibility)")
+	source := fs.String("source", "file", "schema source: file or api")
+	schemaPath := fs.String("schema", "api/onyx.schema.json", "path to schema JSON when --source=file")
+	databaseID := fs.String("database-id", "", "database id when --source=api (optional if configured)")
+	outDir := fs.String("out", "gen/onyx", "output directory")
+	pkg := fs.String("package", "onyx", "Go package name of the generated files")
+	tables := fs.String("tables", "", "comma-separated tables to generate (all when empty)")
+	pointers := fs.Bool("nullable-pointers", true, "emit nullable attributes as pointers")
+	timestamps := fs.String("timestamps", "time", "Timestamp field type: time or string")
+
+	fs.Usage = func() {
+		fmt.Fprintln(Stdout, "Usage of gen:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *source != "file" && *source != "api" {
+		fmt.Fprintf(Stderr, "invalid --source %q: want file or api\n", *source)
+		return 2
+	}
+	if *timestamps != "time" && *timestamps != "string" {
+		fmt.Fprintf(Stderr, "invalid --timestamps %q: want time or string\n", *timestamps)
+		return 2
+	}
+
+	var (
+		schema contract.Schema
+		err    error
+	)
+	if *source == "api" {
+		schema, err = fetchSchema(context.Background(), *databaseID)
+		if err != nil {
+			fmt.Fprintf(Stderr, "failed to fetch schema from API: %v\n", err)
+			return 1
+		}
+	} else {
+		data, err := os.ReadFile(*schemaPath)
+		if err != nil {
+			fmt.Fprintf(Stderr, "failed to read schema: %v\n", err)
+			return 1
+		}
+		if schema, err = contract.ParseSchemaJSON(data); err != nil {
+			fmt.Fprintf(Stderr, "failed to parse schema: %v\n", err)
+			return 1
+		}
+	}
+
+	files, err := Generate(schema, Options{
+		Package:          *pkg,
+		Tables:           splitList(*tables),
+		NullablePointers: *pointers,
+		Timestamps:       *timestamps,
+	})
+	if err != nil {
+		fmt.Fprintf(Stderr, "failed to generate: %v\n", err)
+		return 1
+	}
+
+	if err := os.MkdirAll(*outDir, 0o755); err != nil {
+		fmt.Fprintf(Stderr, "failed to create %s: %v\n", *outDir, err)
+		return 1
+	}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(*outDir, name), files[name], 0o644); err != nil {
+			fmt.Fprintf(Stderr, "failed to write %s: %v\n", name, err)
+			return 1
+		}
+	}
+
+	fmt.Fprintf(Stdout, "Generated %d files in %s\n", len(names), *outDir)
+	return 0
+}
+
+var fetchSchema = func(ctx context.Context, databaseID string) (contract.Schema, error) {
+	var (
+		client onyx.Client
+		err    error
+	)
+	if databaseID != "" {
+		client, err = onyx.InitWithDatabaseID(ctx, databaseID)
+	} else {
+		client, err = onyx.Init(ctx, onyx.Config{})
+	}
+	if err != nil {
+		return contract.Schema{}, err
+	}
+	return client.Schema(ctx)
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
@@ -0,0 +1,87 @@
+package gen
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+func captureOutput(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var out bytes.Buffer
+	Stdout, Stderr = &out, &out
+	t.Cleanup(func() { Stdout, Stderr = os.Stdout, os.Stderr })
+	return &out
+}
+
+func TestRunWritesFiles(t *testing.T) {
+	out := captureOutput(t)
+	dir := filepath.Join(t.TempDir(), "gen")
+	code := Run([]string{"--go", "--schema", exampleSchema, "--out", dir, "--package", "db", "--tables", "User, UserRole", "--nullable-pointers=false"})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	if !strings.Contains(out.String(), "Generated 3 files") {
+		t.Fatalf("unexpected output: %s", out.String())
+	}
+	user, err := os.ReadFile(filepath.Join(dir, "user.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(user), "package db") || !strings.Contains(string(user), "LastLoginAt time.Time ") {
+		t.Fatalf("expected package db and non-pointer nullable fields:\n%s", user)
+	}
+}
+
+func TestRunFromAPI(t *testing.T) {
+	out := captureOutput(t)
+	original := fetchSchema
+	defer func() { fetchSchema = original }()
+
+	fetchSchema = func(ctx context.Context, databaseID string) (contract.Schema, error) {
+		if databaseID != "db-1" {
+			t.Fatalf("expected database id db-1, got %q", databaseID)
+		}
+		return contract.Schema{Tables: []contract.Table{{Name: "Note", Fields: []contract.Field{{Name: "id", Type: "String", Primary: true}}}}}, nil
+	}
+	dir := t.TempDir()
+	if code := Run([]string{"--source", "api", "--database-id", "db-1", "--out", dir}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	if _, err := os.Stat(filepath.Join(dir, "note.go")); err != nil {
+		t.Fatalf("expected note.go: %v", err)
+	}
+
+	fetchSchema = func(ctx context.Context, databaseID string) (contract.Schema, error) {
+		return contract.Schema{}, errors.New("offline")
+	}
+	if code := Run([]string{"--source", "api", "--out", dir}); code != 1 || !strings.Contains(out.String(), "offline") {
+		t.Fatalf("expected exit 1 with the fetch error, got %d: %s", code, out.String())
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	cases := []struct {
+		args []string
+		code int
+		want string
+	}{
+		{[]string{"--bogus"}, 2, "flag provided but not defined"},
+		{[]string{"--source", "db"}, 2, "invalid --source"},
+		{[]string{"--timestamps", "unix"}, 2, "invalid --timestamps"},
+		{[]string{"--schema", "missing.json"}, 1, "failed to read schema"},
+		{[]string{"--schema", exampleSchema, "--tables", "Nope", "--out", "unused"}, 1, "unknown tables: Nope"},
+	}
+	for _, tc := range cases {
+		out := captureOutput(t)
+		if code := Run(tc.args); code != tc.code || !strings.Contains(out.String(), tc.want) {
+			t.Fatalf("Run(%v): expected exit %d with %q, got %d: %s", tc.args, tc.code, tc.want, code, out.String())
+		}
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"io"
 	"os"
 
+	dataCmds "github.com/OnyxDevTools/onyx-database-go/cmd/onyx-go/data"
+	genCmds "github.com/OnyxDevTools/onyx-database-go/cmd/onyx-go/gen"
 	schemaCmds "github.com/OnyxDevTools/onyx-database-go/cmd/onyx-schema-go/commands"
 )
 
@@ -32,6 +34,14 @@ func dispatch(args []string, stdout, stderr io.Writer) int {
 		schemaCmds.Stdout = stdout
 		schemaCmds.Stderr = stderr
 		return schemaCmds.Dispatch(args[1:])
+	case "data":
+		dataCmds.Stdout = stdout
+		dataCmds.Stderr = stderr
+		return dataCmds.Dispatch(args[1:])
+	case "gen":
+		genCmds.Stdout = stdout
+		genCmds.Stderr = stderr
+		return genCmds.Run(args[1:])
 	default:
 		fmt.Fprintf(stderr, "unknown subcommand %q\n", args[0])
 		printRootUsage(stderr)
@@ -44,4 +54,6 @@ func printRootUsage(w io.Writer) {
 	fmt.Fprintln(w)
 	fmt.Fprintln(w, "Subcommands:")
 	fmt.Fprintln(w, "  schema    Schema operations (validate/diff/get/publish)")
+	fmt.Fprintln(w, "  data      Data operations (export)")
+	fmt.Fprintln(w, "  gen       Generate typed Go clients from a schema")
 }
@@ -6,6 +6,8 @@ import (
 	"strings"
 	"testing"
 
+	dataCmds "github.com/OnyxDevTools/onyx-database-go/cmd/onyx-go/data"
+	genCmds "github.com/OnyxDevTools/onyx-database-go/cmd/onyx-go/gen"
 	schemaCmds "github.com/OnyxDevTools/onyx-database-go/cmd/onyx-schema-go/commands"
 )
 
@@ -42,6 +44,19 @@ func TestDispatchScenarios(t *testing.T) {
 			wantCode:      0,
 			wantStdoutSub: []string{"Available commands"},
 		},
+		{
+			name:          "data help",
+			args:          []string{"data", "--help"},
+			wantCode:      0,
+			wantStdoutSub: []string{"onyx-go data", "export"},
+		},
+		{
+			name:          "gen help",
+			args:          []string{"gen", "--help"},
+			wantCode:      2,
+			wantStdoutSub: []string{"Usage of gen"},
+			wantStderrSub: []string{"-nullable-pointers"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -55,6 +70,10 @@ func TestDispatchScenarios(t *testing.T) {
 			code := dispatch(os.Args[1:], &stdout, &stderr)
 			schemaCmds.Stdout = os.Stdout
 			schemaCmds.Stderr = os.Stderr
+			dataCmds.Stdout = os.Stdout
+			dataCmds.Stderr = os.Stderr
+			genCmds.Stdout = os.Stdout
+			genCmds.Stderr = os.Stderr
 			if code != tt.wantCode {
 				t.Fatalf("expected code %d, got %d (stdout=%q, stderr=%q)", tt.wantCode, code, stdout.String(), stderr.String())
 			}
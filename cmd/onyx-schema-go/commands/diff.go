@@ -5,6 +5,8 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"reflect"
+	"sort"
 	"strings"
 
 	schemas "github.com/OnyxDevTools/onyx-database-go/impl/schema"
@@ -12,8 +14,17 @@ import (
 )
 
 // DiffCommand compares two schema files.
+//
+// With --fail-on, the command exits 3 when the diff contains breaking changes and 4 when its
+// worst change is risky, so CI can tell the two apart from ordinary failures (1) and usage
+// errors (2).
 type DiffCommand struct{}
 
+const (
+	exitBreakingChanges = 3
+	exitRiskyChanges    = 4
+)
+
 func (c *DiffCommand) Name() string        { return "diff" }
 func (c *DiffCommand) Description() string { return "diff two schema files" }
 
@@ -23,7 +34,10 @@ func (c *DiffCommand) Run(args []string) int {
 	pathA := fs.String("a", defaultSchemaPath, "path to base schema JSON")
 	pathB := fs.String("b", "", "path to updated schema JSON")
 	databaseID := fs.String("database-id", "", "database id to fetch updated schema via API when --b is omitted")
-	jsonOut := fs.Bool("json", false, "emit machine-readable JSON diff")
+	jsonOut := fs.Bool("json", false, "emit machine-readable JSON diff (same as --format json)")
+	format := fs.String("format", "text", "output format: text, json, or jsonpatch (RFC 6902 patch from base to updated)")
+	failOn := fs.String("fail-on", "", "exit non-zero when the diff has changes at this severity or worse (breaking|risky)")
+	fromGo := fs.String("from-go", "", "use the registered Go model set as the updated schema (base is --a when given, else the API)")
 
 	fs.Usage = func() {
 		fmt.Fprintf(Stdout, "Usage of %s:\n", c.Name())
@@ -34,6 +48,26 @@ func (c *DiffCommand) Run(args []string) int {
 		return 2
 	}
 
+	if *jsonOut {
+		*format = "json"
+	}
+	switch *format {
+	case "text", "json", "jsonpatch":
+	default:
+		fmt.Fprintf(Stderr, "invalid --format %q: want text, json or jsonpatch\n", *format)
+		return 2
+	}
+
+	var threshold schemas.Severity
+	if *failOn != "" {
+		sev, err := schemas.ParseSeverity(*failOn)
+		if err != nil || sev == schemas.SeveritySafe {
+			fmt.Fprintf(Stderr, "invalid --fail-on %q: want breaking or risky\n", *failOn)
+			return 2
+		}
+		threshold = sev
+	}
+
 	var (
 		baseSchema    onyx.Schema
 		updatedSchema onyx.Schema
@@ -42,7 +76,36 @@ func (c *DiffCommand) Run(args []string) int {
 		err           error
 	)
 
-	if *pathB != "" {
+	aSet := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "a" {
+			aSet = true
+		}
+	})
+
+	if *fromGo != "" {
+		updatedSource = fmt.Sprintf("updated=%s (Go models)", *fromGo)
+		updatedSchema, err = schemas.FromRegistered(*fromGo)
+		if err != nil {
+			fmt.Fprintf(Stderr, "failed to build schema from Go models: %v\n", err)
+			return 1
+		}
+		if aSet {
+			baseSource = fmt.Sprintf("base=%s", *pathA)
+			baseSchema, err = loadSchema(*pathA)
+			if err != nil {
+				fmt.Fprintf(Stderr, "failed to read schema --a: %v\n", err)
+				return 1
+			}
+		} else {
+			baseSource = apiSource(*databaseID)
+			baseSchema, err = fetchSchemaFromAPI(context.Background(), *databaseID)
+			if err != nil {
+				fmt.Fprintf(Stderr, "failed to fetch schema from API: %v\n", err)
+				return 1
+			}
+		}
+	} else if *pathB != "" {
 		baseSource = fmt.Sprintf("base=%s", *pathA)
 		baseSchema, err = loadSchema(*pathA)
 		if err != nil {
@@ -64,11 +127,7 @@ func (c *DiffCommand) Run(args []string) int {
 			return 1
 		}
 
-		if *databaseID != "" {
-			baseSource = fmt.Sprintf("base=API (database-id=%s)", *databaseID)
-		} else {
-			baseSource = "base=API (configured credentials)"
-		}
+		baseSource = apiSource(*databaseID)
 		baseSchema, err = fetchSchemaFromAPI(context.Background(), *databaseID)
 		if err != nil {
 			fmt.Fprintf(Stderr, "failed to fetch schema from API: %v\n", err)
@@ -78,14 +137,32 @@ func (c *DiffCommand) Run(args []string) int {
 
 	diff := schemas.DiffSchemas(baseSchema, updatedSchema)
 
-	if *jsonOut {
+	if *format == "jsonpatch" {
+		ops, err := schemas.DiffJSONPatch(baseSchema, updatedSchema)
+		if err != nil {
+			fmt.Fprintf(Stderr, "failed to build patch: %v\n", err)
+			return 1
+		}
+		if ops == nil {
+			ops = []schemas.PatchOperation{}
+		}
+		data, err := jsonMarshalIndent(ops, "", "  ")
+		if err != nil {
+			fmt.Fprintf(Stderr, "failed to render patch: %v\n", err)
+			return 1
+		}
+		fmt.Fprintln(Stdout, string(data))
+		return diffExitCode(diff, threshold)
+	}
+
+	if *format == "json" {
 		data, err := jsonMarshalIndent(diff, "", "  ")
 		if err != nil {
 			fmt.Fprintf(Stderr, "failed to render diff: %v\n", err)
 			return 1
 		}
 		fmt.Fprintln(Stdout, string(data))
-		return 0
+		return diffExitCode(diff, threshold)
 	}
 
 	fmt.Fprintf(Stdout, "Comparing schemas (%s, %s)\n", baseSource, updatedSource)
@@ -96,7 +173,27 @@ func (c *DiffCommand) Run(args []string) int {
 	} else {
 		fmt.Fprintln(Stdout, summary)
 	}
-	return 0
+	return diffExitCode(diff, threshold)
+}
+
+func apiSource(databaseID string) string {
+	if databaseID != "" {
+		return fmt.Sprintf("base=API (database-id=%s)", databaseID)
+	}
+	return "base=API (configured credentials)"
+}
+
+// diffExitCode applies the --fail-on gate. An empty threshold never fails.
+func diffExitCode(diff schemas.SchemaDiff, threshold schemas.Severity) int {
+	worst := diff.Severity()
+	if threshold == "" || worst == schemas.SeveritySafe || !worst.AtLeast(threshold) {
+		return 0
+	}
+	fmt.Fprintf(Stderr, "schema diff contains %s changes (--fail-on=%s)\n", worst, threshold)
+	if worst == schemas.SeverityBreaking {
+		return exitBreakingChanges
+	}
+	return exitRiskyChanges
 }
 
 func loadSchema(path string) (onyx.Schema, error) {
@@ -191,12 +288,66 @@ func summarizeDiff(diff schemas.SchemaDiff) string {
 				lines = append(lines, fmt.Sprintf("  - %s", r))
 			}
 		}
+		if len(td.AddedIndexes) > 0 {
+			lines = append(lines, "  Added indexes:")
+			for _, idx := range td.AddedIndexes {
+				lines = append(lines, fmt.Sprintf("  - %s", describeIndex(idx)))
+			}
+		}
+		if len(td.RemovedIndexes) > 0 {
+			lines = append(lines, "  Removed indexes:")
+			for _, idx := range td.RemovedIndexes {
+				lines = append(lines, fmt.Sprintf("  - %s", describeIndex(idx)))
+			}
+		}
+		if len(td.ModifiedIndexes) > 0 {
+			lines = append(lines, "  Modified indexes:")
+			for _, idx := range td.ModifiedIndexes {
+				lines = append(lines, fmt.Sprintf("  - %s: %s -> %s", idx.Name, describeIndex(idx.From), describeIndex(idx.To)))
+			}
+		}
+		if len(td.AddedTriggers) > 0 {
+			lines = append(lines, "  Added triggers:")
+			for _, trg := range td.AddedTriggers {
+				lines = append(lines, fmt.Sprintf("  - %s", describeTrigger(trg)))
+			}
+		}
+		if len(td.RemovedTriggers) > 0 {
+			lines = append(lines, "  Removed triggers:")
+			for _, trg := range td.RemovedTriggers {
+				lines = append(lines, fmt.Sprintf("  - %s", describeTrigger(trg)))
+			}
+		}
+		if len(td.ModifiedTriggers) > 0 {
+			lines = append(lines, "  Modified triggers:")
+			for _, trg := range td.ModifiedTriggers {
+				lines = append(lines, fmt.Sprintf("  - %s: %s", trg.Name, describeTriggerChange(trg.From, trg.To)))
+			}
+		}
+		if td.Partition != nil {
+			lines = append(lines, fmt.Sprintf("  Partition: %q -> %q", td.Partition.From, td.Partition.To))
+		}
+		if td.Meta != nil {
+			lines = append(lines, "  Meta changed")
+		}
 		if len(lines) > 0 {
 			section := fmt.Sprintf("Changes to table %s:\n%s", td.Name, strings.Join(lines, "\n"))
 			sections = append(sections, section)
 		}
 	}
 
+	if len(diff.Changes) > 0 {
+		lines := []string{fmt.Sprintf("Classification (overall: %s):", diff.Severity())}
+		for _, c := range diff.Changes {
+			target := c.Table
+			if c.Name != "" {
+				target += "." + c.Name
+			}
+			lines = append(lines, fmt.Sprintf("- [%s] %s %s: %s", c.Severity, c.Kind, target, c.Reason))
+		}
+		sections = append(sections, strings.Join(lines, "\n"))
+	}
+
 	return strings.Join(sections, "\n")
 }
 
@@ -208,6 +359,62 @@ func describeFieldChange(a, b onyx.Field) string {
 	if a.Nullable != b.Nullable {
 		parts = append(parts, fmt.Sprintf("nullable %t -> %t", a.Nullable, b.Nullable))
 	}
+	if a.Primary != b.Primary {
+		parts = append(parts, fmt.Sprintf("primaryKey %t -> %t", a.Primary, b.Primary))
+	}
+	if a.Unique != b.Unique {
+		parts = append(parts, fmt.Sprintf("unique %t -> %t", a.Unique, b.Unique))
+	}
+	if a.Generator != b.Generator {
+		parts = append(parts, fmt.Sprintf("generator %q -> %q", a.Generator, b.Generator))
+	}
+	if a.MaxSize != b.MaxSize {
+		parts = append(parts, fmt.Sprintf("maxSize %d -> %d", a.MaxSize, b.MaxSize))
+	}
+	if !reflect.DeepEqual(a.Default, b.Default) {
+		parts = append(parts, fmt.Sprintf("defaultValue %v -> %v", a.Default, b.Default))
+	}
+	return strings.Join(parts, "; ")
+}
+
+func describeIndex(idx onyx.Index) string {
+	desc := idx.Name
+	if idx.Type != "" {
+		desc += " (" + idx.Type + ")"
+	}
+	if len(idx.Fields) > 0 {
+		desc += " on " + strings.Join(idx.Fields, ", ")
+	}
+	if len(idx.Options) > 0 {
+		keys := make([]string, 0, len(idx.Options))
+		for k := range idx.Options {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		opts := make([]string, 0, len(keys))
+		for _, k := range keys {
+			opts = append(opts, fmt.Sprintf("%s=%v", k, idx.Options[k]))
+		}
+		desc += " [" + strings.Join(opts, ", ") + "]"
+	}
+	return desc
+}
+
+func describeTrigger(trg onyx.Trigger) string {
+	if trg.Event == "" {
+		return trg.Name
+	}
+	return fmt.Sprintf("%s (%s)", trg.Name, trg.Event)
+}
+
+func describeTriggerChange(a, b onyx.Trigger) string {
+	var parts []string
+	if a.Event != b.Event {
+		parts = append(parts, fmt.Sprintf("event %s -> %s", a.Event, b.Event))
+	}
+	if a.Trigger != b.Trigger {
+		parts = append(parts, "definition changed")
+	}
 	return strings.Join(parts, "; ")
 }
 
@@ -216,15 +423,8 @@ func describeResolverChange(a, b onyx.Resolver) string {
 	if a.Resolver != b.Resolver {
 		parts = append(parts, "definition changed")
 	}
-	if len(a.Meta) != len(b.Meta) {
+	if (len(a.Meta) > 0 || len(b.Meta) > 0) && !reflect.DeepEqual(a.Meta, b.Meta) {
 		parts = append(parts, "meta changed")
-	} else {
-		for k, v := range a.Meta {
-			if vb, ok := b.Meta[k]; !ok || vb != v {
-				parts = append(parts, "meta changed")
-				break
-			}
-		}
 	}
 	if len(parts) == 0 {
 		return "unchanged"
@@ -102,3 +102,40 @@ func TestSummarizeDiffFullSections(t *testing.T) {
 	assertContains("Added resolvers")
 	assertContains("Removed resolvers")
 }
+
+func TestSummarizeDiffTableAttributes(t *testing.T) {
+	base := onyx.Schema{Tables: []onyx.Table{{
+		Name:      "Doc",
+		Fields:    []onyx.Field{{Name: "id", Type: "String", Primary: true}, {Name: "slug", Type: "String"}},
+		Indexes:   []onyx.Index{{Name: "old"}, {Name: "search", Type: "LUCENE"}},
+		Triggers:  []onyx.Trigger{{Name: "audit", Event: "PreSave", Trigger: "a"}, {Name: "gone"}},
+		Partition: "region",
+	}}}
+	updated := onyx.Schema{Tables: []onyx.Table{{
+		Name:      "Doc",
+		Fields:    []onyx.Field{{Name: "id", Type: "String", Primary: true, Generator: "UUID"}, {Name: "slug", Type: "String", Unique: true, MaxSize: 32, Default: "x"}},
+		Indexes:   []onyx.Index{{Name: "search", Type: "LUCENE", Fields: []string{"slug"}, Options: map[string]any{"minScore": 0.5}}, {Name: "slug"}},
+		Triggers:  []onyx.Trigger{{Name: "audit", Event: "PostSave", Trigger: "b"}, {Name: "stamp", Event: "PreInsert"}},
+		Partition: "tenant",
+		Meta:      map[string]any{"owner": "docs"},
+	}}}
+
+	summary := summarizeDiff(schemas.DiffSchemas(base, updated))
+	for _, want := range []string{
+		`generator "None" -> "UUID"`,
+		"unique false -> true; maxSize 0 -> 32; defaultValue <nil> -> x",
+		"Added indexes:\n  - slug",
+		"Removed indexes:\n  - old",
+		"search: search (LUCENE) -> search (LUCENE) on slug [minScore=0.5]",
+		"Added triggers:\n  - stamp (PreInsert)",
+		"Removed triggers:\n  - gone",
+		"audit: event PreSave -> PostSave; definition changed",
+		`Partition: "region" -> "tenant"`,
+		"Meta changed",
+		"[breaking] partition_changed Doc",
+	} {
+		if !strings.Contains(summary, want) {
+			t.Fatalf("expected %q in summary, got:\n%s", want, summary)
+		}
+	}
+}
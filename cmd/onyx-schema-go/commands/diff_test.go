@@ -80,6 +80,28 @@ func TestDiffCommandJSON(t *testing.T) {
         }
       ]
     }
+  ],
+  "changes": [
+    {
+      "kind": "table_added",
+      "table": "events",
+      "severity": "safe",
+      "reason": "new table"
+    },
+    {
+      "kind": "field_added",
+      "table": "users",
+      "name": "email",
+      "severity": "breaking",
+      "reason": "new required field without a default; existing rows and older writers have no value"
+    },
+    {
+      "kind": "field_modified",
+      "table": "users",
+      "name": "id",
+      "severity": "risky",
+      "reason": "becomes nullable; readers may now see nulls"
+    }
   ]
 }
 `
@@ -209,3 +231,49 @@ func TestDiffCommandDefaultsToAPIWhenBMissing(t *testing.T) {
 		t.Fatalf("expected header showing API source, got: %s", out.String())
 	}
 }
+
+func TestDiffCommandFailOnGate(t *testing.T) {
+	tmpDir := t.TempDir()
+	base := filepath.Join(tmpDir, "base.json")
+	risky := filepath.Join(tmpDir, "risky.json")
+	breaking := filepath.Join(tmpDir, "breaking.json")
+	writeFile(t, base, `{"tables":[{"name":"users","fields":[{"name":"id","type":"string"},{"name":"name","type":"string"}]}]}`)
+	writeFile(t, risky, `{"tables":[{"name":"users","fields":[{"name":"id","type":"string"},{"name":"name","type":"string","nullable":true}]}]}`)
+	writeFile(t, breaking, `{"tables":[{"name":"users","fields":[{"name":"id","type":"string"}]}]}`)
+
+	var out bytes.Buffer
+	Stdout = &out
+	Stderr = &out
+	defer func() {
+		Stdout = os.Stdout
+		Stderr = os.Stderr
+	}()
+
+	cases := []struct {
+		updated string
+		args    []string
+		want    int
+	}{
+		{risky, nil, 0},
+		{risky, []string{"--fail-on", "breaking"}, 0},
+		{risky, []string{"--fail-on", "risky"}, exitRiskyChanges},
+		{breaking, []string{"--fail-on", "risky"}, exitBreakingChanges},
+		{breaking, []string{"--fail-on=breaking", "--json"}, exitBreakingChanges},
+		{base, []string{"--fail-on", "risky"}, 0},
+		{breaking, []string{"--fail-on", "safe"}, 2},
+		{breaking, []string{"--fail-on", "fatal"}, 2},
+	}
+	for _, tc := range cases {
+		out.Reset()
+		args := append([]string{"--a", base, "--b", tc.updated}, tc.args...)
+		if code := (&DiffCommand{}).Run(args); code != tc.want {
+			t.Fatalf("%v: expected exit %d, got %d: %s", args, tc.want, code, out.String())
+		}
+	}
+
+	out.Reset()
+	(&DiffCommand{}).Run([]string{"--a", base, "--b", breaking})
+	if !strings.Contains(out.String(), "Classification (overall: breaking)") || !strings.Contains(out.String(), "[breaking] field_removed users.name") {
+		t.Fatalf("expected classification in summary, got:\n%s", out.String())
+	}
+}
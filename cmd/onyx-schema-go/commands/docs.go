@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	schemas "github.com/OnyxDevTools/onyx-database-go/impl/schema"
+)
+
+// DocsCommand renders reference docs or an ER diagram for a schema.
+type DocsCommand struct{}
+
+func (c *DocsCommand) Name() string        { return "docs" }
+func (c *DocsCommand) Description() string { return "render schema docs or an ER diagram" }
+
+func (c *DocsCommand) Run(args []string) int {
+	fs := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	fs.SetOutput(Stderr)
+	schemaPath := fs.String("schema", defaultSchemaPath, "path to schema JSON file")
+	fromGo := fs.String("from-go", "", "document the registered Go model set instead of --schema")
+	format := fs.String("format", "markdown", "output format: "+strings.Join(schemas.DocFormats, ", "))
+	outPath := fs.String("out", "", "destination file path (defaults to stdout)")
+
+	fs.Usage = func() {
+		fmt.Fprintf(Stdout, "Usage of %s:\n", c.Name())
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if !containsString(schemas.DocFormats, *format) {
+		fmt.Fprintf(Stderr, "invalid --format %q: want %s\n", *format, strings.Join(schemas.DocFormats, ", "))
+		return 2
+	}
+
+	schema, code := readSchemaSource(*schemaPath, *fromGo)
+	if code != 0 {
+		return code
+	}
+
+	rendered, err := schemas.RenderDocs(schema, *format)
+	if err != nil {
+		fmt.Fprintf(Stderr, "failed to render docs: %v\n", err)
+		return 1
+	}
+
+	if *outPath == "" {
+		if _, err := fmt.Fprint(Stdout, rendered); err != nil {
+			fmt.Fprintf(Stderr, "failed to write output: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	if err := os.WriteFile(*outPath, []byte(rendered), 0o644); err != nil {
+		fmt.Fprintf(Stderr, "failed to write output file: %v\n", err)
+		return 1
+	}
+
+	fmt.Fprintf(Stdout, "Wrote %s docs to %s\n", *format, *outPath)
+	return 0
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
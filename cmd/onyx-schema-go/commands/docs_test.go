@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDocsCommandFormats(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schema.json")
+	writeFile(t, path, `{"tables": [
+  {"name": "User", "fields": [{"name": "id", "type": "String", "primaryKey": true}]},
+  {"name": "UserProfile", "fields": [{"name": "id", "type": "String", "primaryKey": true}, {"name": "userId", "type": "String"}]}
+]}`)
+
+	for format, want := range map[string]string{
+		"markdown": "| userId | String | no | → [User](#user) |",
+		"mermaid":  `UserProfile }o--|| User : "userId"`,
+		"dot":      `"UserProfile" -> "User" [label="userId", style=dashed];`,
+	} {
+		out := captureOutput(t)
+		if code := (&DocsCommand{}).Run([]string{"--schema", path, "--format", format}); code != 0 {
+			t.Fatalf("%s: expected exit 0, got %d: %s", format, code, out.String())
+		}
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("%s: expected %q in output:\n%s", format, want, out.String())
+		}
+	}
+}
+
+func TestDocsCommandWritesFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "schema.json")
+	writeFile(t, path, `{"tables": [{"name": "User", "fields": [{"name": "id", "type": "String", "primaryKey": true}]}]}`)
+	outPath := filepath.Join(dir, "SCHEMA.md")
+	out := captureOutput(t)
+
+	if code := (&DocsCommand{}).Run([]string{"--schema", path, "--out", outPath}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("read docs: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "# Schema\n") || !strings.Contains(out.String(), "Wrote markdown docs to") {
+		t.Fatalf("unexpected docs %q, output %q", data, out.String())
+	}
+}
+
+func TestDocsCommandErrors(t *testing.T) {
+	captureOutput(t)
+	if code := (&DocsCommand{}).Run([]string{"--format", "html"}); code != 2 {
+		t.Fatalf("expected exit 2 for unknown format, got %d", code)
+	}
+	if code := (&DocsCommand{}).Run([]string{"--schema", filepath.Join(t.TempDir(), "missing.json")}); code != 1 {
+		t.Fatalf("expected exit 1 for missing schema, got %d", code)
+	}
+	if code := (&DocsCommand{}).Run([]string{"--bogus"}); code != 2 {
+		t.Fatalf("expected exit 2 for bad flag, got %d", code)
+	}
+}
@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	schemas "github.com/OnyxDevTools/onyx-database-go/impl/schema"
+)
+
+// ExportCommand writes table definitions as JSON Schema or OpenAPI.
+type ExportCommand struct{}
+
+func (c *ExportCommand) Name() string        { return "export" }
+func (c *ExportCommand) Description() string { return "export tables as JSON Schema or OpenAPI" }
+
+func (c *ExportCommand) Run(args []string) int {
+	fs := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	fs.SetOutput(Stderr)
+	schemaPath := fs.String("schema", defaultSchemaPath, "path to schema JSON file")
+	fromGo := fs.String("from-go", "", "export the registered Go model set instead of --schema")
+	format := fs.String("format", "jsonschema", "output format: "+strings.Join(schemas.ExportFormats, ", "))
+	outPath := fs.String("out", "", "destination file path (defaults to stdout)")
+	title := fs.String("title", "", "document title (default \"Onyx schema\")")
+	version := fs.String("version", "", "OpenAPI info.version (default \"1.0.0\")")
+
+	fs.Usage = func() {
+		fmt.Fprintf(Stdout, "Usage of %s:\n", c.Name())
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if !containsString(schemas.ExportFormats, *format) {
+		fmt.Fprintf(Stderr, "invalid --format %q: want %s\n", *format, strings.Join(schemas.ExportFormats, ", "))
+		return 2
+	}
+
+	schema, code := readSchemaSource(*schemaPath, *fromGo)
+	if code != 0 {
+		return code
+	}
+
+	doc, err := schemas.Export(schema, *format, schemas.ExportOptions{Title: *title, Version: *version})
+	if err != nil {
+		fmt.Fprintf(Stderr, "failed to export schema: %v\n", err)
+		return 1
+	}
+
+	if *outPath == "" {
+		data, err := jsonMarshalIndent(doc, "", "  ")
+		if err != nil {
+			fmt.Fprintf(Stderr, "failed to encode %s document: %v\n", *format, err)
+			return 1
+		}
+		fmt.Fprintln(Stdout, string(data))
+		return 0
+	}
+
+	if err := writeJSONFile(*outPath, doc); err != nil {
+		fmt.Fprintf(Stderr, "failed to write output file: %v\n", err)
+		return 1
+	}
+	fmt.Fprintf(Stdout, "Wrote %s document to %s\n", *format, *outPath)
+	return 0
+}
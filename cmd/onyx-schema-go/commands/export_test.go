@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExportCommand(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "schema.json")
+	writeFile(t, path, `{"tables": [{"name": "User", "fields": [
+  {"name": "id", "type": "String", "primaryKey": true},
+  {"name": "lastLoginAt", "type": "Timestamp", "nullable": true}
+]}]}`)
+
+	out := captureOutput(t)
+	if code := (&ExportCommand{}).Run([]string{"--schema", path}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(out.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out.String())
+	}
+	user := doc["$defs"].(map[string]any)["User"].(map[string]any)
+	login := user["properties"].(map[string]any)["lastLoginAt"].(map[string]any)
+	if login["format"] != "date-time" || len(user["required"].([]any)) != 1 {
+		t.Fatalf("unexpected User definition: %v", user)
+	}
+
+	outPath := filepath.Join(dir, "openapi", "schema.json")
+	if code := (&ExportCommand{}).Run([]string{"--schema", path, "--format", "openapi", "--title", "Users", "--out", outPath}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("read export: %v", err)
+	}
+	doc = nil
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("invalid OpenAPI JSON: %v", err)
+	}
+	if doc["openapi"] != "3.1.0" || doc["info"].(map[string]any)["title"] != "Users" {
+		t.Fatalf("unexpected OpenAPI document: %v", doc)
+	}
+}
+
+func TestExportCommandErrors(t *testing.T) {
+	captureOutput(t)
+	if code := (&ExportCommand{}).Run([]string{"--format", "xml"}); code != 2 {
+		t.Fatalf("expected exit 2 for unknown format, got %d", code)
+	}
+	if code := (&ExportCommand{}).Run([]string{"--schema", filepath.Join(t.TempDir(), "missing.json")}); code != 1 {
+		t.Fatalf("expected exit 1 for missing schema, got %d", code)
+	}
+	if code := (&ExportCommand{}).Run([]string{"--bogus"}); code != 2 {
+		t.Fatalf("expected exit 2 for bad flag, got %d", code)
+	}
+}
@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	schemas "github.com/OnyxDevTools/onyx-database-go/impl/schema"
+	"github.com/OnyxDevTools/onyx-database-go/onyx"
+)
+
+type fromGoUser struct {
+	_     struct{} `onyx:"table=User"`
+	ID    string   `json:"id" onyx:"primaryKey"`
+	Email string   `json:"email" onyx:"unique"`
+}
+
+func init() {
+	schemas.RegisterModels("from-go-test", fromGoUser{})
+}
+
+func TestValidateCommandFromGo(t *testing.T) {
+	out := captureOutput(t)
+	if code := (&ValidateCommand{}).Run([]string{"--from-go", "from-go-test"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	if !strings.Contains(out.String(), "Schema is valid") {
+		t.Fatalf("expected success message, got: %s", out.String())
+	}
+
+	out.Reset()
+	if code := (&ValidateCommand{}).Run([]string{"--from-go", "unknown-set"}); code != 1 {
+		t.Fatalf("expected exit 1 for an unregistered set, got %d", code)
+	}
+	if !strings.Contains(out.String(), "from-go-test") {
+		t.Fatalf("expected registered sets in error, got: %s", out.String())
+	}
+}
+
+func TestPublishCommandFromGo(t *testing.T) {
+	out := captureOutput(t)
+	stub := &stubClient{}
+	original := initSchemaClient
+	initSchemaClient = func(ctx context.Context, databaseID string) (onyx.Client, error) { return stub, nil }
+	defer func() { initSchemaClient = original }()
+
+	if code := (&PublishCommand{}).Run([]string{"--from-go", "from-go-test"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	if !stub.publishCalled || len(stub.schema.Tables) != 1 || stub.schema.Tables[0].Name != "User" {
+		t.Fatalf("expected the Go models to be published, got %+v", stub.schema)
+	}
+}
+
+func TestDiffCommandFromGo(t *testing.T) {
+	base := filepath.Join(t.TempDir(), "base.json")
+	writeFile(t, base, `{"tables":[{"name":"User","fields":[{"name":"id","type":"String","primaryKey":true}]}]}`)
+
+	out := captureOutput(t)
+	if code := (&DiffCommand{}).Run([]string{"--from-go", "from-go-test", "--a", base}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	text := out.String()
+	if !strings.Contains(text, "updated=from-go-test (Go models)") || !strings.Contains(text, "email") {
+		t.Fatalf("expected the Go models to be diffed against --a, got: %s", text)
+	}
+
+	original := fetchSchemaFromAPI
+	var fetched bool
+	fetchSchemaFromAPI = func(ctx context.Context, databaseID string) (onyx.Schema, error) {
+		fetched = true
+		return onyx.Schema{}, nil
+	}
+	defer func() { fetchSchemaFromAPI = original }()
+
+	out.Reset()
+	if code := (&DiffCommand{}).Run([]string{"--from-go", "from-go-test"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	if !fetched || !strings.Contains(out.String(), "base=API") {
+		t.Fatalf("expected the base to come from the API, got: %s", out.String())
+	}
+}
@@ -100,6 +100,9 @@ type stubClient struct {
 	publishCalled bool
 	schemaErr     error
 	publishErr    error
+	updates       []onyx.Schema
+	revisions     []onyx.SchemaRevision
+	historyErr    error
 }
 
 func (s *stubClient) From(table string) onyx.Query                            { return nil }
@@ -112,6 +115,13 @@ func (s *stubClient) Delete(ctx context.Context, table, id string) error { retur
 func (s *stubClient) BatchSave(ctx context.Context, table string, entities []any, batchSize int) error {
 	return nil
 }
+func (s *stubClient) Patch(ctx context.Context, table, id string, updates map[string]any) (onyx.PatchResult, error) {
+	return onyx.PatchResult{}, nil
+}
+func (s *stubClient) PatchMany(ctx context.Context, table string, ops []onyx.PatchOp) ([]onyx.PatchResult, error) {
+	return nil, nil
+}
+func (s *stubClient) UnitOfWork() onyx.UnitOfWork { return nil }
 func (s *stubClient) Schema(ctx context.Context) (onyx.Schema, error) {
 	if s.schemaErr != nil {
 		return onyx.Schema{}, s.schemaErr
@@ -124,9 +134,13 @@ func (s *stubClient) GetSchema(ctx context.Context, tables []string) (onyx.Schem
 func (s *stubClient) GetSchemaHistory(ctx context.Context) ([]onyx.Schema, error) {
 	return nil, nil
 }
+func (s *stubClient) GetSchemaRevisions(ctx context.Context) ([]onyx.SchemaRevision, error) {
+	return s.revisions, s.historyErr
+}
 func (s *stubClient) UpdateSchema(ctx context.Context, schema onyx.Schema, publish bool) error {
 	s.publishCalled = publish
 	s.schema = schema
+	s.updates = append(s.updates, schema)
 	return nil
 }
 func (s *stubClient) ValidateSchema(ctx context.Context, schema onyx.Schema) error { return nil }
@@ -149,6 +163,7 @@ func (s *stubClient) PutSecret(ctx context.Context, secret onyx.Secret) (onyx.Se
 	return secret, nil
 }
 func (s *stubClient) DeleteSecret(ctx context.Context, key string) error { return nil }
+func (s *stubClient) Stats() onyx.ClientStats                            { return onyx.ClientStats{} }
 func (s *stubClient) Chat(ctx context.Context, req onyx.AIChatCompletionRequest) (onyx.AIChatCompletionResponse, error) {
 	return onyx.AIChatCompletionResponse{}, nil
 }
@@ -0,0 +1,316 @@
+package commands
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	schemas "github.com/OnyxDevTools/onyx-database-go/impl/schema"
+	"github.com/OnyxDevTools/onyx-database-go/onyx"
+)
+
+// HistoryCommand browses published schema revisions and rolls back to one of them.
+type HistoryCommand struct{}
+
+func (c *HistoryCommand) Name() string { return "history" }
+func (c *HistoryCommand) Description() string {
+	return "list, show, diff or roll back schema revisions"
+}
+
+func (c *HistoryCommand) Run(args []string) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" {
+		c.printUsage()
+		if len(args) == 0 {
+			return 2
+		}
+		return 0
+	}
+	switch args[0] {
+	case "list":
+		return c.runList(args[1:])
+	case "show":
+		return c.runShow(args[1:])
+	case "diff":
+		return c.runDiff(args[1:])
+	case "rollback":
+		return c.runRollback(args[1:])
+	default:
+		fmt.Fprintf(Stderr, "unknown history subcommand %q\n", args[0])
+		c.printUsage()
+		return 2
+	}
+}
+
+func (c *HistoryCommand) printUsage() {
+	fmt.Fprintln(Stdout, "Usage: onyx-schema-go history <list|show|diff|rollback> [options]")
+	fmt.Fprintln(Stdout)
+	fmt.Fprintln(Stdout, "  list                   list published revisions, oldest first")
+	fmt.Fprintln(Stdout, "  show <rev>             print the schema of a revision")
+	fmt.Fprintln(Stdout, "  diff <revA> <revB>     compare two revisions")
+	fmt.Fprintln(Stdout, "  rollback <rev>         republish a revision after confirming the diff from the live schema")
+	fmt.Fprintln(Stdout)
+	fmt.Fprintln(Stdout, "A revision is its id from history list, or latest.")
+}
+
+// historyEntry is the history list --json shape: revision metadata without the schema body.
+type historyEntry struct {
+	Revision  string    `json:"revision"`
+	Author    string    `json:"author,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	Tables    []string  `json:"tables"`
+}
+
+func (c *HistoryCommand) runList(args []string) int {
+	fs := newHistoryFlagSet("list")
+	databaseID := fs.String("database-id", "", "database id (optional if configured)")
+	jsonOut := fs.Bool("json", false, "emit the revisions as JSON")
+
+	if _, code := parseHistoryArgs(fs, args, 0); code != 0 {
+		return code
+	}
+
+	_, revisions, code := loadRevisions(context.Background(), *databaseID)
+	if code != 0 {
+		return code
+	}
+
+	if *jsonOut {
+		entries := make([]historyEntry, 0, len(revisions))
+		for _, rev := range revisions {
+			entries = append(entries, historyEntry{
+				Revision:  rev.Revision,
+				Author:    rev.Author,
+				CreatedAt: rev.CreatedAt,
+				Tables:    tableNames(rev.Schema),
+			})
+		}
+		data, err := jsonMarshalIndent(entries, "", "  ")
+		if err != nil {
+			fmt.Fprintf(Stderr, "failed to render history: %v\n", err)
+			return 1
+		}
+		fmt.Fprintln(Stdout, string(data))
+		return 0
+	}
+
+	if len(revisions) == 0 {
+		fmt.Fprintln(Stdout, "No schema revisions.")
+		return 0
+	}
+	fmt.Fprintf(Stdout, "%-12s %-22s %-20s %s\n", "REVISION", "CREATED", "AUTHOR", "TABLES")
+	for _, rev := range revisions {
+		fmt.Fprintf(Stdout, "%-12s %-22s %-20s %d\n", rev.Revision, formatRevisionTime(rev.CreatedAt), orDash(rev.Author), len(rev.Schema.Tables))
+	}
+	return 0
+}
+
+func (c *HistoryCommand) runShow(args []string) int {
+	fs := newHistoryFlagSet("show <rev>")
+	databaseID := fs.String("database-id", "", "database id (optional if configured)")
+	outPath := fs.String("out", "", "write the schema JSON to this path instead of stdout")
+
+	revs, code := parseHistoryArgs(fs, args, 1)
+	if code != 0 {
+		return code
+	}
+
+	_, revisions, code := loadRevisions(context.Background(), *databaseID)
+	if code != 0 {
+		return code
+	}
+	rev, ok := findRevision(revisions, revs[0])
+	if !ok {
+		return 1
+	}
+
+	if *outPath != "" {
+		if err := writeJSONFile(*outPath, onyx.NormalizeSchema(rev.Schema)); err != nil {
+			fmt.Fprintf(Stderr, "failed to write schema: %v\n", err)
+			return 1
+		}
+		fmt.Fprintf(Stdout, "Revision %s written to %s\n", rev.Revision, *outPath)
+		return 0
+	}
+
+	data, err := jsonMarshalIndent(onyx.NormalizeSchema(rev.Schema), "", "  ")
+	if err != nil {
+		fmt.Fprintf(Stderr, "failed to render schema: %v\n", err)
+		return 1
+	}
+	fmt.Fprintln(Stdout, string(data))
+	return 0
+}
+
+func (c *HistoryCommand) runDiff(args []string) int {
+	fs := newHistoryFlagSet("diff <revA> <revB>")
+	databaseID := fs.String("database-id", "", "database id (optional if configured)")
+	jsonOut := fs.Bool("json", false, "emit machine-readable JSON diff")
+
+	revs, code := parseHistoryArgs(fs, args, 2)
+	if code != 0 {
+		return code
+	}
+
+	_, revisions, code := loadRevisions(context.Background(), *databaseID)
+	if code != 0 {
+		return code
+	}
+	a, ok := findRevision(revisions, revs[0])
+	if !ok {
+		return 1
+	}
+	b, ok := findRevision(revisions, revs[1])
+	if !ok {
+		return 1
+	}
+
+	diff := schemas.DiffSchemas(a.Schema, b.Schema)
+	if *jsonOut {
+		data, err := jsonMarshalIndent(diff, "", "  ")
+		if err != nil {
+			fmt.Fprintf(Stderr, "failed to render diff: %v\n", err)
+			return 1
+		}
+		fmt.Fprintln(Stdout, string(data))
+		return 0
+	}
+
+	fmt.Fprintf(Stdout, "Comparing revisions (%s, %s)\n", a.Revision, b.Revision)
+	if summary := summarizeDiff(diff); summary != "" {
+		fmt.Fprintln(Stdout, summary)
+	} else {
+		fmt.Fprintln(Stdout, "Schemas are identical.")
+	}
+	return 0
+}
+
+func (c *HistoryCommand) runRollback(args []string) int {
+	fs := newHistoryFlagSet("rollback <rev>")
+	databaseID := fs.String("database-id", "", "database id (optional if configured)")
+	yes := fs.Bool("yes", false, "roll back without asking for confirmation")
+
+	revs, code := parseHistoryArgs(fs, args, 1)
+	if code != 0 {
+		return code
+	}
+
+	ctx := context.Background()
+	client, revisions, code := loadRevisions(ctx, *databaseID)
+	if code != 0 {
+		return code
+	}
+	rev, ok := findRevision(revisions, revs[0])
+	if !ok {
+		return 1
+	}
+
+	live, err := client.Schema(ctx)
+	if err != nil {
+		fmt.Fprintf(Stderr, "failed to fetch schema from API: %v\n", err)
+		return 1
+	}
+
+	summary := summarizeDiff(schemas.DiffSchemas(live, rev.Schema))
+	if summary == "" {
+		fmt.Fprintf(Stdout, "Schema already matches revision %s.\n", rev.Revision)
+		return 0
+	}
+
+	fmt.Fprintf(Stdout, "Comparing schemas (live, revision %s)\n", rev.Revision)
+	fmt.Fprintln(Stdout, summary)
+	if !*yes && !confirm(fmt.Sprintf("Republish revision %s?", rev.Revision)) {
+		fmt.Fprintln(Stdout, "Aborted.")
+		return 1
+	}
+
+	if err := client.PublishSchema(ctx, rev.Schema); err != nil {
+		fmt.Fprintf(Stderr, "failed to publish revision %s: %v\n", rev.Revision, err)
+		return 1
+	}
+	fmt.Fprintf(Stdout, "Rolled back to revision %s.\n", rev.Revision)
+	return 0
+}
+
+func newHistoryFlagSet(usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet("history "+usage, flag.ContinueOnError)
+	fs.SetOutput(Stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(Stdout, "Usage of history %s:\n", usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseHistoryArgs parses flags placed before, between or after the positional revision
+// arguments and checks that exactly want positionals were given.
+func parseHistoryArgs(fs *flag.FlagSet, args []string, want int) ([]string, int) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, 2
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	if len(positional) != want {
+		fmt.Fprintf(Stderr, "expected %d revision arguments, got %d\n", want, len(positional))
+		fs.Usage()
+		return nil, 2
+	}
+	return positional, 0
+}
+
+func loadRevisions(ctx context.Context, databaseID string) (onyx.Client, []onyx.SchemaRevision, int) {
+	client, err := initSchemaClient(ctx, databaseID)
+	if err != nil {
+		fmt.Fprintln(Stderr, err)
+		return nil, nil, 1
+	}
+	revisions, err := client.GetSchemaRevisions(ctx)
+	if err != nil {
+		fmt.Fprintf(Stderr, "failed to fetch schema history: %v\n", err)
+		return nil, nil, 1
+	}
+	return client, revisions, 0
+}
+
+// findRevision looks up a revision by id, or the newest one for "latest". It prints a failure
+// when there is no match.
+func findRevision(revisions []onyx.SchemaRevision, id string) (onyx.SchemaRevision, bool) {
+	if id == "latest" && len(revisions) > 0 {
+		return revisions[len(revisions)-1], true
+	}
+	for _, rev := range revisions {
+		if rev.Revision == id {
+			return rev, true
+		}
+	}
+	fmt.Fprintf(Stderr, "unknown revision %q; run history list to see available revisions\n", id)
+	return onyx.SchemaRevision{}, false
+}
+
+func tableNames(s onyx.Schema) []string {
+	names := make([]string, 0, len(s.Tables))
+	for _, t := range onyx.NormalizeSchema(s).Tables {
+		names = append(names, t.Name)
+	}
+	return names
+}
+
+func formatRevisionTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
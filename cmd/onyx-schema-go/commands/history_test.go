@@ -0,0 +1,190 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/OnyxDevTools/onyx-database-go/onyx"
+)
+
+func historyStub(t *testing.T) *stubClient {
+	t.Helper()
+	id := onyx.Field{Name: "id", Type: "String", Primary: true}
+	stub := &stubClient{
+		schema: onyx.Schema{Tables: []onyx.Table{{Name: "User", Fields: []onyx.Field{id, {Name: "email", Type: "String"}}}}},
+		revisions: []onyx.SchemaRevision{
+			{Revision: "r1", Author: "ada", CreatedAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+				Schema: onyx.Schema{Tables: []onyx.Table{{Name: "User", Fields: []onyx.Field{id}}}}},
+			{Revision: "r2",
+				Schema: onyx.Schema{Tables: []onyx.Table{{Name: "User", Fields: []onyx.Field{id, {Name: "email", Type: "String"}}}}}},
+		},
+	}
+	original := initSchemaClient
+	initSchemaClient = func(ctx context.Context, databaseID string) (onyx.Client, error) { return stub, nil }
+	t.Cleanup(func() { initSchemaClient = original })
+	return stub
+}
+
+func TestHistoryList(t *testing.T) {
+	historyStub(t)
+	out := captureOutput(t)
+
+	if code := (&HistoryCommand{}).Run([]string{"list"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "REVISION") {
+		t.Fatalf("unexpected list output:\n%s", out.String())
+	}
+	if fields := strings.Fields(lines[1]); strings.Join(fields, " ") != "r1 2024-05-01T10:00:00Z ada 1" {
+		t.Fatalf("unexpected first row %q", lines[1])
+	}
+	if fields := strings.Fields(lines[2]); strings.Join(fields, " ") != "r2 - - 1" {
+		t.Fatalf("unexpected second row %q", lines[2])
+	}
+}
+
+func TestHistoryListJSON(t *testing.T) {
+	historyStub(t)
+	out := captureOutput(t)
+
+	if code := (&HistoryCommand{}).Run([]string{"list", "--json"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	var entries []historyEntry
+	if err := json.Unmarshal(out.Bytes(), &entries); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out.String())
+	}
+	if len(entries) != 2 || entries[0].Revision != "r1" || entries[0].Author != "ada" || entries[1].Tables[0] != "User" {
+		t.Fatalf("unexpected entries: %+v", entries)
+	}
+}
+
+func TestHistoryShowWritesRevision(t *testing.T) {
+	historyStub(t)
+	out := captureOutput(t)
+	path := filepath.Join(t.TempDir(), "r1.json")
+
+	if code := (&HistoryCommand{}).Run([]string{"show", "r1", "--out", path}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	schema, err := loadSchema(path)
+	if err != nil {
+		t.Fatalf("read revision: %v", err)
+	}
+	if len(schema.Tables) != 1 || len(schema.Tables[0].Fields) != 1 {
+		t.Fatalf("unexpected schema: %+v", schema)
+	}
+}
+
+func TestHistoryShowLatestPrints(t *testing.T) {
+	historyStub(t)
+	out := captureOutput(t)
+
+	if code := (&HistoryCommand{}).Run([]string{"show", "latest"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	if !strings.Contains(out.String(), `"email"`) {
+		t.Fatalf("expected latest revision, got:\n%s", out.String())
+	}
+}
+
+func TestHistoryDiff(t *testing.T) {
+	historyStub(t)
+	out := captureOutput(t)
+
+	if code := (&HistoryCommand{}).Run([]string{"diff", "r1", "r2"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	text := out.String()
+	if !strings.Contains(text, "Comparing revisions (r1, r2)") || !strings.Contains(text, "email") {
+		t.Fatalf("unexpected diff output:\n%s", text)
+	}
+}
+
+func TestHistoryRollbackConfirms(t *testing.T) {
+	stub := historyStub(t)
+	out := captureOutput(t)
+	Stdin = strings.NewReader("n\n")
+
+	if code := (&HistoryCommand{}).Run([]string{"rollback", "r1"}); code != 1 {
+		t.Fatalf("expected exit 1 when declined, got %d", code)
+	}
+	if stub.publishCalled || !strings.Contains(out.String(), "Aborted.") || !strings.Contains(out.String(), "email") {
+		t.Fatalf("expected diff and abort without publishing:\n%s", out.String())
+	}
+
+	Stdin = strings.NewReader("y\n")
+	if code := (&HistoryCommand{}).Run([]string{"rollback", "r1"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	if !stub.publishCalled || len(stub.schema.Tables[0].Fields) != 1 {
+		t.Fatalf("expected revision r1 to be published, got %+v", stub.schema)
+	}
+	if !strings.Contains(out.String(), "Rolled back to revision r1.") {
+		t.Fatalf("unexpected output:\n%s", out.String())
+	}
+}
+
+func TestHistoryRollbackNoChanges(t *testing.T) {
+	stub := historyStub(t)
+	out := captureOutput(t)
+
+	if code := (&HistoryCommand{}).Run([]string{"rollback", "--yes", "r2"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	if stub.publishCalled || !strings.Contains(out.String(), "already matches revision r2") {
+		t.Fatalf("expected no publish:\n%s", out.String())
+	}
+}
+
+func TestHistoryErrors(t *testing.T) {
+	stub := historyStub(t)
+	captureOutput(t)
+
+	cases := []struct {
+		args []string
+		code int
+	}{
+		{nil, 2},
+		{[]string{"bogus"}, 2},
+		{[]string{"show"}, 2},
+		{[]string{"diff", "r1"}, 2},
+		{[]string{"list", "--bogus"}, 2},
+		{[]string{"show", "r9"}, 1},
+		{[]string{"diff", "r1", "r9"}, 1},
+		{[]string{"--help"}, 0},
+	}
+	for _, tc := range cases {
+		if code := (&HistoryCommand{}).Run(tc.args); code != tc.code {
+			t.Fatalf("%v: expected exit %d, got %d", tc.args, tc.code, code)
+		}
+	}
+
+	stub.historyErr = errors.New("boom")
+	if code := (&HistoryCommand{}).Run([]string{"list"}); code != 1 {
+		t.Fatalf("expected exit 1 on history error, got %d", code)
+	}
+
+	initSchemaClient = func(ctx context.Context, databaseID string) (onyx.Client, error) {
+		return nil, errors.New("no credentials")
+	}
+	if code := (&HistoryCommand{}).Run([]string{"list"}); code != 1 {
+		t.Fatalf("expected exit 1 on client error, got %d", code)
+	}
+}
+
+func TestHistoryListEmpty(t *testing.T) {
+	stub := historyStub(t)
+	stub.revisions = nil
+	out := captureOutput(t)
+
+	if code := (&HistoryCommand{}).Run([]string{"list"}); code != 0 || !strings.Contains(out.String(), "No schema revisions.") {
+		t.Fatalf("unexpected result %d: %s", code, out.String())
+	}
+}
@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	schemas "github.com/OnyxDevTools/onyx-database-go/impl/schema"
+)
+
+// ImportSQLCommand converts SQL DDL into a schema JSON file.
+type ImportSQLCommand struct{}
+
+func (c *ImportSQLCommand) Name() string { return "import-sql" }
+func (c *ImportSQLCommand) Description() string {
+	return "convert CREATE TABLE/INDEX DDL into a schema"
+}
+
+func (c *ImportSQLCommand) Run(args []string) int {
+	fs := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	fs.SetOutput(Stderr)
+	file := fs.String("file", "", "path to the SQL DDL file (required)")
+	outPath := fs.String("out", "", "destination schema path (defaults to stdout)")
+	strict := fs.Bool("strict", false, "exit 1 when any construct could not be imported")
+
+	fs.Usage = func() {
+		fmt.Fprintf(Stdout, "Usage of %s:\n", c.Name())
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *file == "" {
+		fmt.Fprintln(Stderr, "--file is required")
+		fs.Usage()
+		return 2
+	}
+
+	src, err := os.ReadFile(*file)
+	if err != nil {
+		fmt.Fprintf(Stderr, "failed to read SQL file: %v\n", err)
+		return 1
+	}
+	schema, warnings, err := schemas.ImportSQL(string(src))
+	if err != nil {
+		fmt.Fprintf(Stderr, "failed to parse %s: %v\n", *file, err)
+		return 1
+	}
+	for _, w := range warnings {
+		fmt.Fprintf(Stderr, "warning: %s\n", w)
+	}
+	if len(schema.Tables) == 0 {
+		fmt.Fprintf(Stderr, "no CREATE TABLE statements found in %s\n", *file)
+		return 1
+	}
+
+	if *outPath == "" {
+		data, err := jsonMarshalIndent(schema, "", "  ")
+		if err != nil {
+			fmt.Fprintf(Stderr, "failed to encode schema: %v\n", err)
+			return 1
+		}
+		fmt.Fprintln(Stdout, string(data))
+	} else {
+		if err := writeJSONFile(*outPath, schema); err != nil {
+			fmt.Fprintf(Stderr, "failed to write output file: %v\n", err)
+			return 1
+		}
+		fmt.Fprintf(Stdout, "Imported %d tables into %s\n", len(schema.Tables), *outPath)
+	}
+
+	if *strict && len(warnings) > 0 {
+		fmt.Fprintf(Stderr, "%d constructs were not imported\n", len(warnings))
+		return 1
+	}
+	return 0
+}
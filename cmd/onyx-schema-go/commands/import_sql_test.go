@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/OnyxDevTools/onyx-database-go/onyx"
+)
+
+const importSQLFixture = `CREATE TABLE roles (id serial PRIMARY KEY, name varchar(40) NOT NULL);
+CREATE TABLE users (
+  id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  role_id int REFERENCES roles(id),
+  last_seen time
+);
+`
+
+func TestImportSQLCommand(t *testing.T) {
+	dir := t.TempDir()
+	sqlPath := filepath.Join(dir, "schema.sql")
+	writeFile(t, sqlPath, importSQLFixture)
+
+	out := captureOutput(t)
+	if code := (&ImportSQLCommand{}).Run([]string{"--file", sqlPath}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	text := out.String()
+	if !strings.Contains(text, "warning: line 5: column users.last_seen: time of day imported as String") {
+		t.Fatalf("expected warning on stderr:\n%s", text)
+	}
+	var schema onyx.Schema
+	if err := json.Unmarshal([]byte(text[strings.Index(text, "{"):]), &schema); err != nil {
+		t.Fatalf("invalid schema JSON: %v\n%s", err, text)
+	}
+	if len(schema.Tables) != 2 || len(schema.Tables[1].Resolvers) != 1 || schema.Tables[1].Resolvers[0].Name != "role" {
+		t.Fatalf("unexpected schema: %+v", schema)
+	}
+
+	outPath := filepath.Join(dir, "out", "onyx.schema.json")
+	if code := (&ImportSQLCommand{}).Run([]string{"--file", sqlPath, "--out", outPath}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	written, err := loadSchema(outPath)
+	if err != nil {
+		t.Fatalf("read imported schema: %v", err)
+	}
+	if len(written.Tables) != 2 {
+		t.Fatalf("unexpected written schema: %+v", written)
+	}
+
+	if code := (&ImportSQLCommand{}).Run([]string{"--file", sqlPath, "--out", outPath, "--strict"}); code != 1 {
+		t.Fatalf("expected exit 1 with --strict and warnings, got %d", code)
+	}
+}
+
+func TestImportSQLCommandErrors(t *testing.T) {
+	dir := t.TempDir()
+	captureOutput(t)
+
+	bad := filepath.Join(dir, "bad.sql")
+	writeFile(t, bad, "CREATE TABLE t (name text DEFAULT 'oops);")
+	empty := filepath.Join(dir, "empty.sql")
+	writeFile(t, empty, "CREATE VIEW v AS SELECT 1;")
+
+	cases := []struct {
+		args []string
+		code int
+	}{
+		{nil, 2},
+		{[]string{"--bogus"}, 2},
+		{[]string{"--file", filepath.Join(dir, "missing.sql")}, 1},
+		{[]string{"--file", bad}, 1},
+		{[]string{"--file", empty}, 1},
+	}
+	for _, tc := range cases {
+		if code := (&ImportSQLCommand{}).Run(tc.args); code != tc.code {
+			t.Fatalf("%v: expected exit %d, got %d", tc.args, tc.code, code)
+		}
+	}
+}
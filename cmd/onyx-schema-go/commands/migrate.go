@@ -0,0 +1,317 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	schemas "github.com/OnyxDevTools/onyx-database-go/impl/schema"
+	"github.com/OnyxDevTools/onyx-database-go/onyx"
+)
+
+// MigrateCommand plans and applies ordered schema migrations.
+type MigrateCommand struct{}
+
+func (c *MigrateCommand) Name() string        { return "migrate" }
+func (c *MigrateCommand) Description() string { return "plan or apply schema migrations" }
+
+func (c *MigrateCommand) Run(args []string) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" {
+		c.printUsage()
+		if len(args) == 0 {
+			return 2
+		}
+		return 0
+	}
+	switch args[0] {
+	case "plan":
+		return c.runPlan(args[1:])
+	case "apply":
+		return c.runApply(args[1:])
+	default:
+		fmt.Fprintf(Stderr, "unknown migrate subcommand %q\n", args[0])
+		c.printUsage()
+		return 2
+	}
+}
+
+func (c *MigrateCommand) printUsage() {
+	fmt.Fprintln(Stdout, "Usage: onyx-schema-go migrate <plan|apply> [options]")
+	fmt.Fprintln(Stdout)
+	fmt.Fprintln(Stdout, "  plan   print the ordered steps from the live (or --base) schema to --schema")
+	fmt.Fprintln(Stdout, "  apply  run a plan against the API and record it in the history file")
+}
+
+func (c *MigrateCommand) runPlan(args []string) int {
+	fs := flag.NewFlagSet("migrate plan", flag.ContinueOnError)
+	fs.SetOutput(Stderr)
+	schemaPath := fs.String("schema", defaultSchemaPath, "path to the target schema JSON")
+	basePath := fs.String("base", "", "path to the base schema JSON (defaults to the live API schema)")
+	databaseID := fs.String("database-id", "", "database id used when fetching the base schema")
+	outPath := fs.String("out", "", "write the plan JSON to this path for a later apply --plan")
+	jsonOut := fs.Bool("json", false, "emit the plan as JSON")
+
+	fs.Usage = func() {
+		fmt.Fprintln(Stdout, "Usage of migrate plan:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	target, err := loadSchema(*schemaPath)
+	if err != nil {
+		fmt.Fprintf(Stderr, "failed to read schema --schema: %v\n", err)
+		return 1
+	}
+
+	var base onyx.Schema
+	if *basePath != "" {
+		base, err = loadSchema(*basePath)
+		if err != nil {
+			fmt.Fprintf(Stderr, "failed to read schema --base: %v\n", err)
+			return 1
+		}
+	} else {
+		base, err = fetchSchemaFromAPI(context.Background(), *databaseID)
+		if err != nil {
+			fmt.Fprintf(Stderr, "failed to fetch schema from API: %v\n", err)
+			return 1
+		}
+	}
+
+	plan := schemas.PlanMigration(base, target)
+
+	if *outPath != "" {
+		if err := writeJSONFile(*outPath, plan); err != nil {
+			fmt.Fprintf(Stderr, "failed to write plan: %v\n", err)
+			return 1
+		}
+	}
+
+	if *jsonOut {
+		data, err := jsonMarshalIndent(plan, "", "  ")
+		if err != nil {
+			fmt.Fprintf(Stderr, "failed to render plan: %v\n", err)
+			return 1
+		}
+		fmt.Fprintln(Stdout, string(data))
+		return 0
+	}
+
+	printPlan(plan)
+	if *outPath != "" {
+		fmt.Fprintf(Stdout, "Plan written to %s\n", *outPath)
+	}
+	return 0
+}
+
+func (c *MigrateCommand) runApply(args []string) int {
+	fs := flag.NewFlagSet("migrate apply", flag.ContinueOnError)
+	fs.SetOutput(Stderr)
+	schemaPath := fs.String("schema", defaultSchemaPath, "path to the target schema JSON (ignored with --plan)")
+	planPath := fs.String("plan", "", "apply a plan written by migrate plan --out")
+	databaseID := fs.String("database-id", "", "database id (optional if configured)")
+	historyPath := fs.String("history", defaultHistoryPath, "path to the applied-migrations history file")
+	yes := fs.Bool("yes", false, "apply without asking for confirmation")
+
+	fs.Usage = func() {
+		fmt.Fprintln(Stdout, "Usage of migrate apply:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	ctx := context.Background()
+	client, err := initSchemaClient(ctx, *databaseID)
+	if err != nil {
+		fmt.Fprintln(Stderr, err)
+		return 1
+	}
+
+	live, err := client.Schema(ctx)
+	if err != nil {
+		fmt.Fprintf(Stderr, "failed to fetch schema from API: %v\n", err)
+		return 1
+	}
+
+	var plan schemas.MigrationPlan
+	if *planPath != "" {
+		data, err := os.ReadFile(*planPath)
+		if err != nil {
+			fmt.Fprintf(Stderr, "failed to read plan: %v\n", err)
+			return 1
+		}
+		if err := json.Unmarshal(data, &plan); err != nil {
+			fmt.Fprintf(Stderr, "failed to parse plan: %v\n", err)
+			return 1
+		}
+	} else {
+		target, err := loadSchema(*schemaPath)
+		if err != nil {
+			fmt.Fprintf(Stderr, "failed to read schema --schema: %v\n", err)
+			return 1
+		}
+		plan = schemas.PlanMigration(live, target)
+	}
+
+	history, err := loadMigrationHistory(*historyPath)
+	if err != nil {
+		fmt.Fprintf(Stderr, "failed to read history: %v\n", err)
+		return 1
+	}
+	if history.applied(plan.ID) {
+		fmt.Fprintf(Stdout, "Migration %s already applied.\n", plan.ID)
+		return 0
+	}
+
+	liveSum := schemas.SchemaChecksum(live)
+	if liveSum == plan.Target {
+		fmt.Fprintln(Stdout, "Schema is already up to date.")
+		return 0
+	}
+	if liveSum != plan.Base {
+		fmt.Fprintf(Stderr, "live schema does not match the base of plan %s; run migrate plan again\n", plan.ID)
+		return 1
+	}
+	for _, step := range plan.Steps {
+		if step.Kind == schemas.StepBackfill && step.Value == nil {
+			fmt.Fprintf(Stderr, "cannot %s: set a defaultValue for the field in the target schema\n", step.Description)
+			return 1
+		}
+	}
+
+	printPlan(plan)
+	if !*yes && !confirm(fmt.Sprintf("Apply %d steps?", len(plan.Steps))) {
+		fmt.Fprintln(Stdout, "Aborted.")
+		return 1
+	}
+
+	current := live
+	for i, step := range plan.Steps {
+		if step.Kind == schemas.StepBackfill {
+			if _, err := runBackfill(ctx, client, step.Table, step.Field, step.Value); err != nil {
+				fmt.Fprintf(Stderr, "step %d (%s) failed: %v\n", i+1, step.Description, err)
+				return 1
+			}
+			continue
+		}
+		next, err := schemas.ApplyMigrationStep(current, step)
+		if err != nil {
+			fmt.Fprintf(Stderr, "step %d (%s) failed: %v\n", i+1, step.Description, err)
+			return 1
+		}
+		if err := client.UpdateSchema(ctx, next, true); err != nil {
+			fmt.Fprintf(Stderr, "step %d (%s) failed: %v\n", i+1, step.Description, err)
+			return 1
+		}
+		current = next
+	}
+	// Publishing the full target carries table settings the planner does not stage step by step.
+	if err := client.PublishSchema(ctx, plan.Schema); err != nil {
+		fmt.Fprintf(Stderr, "failed to publish target schema: %v\n", err)
+		return 1
+	}
+
+	history.Migrations = append(history.Migrations, migrationRecord{
+		ID:         plan.ID,
+		DatabaseID: *databaseID,
+		Base:       plan.Base,
+		Target:     plan.Target,
+		AppliedAt:  migrationNow().UTC().Format(time.RFC3339),
+		Steps:      plan.Steps,
+	})
+	if err := writeJSONFile(*historyPath, history); err != nil {
+		fmt.Fprintf(Stderr, "migration applied but history was not written: %v\n", err)
+		return 1
+	}
+
+	fmt.Fprintf(Stdout, "Migration %s applied (%d steps).\n", plan.ID, len(plan.Steps))
+	return 0
+}
+
+var (
+	migrationNow = time.Now
+
+	// runBackfill sets value on every row of table where field is null.
+	runBackfill = func(ctx context.Context, client onyx.Client, table, field string, value any) (int, error) {
+		return client.From(table).Where(onyx.IsNull(field)).SetUpdates(map[string]any{field: value}).Update(ctx)
+	}
+)
+
+func printPlan(plan schemas.MigrationPlan) {
+	if len(plan.Steps) == 0 {
+		fmt.Fprintf(Stdout, "Migration %s: no changes.\n", plan.ID)
+		return
+	}
+	fmt.Fprintf(Stdout, "Migration %s (%d steps):\n", plan.ID, len(plan.Steps))
+	for i, step := range plan.Steps {
+		fmt.Fprintf(Stdout, "%3d. %-20s %s\n", i+1, step.Kind, step.Description)
+	}
+}
+
+func confirm(prompt string) bool {
+	fmt.Fprintf(Stdout, "%s [y/N]: ", prompt)
+	answer, _ := bufio.NewReader(Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// migrationHistory is the on-disk record of applied migrations.
+type migrationHistory struct {
+	Migrations []migrationRecord `json:"migrations"`
+}
+
+type migrationRecord struct {
+	ID         string                  `json:"id"`
+	DatabaseID string                  `json:"databaseId,omitempty"`
+	Base       string                  `json:"base"`
+	Target     string                  `json:"target"`
+	AppliedAt  string                  `json:"appliedAt"`
+	Steps      []schemas.MigrationStep `json:"steps"`
+}
+
+func (h migrationHistory) applied(id string) bool {
+	for _, m := range h.Migrations {
+		if m.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func loadMigrationHistory(path string) (migrationHistory, error) {
+	var history migrationHistory
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return history, nil
+	}
+	if err != nil {
+		return history, err
+	}
+	err = json.Unmarshal(data, &history)
+	return history, err
+}
+
+func writeJSONFile(path string, v any) error {
+	data, err := jsonMarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(path); dir != "." && dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
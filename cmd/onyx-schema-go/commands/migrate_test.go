@@ -0,0 +1,229 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/OnyxDevTools/onyx-database-go/onyx"
+)
+
+const (
+	migrateBaseJSON   = `{"tables":[{"name":"User","fields":[{"name":"id","type":"String","primaryKey":true},{"name":"legacy","type":"String","nullable":true}]}]}`
+	migrateTargetJSON = `{"tables":[{"name":"User","fields":[{"name":"id","type":"String","primaryKey":true},{"name":"email","type":"String","defaultValue":"n/a"}]}]}`
+)
+
+func writeMigrateFixtures(t *testing.T) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	base := filepath.Join(dir, "base.json")
+	target := filepath.Join(dir, "target.json")
+	if err := os.WriteFile(base, []byte(migrateBaseJSON), 0o644); err != nil {
+		t.Fatalf("write base: %v", err)
+	}
+	if err := os.WriteFile(target, []byte(migrateTargetJSON), 0o644); err != nil {
+		t.Fatalf("write target: %v", err)
+	}
+	return base, target
+}
+
+func stubMigrateClient(t *testing.T) *stubClient {
+	t.Helper()
+	live, err := onyx.ParseSchemaJSON([]byte(migrateBaseJSON))
+	if err != nil {
+		t.Fatalf("parse base: %v", err)
+	}
+	stub := &stubClient{schema: live}
+	originalInit := initSchemaClient
+	initSchemaClient = func(ctx context.Context, databaseID string) (onyx.Client, error) { return stub, nil }
+	t.Cleanup(func() { initSchemaClient = originalInit })
+	return stub
+}
+
+func captureOutput(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var out bytes.Buffer
+	Stdout, Stderr = &out, &out
+	t.Cleanup(func() { Stdout, Stderr, Stdin = os.Stdout, os.Stderr, os.Stdin })
+	return &out
+}
+
+func TestMigratePlanPrintsOrderedSteps(t *testing.T) {
+	base, target := writeMigrateFixtures(t)
+	out := captureOutput(t)
+
+	planPath := filepath.Join(t.TempDir(), "plan.json")
+	if code := (&MigrateCommand{}).Run([]string{"plan", "--base", base, "--schema", target, "--out", planPath}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	text := out.String()
+	for _, want := range []string{"add_field", "backfill", "tighten_nullability", "drop_field"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %s in plan output:\n%s", want, text)
+		}
+	}
+	if strings.Index(text, "add_field") > strings.Index(text, "drop_field") {
+		t.Fatalf("expected adds before drops:\n%s", text)
+	}
+	if _, err := os.Stat(planPath); err != nil {
+		t.Fatalf("expected plan file: %v", err)
+	}
+}
+
+func TestMigrateApplyRecordsHistory(t *testing.T) {
+	_, target := writeMigrateFixtures(t)
+	stub := stubMigrateClient(t)
+	out := captureOutput(t)
+
+	var backfilled []string
+	originalBackfill := runBackfill
+	runBackfill = func(ctx context.Context, client onyx.Client, table, field string, value any) (int, error) {
+		backfilled = append(backfilled, table+"."+field+"="+value.(string))
+		return 1, nil
+	}
+	t.Cleanup(func() { runBackfill = originalBackfill })
+
+	history := filepath.Join(t.TempDir(), "history.json")
+	args := []string{"apply", "--schema", target, "--history", history, "--yes"}
+	if code := (&MigrateCommand{}).Run(args); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	if len(stub.updates) != 3 || len(backfilled) != 1 || backfilled[0] != "User.email=n/a" {
+		t.Fatalf("expected 3 schema updates and one backfill, got %d and %v", len(stub.updates), backfilled)
+	}
+	if f := stub.updates[0].Tables[0].Fields; !f[len(f)-1].Nullable {
+		t.Fatalf("expected the new field to be added nullable first: %+v", f)
+	}
+	if !stub.publishCalled {
+		t.Fatalf("expected the target schema to be published")
+	}
+	data, err := os.ReadFile(history)
+	if err != nil || !strings.Contains(string(data), `"kind": "backfill"`) {
+		t.Fatalf("expected history with applied steps, got %s (%v)", data, err)
+	}
+
+	out.Reset()
+	if code := (&MigrateCommand{}).Run(args); code != 0 || !strings.Contains(out.String(), "already") {
+		t.Fatalf("expected re-run to be a no-op, got %d: %s", code, out.String())
+	}
+	if len(stub.updates) != 3 {
+		t.Fatalf("re-run must not update the schema")
+	}
+}
+
+func TestMigrateApplyRequiresConfirmation(t *testing.T) {
+	_, target := writeMigrateFixtures(t)
+	stub := stubMigrateClient(t)
+	out := captureOutput(t)
+	Stdin = strings.NewReader("n\n")
+
+	history := filepath.Join(t.TempDir(), "history.json")
+	if code := (&MigrateCommand{}).Run([]string{"apply", "--schema", target, "--history", history}); code != 1 {
+		t.Fatalf("expected exit 1 when declined, got %d", code)
+	}
+	if !strings.Contains(out.String(), "Aborted.") || len(stub.updates) != 0 {
+		t.Fatalf("expected no changes after declining: %s", out.String())
+	}
+	if _, err := os.Stat(history); !os.IsNotExist(err) {
+		t.Fatalf("declined apply must not write history")
+	}
+}
+
+func TestMigrateApplyRejectsStalePlan(t *testing.T) {
+	_, target := writeMigrateFixtures(t)
+	stub := stubMigrateClient(t)
+	out := captureOutput(t)
+
+	dir := t.TempDir()
+	next := filepath.Join(dir, "next.json")
+	if err := os.WriteFile(next, []byte(`{"tables":[{"name":"Role","fields":[{"name":"id","type":"String"}]}]}`), 0o644); err != nil {
+		t.Fatalf("write next: %v", err)
+	}
+	planPath := filepath.Join(dir, "plan.json")
+	if code := (&MigrateCommand{}).Run([]string{"plan", "--base", target, "--schema", next, "--out", planPath}); code != 0 {
+		t.Fatalf("plan failed: %s", out.String())
+	}
+	code := (&MigrateCommand{}).Run([]string{"apply", "--plan", planPath, "--history", filepath.Join(t.TempDir(), "h.json"), "--yes"})
+	if code != 1 || !strings.Contains(out.String(), "does not match") || len(stub.updates) != 0 {
+		t.Fatalf("expected stale plan rejection, got %d: %s", code, out.String())
+	}
+}
+
+func TestMigrateApplyRequiresBackfillValue(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "target.json")
+	if err := os.WriteFile(target, []byte(`{"tables":[{"name":"User","fields":[{"name":"id","type":"String","primaryKey":true},{"name":"email","type":"String"}]}]}`), 0o644); err != nil {
+		t.Fatalf("write target: %v", err)
+	}
+	stub := stubMigrateClient(t)
+	out := captureOutput(t)
+
+	if code := (&MigrateCommand{}).Run([]string{"apply", "--schema", target, "--history", filepath.Join(dir, "h.json"), "--yes"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if !strings.Contains(out.String(), "defaultValue") || len(stub.updates) != 0 {
+		t.Fatalf("expected missing default error before any update: %s", out.String())
+	}
+}
+
+func TestMigrateUsage(t *testing.T) {
+	out := captureOutput(t)
+	if code := (&MigrateCommand{}).Run(nil); code != 2 {
+		t.Fatalf("expected usage exit 2, got %d", code)
+	}
+	if code := (&MigrateCommand{}).Run([]string{"rollback"}); code != 2 {
+		t.Fatalf("expected unknown subcommand exit 2, got %d", code)
+	}
+	if !strings.Contains(out.String(), "migrate <plan|apply>") {
+		t.Fatalf("expected usage text: %s", out.String())
+	}
+}
+
+// TestMigratePlanApplyPlanIsStable runs plan, apply and plan again against a stub that echoes the
+// schema the way the server does, filling in the default "None" generator on primary keys.
+func TestMigratePlanApplyPlanIsStable(t *testing.T) {
+	_, target := writeMigrateFixtures(t)
+	stub := stubMigrateClient(t)
+	out := captureOutput(t)
+	originalBackfill := runBackfill
+	runBackfill = func(ctx context.Context, client onyx.Client, table, field string, value any) (int, error) {
+		return 1, nil
+	}
+	originalFetch := fetchSchemaFromAPI
+	fetchSchemaFromAPI = func(ctx context.Context, databaseID string) (onyx.Schema, error) { return stub.schema, nil }
+	t.Cleanup(func() { runBackfill, fetchSchemaFromAPI = originalBackfill, originalFetch })
+
+	planPath := filepath.Join(t.TempDir(), "plan.json")
+	if code := (&MigrateCommand{}).Run([]string{"plan", "--schema", target, "--out", planPath}); code != 0 {
+		t.Fatalf("plan: exit %d: %s", code, out.String())
+	}
+	history := filepath.Join(t.TempDir(), "history.json")
+	if code := (&MigrateCommand{}).Run([]string{"apply", "--plan", planPath, "--history", history, "--yes"}); code != 0 {
+		t.Fatalf("apply: exit %d: %s", code, out.String())
+	}
+
+	data, err := json.Marshal(map[string]any{"entities": onyx.SchemaToEntities(stub.schema)})
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	if stub.schema, err = onyx.ParseSchemaJSON(data); err != nil {
+		t.Fatalf("parse echoed schema: %v", err)
+	}
+	if id, _ := stub.schema.Tables[0].Field("id"); id.Generator != "None" {
+		t.Fatalf("expected the echoed schema to carry the server default generator, got %+v", stub.schema)
+	}
+
+	out.Reset()
+	fresh := filepath.Join(t.TempDir(), "history.json")
+	if code := (&MigrateCommand{}).Run([]string{"apply", "--plan", planPath, "--history", fresh, "--yes"}); code != 0 || !strings.Contains(out.String(), "already up to date") {
+		t.Fatalf("expected the applied plan to be up to date, got %d: %s", code, out.String())
+	}
+	out.Reset()
+	if code := (&MigrateCommand{}).Run([]string{"plan", "--schema", target}); code != 0 || !strings.Contains(out.String(), "no changes") {
+		t.Fatalf("expected an empty plan after apply, got %d: %s", code, out.String())
+	}
+}
@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	schemas "github.com/OnyxDevTools/onyx-database-go/impl/schema"
+	"github.com/OnyxDevTools/onyx-database-go/onyx"
+)
+
+// PatchCommand applies an RFC 6902 patch produced by diff --format jsonpatch to a schema file.
+// It exits 3 when the schema has drifted from the patch's base.
+type PatchCommand struct{}
+
+const exitPatchConflict = 3
+
+func (c *PatchCommand) Name() string        { return "patch" }
+func (c *PatchCommand) Description() string { return "apply a JSON Patch to a schema file" }
+
+func (c *PatchCommand) Run(args []string) int {
+	fs := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	fs.SetOutput(Stderr)
+	schemaPath := fs.String("schema", defaultSchemaPath, "path to the schema JSON to patch")
+	patchPath := fs.String("patch", "", "path to the JSON Patch (from diff --format jsonpatch)")
+	outPath := fs.String("out", "", "path to write the patched schema (stdout when empty)")
+
+	fs.Usage = func() {
+		fmt.Fprintf(Stdout, "Usage of %s:\n", c.Name())
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *patchPath == "" {
+		fmt.Fprintln(Stderr, "--patch is required")
+		return 2
+	}
+
+	schema, err := loadSchema(*schemaPath)
+	if err != nil {
+		fmt.Fprintf(Stderr, "failed to read schema: %v\n", err)
+		return 1
+	}
+
+	data, err := os.ReadFile(*patchPath)
+	if err != nil {
+		fmt.Fprintf(Stderr, "failed to read patch: %v\n", err)
+		return 1
+	}
+	var ops []schemas.PatchOperation
+	if err := json.Unmarshal(data, &ops); err != nil {
+		fmt.Fprintf(Stderr, "failed to parse patch: %v\n", err)
+		return 1
+	}
+
+	patched, err := schemas.ApplyJSONPatch(schema, ops)
+	if err != nil {
+		var conflict *schemas.PatchConflictError
+		if errors.As(err, &conflict) {
+			fmt.Fprintf(Stderr, "%v\nthe schema has drifted from the patch's base; regenerate the patch against it\n", err)
+			return exitPatchConflict
+		}
+		fmt.Fprintf(Stderr, "failed to apply patch: %v\n", err)
+		return 1
+	}
+
+	out, err := jsonMarshalIndent(onyx.NormalizeSchema(patched), "", "  ")
+	if err != nil {
+		fmt.Fprintf(Stderr, "failed to render schema: %v\n", err)
+		return 1
+	}
+	if *outPath == "" {
+		fmt.Fprintln(Stdout, string(out))
+		return 0
+	}
+	if err := os.WriteFile(*outPath, append(out, '\n'), 0o644); err != nil {
+		fmt.Fprintf(Stderr, "failed to write schema: %v\n", err)
+		return 1
+	}
+	fmt.Fprintf(Stdout, "Applied %d operations; schema written to %s\n", len(ops), *outPath)
+	return 0
+}
@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDiffJSONPatchThenPatchCommand(t *testing.T) {
+	tmpDir := t.TempDir()
+	base := filepath.Join(tmpDir, "base.json")
+	updated := filepath.Join(tmpDir, "updated.json")
+	patchPath := filepath.Join(tmpDir, "change.json")
+	outPath := filepath.Join(tmpDir, "patched.json")
+	writeFile(t, base, `{"tables":[{"name":"User","fields":[{"name":"id","type":"String"},{"name":"name","type":"String"}]}]}`)
+	writeFile(t, updated, `{"tables":[{"name":"User","fields":[{"name":"id","type":"String"},{"name":"name","type":"String","nullable":true}]},{"name":"Role","fields":[]}]}`)
+
+	var out bytes.Buffer
+	Stdout = &out
+	Stderr = &out
+	defer func() {
+		Stdout = os.Stdout
+		Stderr = os.Stderr
+	}()
+
+	if code := (&DiffCommand{}).Run([]string{"--a", base, "--b", updated, "--format", "jsonpatch"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	if !strings.Contains(out.String(), `"op": "add"`) || !strings.Contains(out.String(), `"path": "/tables/0/fields/1/nullable"`) {
+		t.Fatalf("unexpected patch: %s", out.String())
+	}
+	writeFile(t, patchPath, out.String())
+
+	out.Reset()
+	if code := (&PatchCommand{}).Run([]string{"--schema", base, "--patch", patchPath, "--out", outPath}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	out.Reset()
+	if code := (&DiffCommand{}).Run([]string{"--a", updated, "--b", outPath}); code != 0 || !strings.Contains(out.String(), "Schemas are identical.") {
+		t.Fatalf("patched schema should match updated, got %d: %s", code, out.String())
+	}
+
+	reordered := filepath.Join(tmpDir, "reordered.json")
+	writeFile(t, reordered, `{"tables":[{"name":"User","fields":[{"name":"name","type":"String"},{"name":"id","type":"String"}]}]}`)
+	out.Reset()
+	if code := (&PatchCommand{}).Run([]string{"--schema", reordered, "--patch", patchPath}); code != 0 {
+		t.Fatalf("a reordered but equivalent schema should patch cleanly, got %d: %s", code, out.String())
+	}
+
+	drifted := filepath.Join(tmpDir, "drifted.json")
+	writeFile(t, drifted, `{"tables":[{"name":"User","fields":[{"name":"id","type":"String"},{"name":"title","type":"String"}]}]}`)
+	out.Reset()
+	if code := (&PatchCommand{}).Run([]string{"--schema", drifted, "--patch", patchPath}); code != exitPatchConflict {
+		t.Fatalf("expected conflict exit %d, got %d: %s", exitPatchConflict, code, out.String())
+	}
+	if !strings.Contains(out.String(), "patch conflict") {
+		t.Fatalf("expected conflict message, got %s", out.String())
+	}
+}
+
+func TestPatchCommandErrors(t *testing.T) {
+	tmpDir := t.TempDir()
+	schemaPath := filepath.Join(tmpDir, "schema.json")
+	badPatch := filepath.Join(tmpDir, "bad.json")
+	writeFile(t, schemaPath, `{"tables":[]}`)
+	writeFile(t, badPatch, `{"op":"add"}`)
+
+	var out bytes.Buffer
+	Stdout = &out
+	Stderr = &out
+	defer func() {
+		Stdout = os.Stdout
+		Stderr = os.Stderr
+	}()
+
+	cases := map[int][]string{
+		2: {"--schema", schemaPath},
+		1: {"--schema", schemaPath, "--patch", badPatch},
+	}
+	for want, args := range cases {
+		if code := (&PatchCommand{}).Run(args); code != want {
+			t.Fatalf("%v: expected exit %d, got %d", args, want, code)
+		}
+	}
+	if code := (&PatchCommand{}).Run([]string{"--schema", filepath.Join(tmpDir, "missing.json"), "--patch", badPatch}); code != 1 {
+		t.Fatalf("expected exit 1 for missing schema, got %d", code)
+	}
+	if code := (&DiffCommand{}).Run([]string{"--a", schemaPath, "--b", schemaPath, "--format", "yaml"}); code != 2 {
+		t.Fatalf("expected usage exit for unknown format, got %d", code)
+	}
+}
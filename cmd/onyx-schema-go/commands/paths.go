@@ -1,3 +1,6 @@
 package commands
 
-const defaultSchemaPath = "api/onyx.schema.json"
+const (
+	defaultSchemaPath  = "api/onyx.schema.json"
+	defaultHistoryPath = "api/onyx.migrations.json"
+)
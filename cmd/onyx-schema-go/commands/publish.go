@@ -4,9 +4,6 @@ import (
 	"context"
 	"flag"
 	"fmt"
-	"os"
-
-	"github.com/OnyxDevTools/onyx-database-go/onyx"
 )
 
 // PublishCommand pushes a local schema to the API.
@@ -20,6 +17,7 @@ func (c *PublishCommand) Run(args []string) int {
 	fs.SetOutput(Stderr)
 	databaseID := fs.String("database-id", "", "database id (optional if configured)")
 	schemaPath := fs.String("schema", defaultSchemaPath, "path to schema JSON file")
+	fromGo := fs.String("from-go", "", "publish the registered Go model set instead of --schema")
 
 	fs.Usage = func() {
 		fmt.Fprintf(Stdout, "Usage of %s:\n", c.Name())
@@ -30,16 +28,9 @@ func (c *PublishCommand) Run(args []string) int {
 		return 2
 	}
 
-	data, err := os.ReadFile(*schemaPath)
-	if err != nil {
-		fmt.Fprintf(Stderr, "failed to read schema: %v\n", err)
-		return 1
-	}
-
-	schema, err := onyx.ParseSchemaJSON(data)
-	if err != nil {
-		fmt.Fprintf(Stderr, "failed to parse schema: %v\n", err)
-		return 1
+	schema, code := readSchemaSource(*schemaPath, *fromGo)
+	if code != 0 {
+		return code
 	}
 
 	ctx := context.Background()
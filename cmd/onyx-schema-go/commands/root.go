@@ -7,8 +7,9 @@ import (
 	"sort"
 )
 
-// Stdout and Stderr allow commands to direct output; tests can override.
+// Stdin, Stdout and Stderr allow commands to direct I/O; tests can override.
 var (
+	Stdin  io.Reader = os.Stdin
 	Stdout io.Writer = os.Stdout
 	Stderr io.Writer = os.Stderr
 )
@@ -73,5 +74,11 @@ func defaultAvailableCommands() []Command {
 		&GetCommand{},
 		&PublishCommand{},
 		&InfoCommand{},
+		&MigrateCommand{},
+		&PatchCommand{},
+		&HistoryCommand{},
+		&DocsCommand{},
+		&ExportCommand{},
+		&ImportSQLCommand{},
 	}
 }
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 
+	schemas "github.com/OnyxDevTools/onyx-database-go/impl/schema"
 	"github.com/OnyxDevTools/onyx-database-go/onyx"
 )
 
@@ -19,6 +20,8 @@ func (c *ValidateCommand) Run(args []string) int {
 	fs := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
 	fs.SetOutput(Stderr)
 	schemaPath := fs.String("schema", defaultSchemaPath, "path to schema JSON file")
+	fromGo := fs.String("from-go", "", "validate the registered Go model set instead of --schema")
+	asJSON := fs.Bool("json", false, "print diagnostics as JSON")
 
 	fs.Usage = func() {
 		fmt.Fprintf(Stdout, "Usage of %s:\n", c.Name())
@@ -29,57 +32,84 @@ func (c *ValidateCommand) Run(args []string) int {
 		return 2
 	}
 
-	data, err := os.ReadFile(*schemaPath)
-	if err != nil {
-		fmt.Fprintf(Stderr, "failed to read schema: %v\n", err)
-		return 1
+	var diags []schemas.Diagnostic
+	if *fromGo != "" {
+		schema, code := readSchemaSource(*schemaPath, *fromGo)
+		if code != 0 {
+			return code
+		}
+		diags = schemas.ValidateSchema(schema)
+	} else {
+		data, err := os.ReadFile(*schemaPath)
+		if err != nil {
+			fmt.Fprintf(Stderr, "failed to read schema: %v\n", err)
+			return 1
+		}
+		if diags, err = schemas.ValidateSchemaJSON(data); err != nil {
+			fmt.Fprintf(Stderr, "failed to parse schema: %v\n", err)
+			return 1
+		}
 	}
 
-	schema, err := onyx.ParseSchemaJSON(data)
-	if err != nil {
-		fmt.Fprintf(Stderr, "failed to parse schema: %v\n", err)
-		return 1
+	code := 0
+	if schemas.HasErrors(diags) {
+		code = 1
 	}
 
-	if errs := validateSchema(schema); len(errs) > 0 {
-		for _, e := range errs {
-			fmt.Fprintln(Stderr, e.Error())
+	if *asJSON {
+		if diags == nil {
+			diags = []schemas.Diagnostic{}
+		}
+		data, err := jsonMarshalIndent(diags, "", "  ")
+		if err != nil {
+			fmt.Fprintf(Stderr, "failed to render diagnostics: %v\n", err)
+			return 1
 		}
-		return 1
+		fmt.Fprintln(Stdout, string(data))
+		return code
 	}
 
-	fmt.Fprintln(Stdout, "Schema is valid.")
-	return 0
+	for _, d := range diags {
+		fmt.Fprintln(Stderr, d.String())
+	}
+	if code == 0 {
+		fmt.Fprintln(Stdout, "Schema is valid.")
+	}
+	return code
 }
 
-func validateSchema(s onyx.Schema) []error {
-	var errs []error
-	tableNames := map[string]struct{}{}
-
-	for _, table := range s.Tables {
-		if table.Name == "" {
-			errs = append(errs, errors.New("table name cannot be empty"))
-			continue
+// readSchemaSource loads the schema from the registered Go model set when fromGo is set, else
+// from the JSON file at path. It prints failures and returns a non-zero exit code.
+func readSchemaSource(path, fromGo string) (onyx.Schema, int) {
+	if fromGo != "" {
+		schema, err := schemas.FromRegistered(fromGo)
+		if err != nil {
+			fmt.Fprintf(Stderr, "failed to build schema from Go models: %v\n", err)
+			return onyx.Schema{}, 1
 		}
-		if _, exists := tableNames[table.Name]; exists {
-			errs = append(errs, fmt.Errorf("duplicate table name: %s", table.Name))
-			continue
-		}
-		tableNames[table.Name] = struct{}{}
+		return schema, 0
+	}
 
-		fieldNames := map[string]struct{}{}
-		for _, field := range table.Fields {
-			if field.Name == "" {
-				errs = append(errs, fmt.Errorf("table %s has a field with empty name", table.Name))
-				continue
-			}
-			if _, exists := fieldNames[field.Name]; exists {
-				errs = append(errs, fmt.Errorf("table %s has duplicate field name: %s", table.Name, field.Name))
-				continue
-			}
-			fieldNames[field.Name] = struct{}{}
-		}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintf(Stderr, "failed to read schema: %v\n", err)
+		return onyx.Schema{}, 1
+	}
+	schema, err := onyx.ParseSchemaJSON(data)
+	if err != nil {
+		fmt.Fprintf(Stderr, "failed to parse schema: %v\n", err)
+		return onyx.Schema{}, 1
 	}
+	return schema, 0
+}
 
+// validateSchema returns the error-severity diagnostics for s as errors.
+func validateSchema(s onyx.Schema) []error {
+	var errs []error
+	for _, d := range schemas.ValidateSchema(s) {
+		if d.Severity == schemas.DiagnosticError {
+			errs = append(errs, errors.New(d.Message))
+		}
+	}
 	return errs
 }
@@ -2,8 +2,10 @@ package commands
 
 import (
 	"bytes"
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -13,7 +15,7 @@ func TestValidateCommandSuccess(t *testing.T) {
 	path := filepath.Join(tmpDir, "schema.json")
 	writeFile(t, path, `{
   "tables": [
-    {"name": "users", "fields": [{"name": "id", "type": "string"}]}
+    {"name": "users", "fields": [{"name": "id", "type": "String", "primaryKey": true}]}
   ]
 }`)
 
@@ -68,6 +70,96 @@ func TestValidateCommandDuplicateNames(t *testing.T) {
 	}
 }
 
+func TestValidateCommandReportsDiagnostics(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schema.json")
+	writeFile(t, path, `{
+  "tables": [
+    {"name": "users", "partition": "region", "fields": [
+      {"name": "id", "type": "String", "primaryKey": true, "nullable": true},
+      {"name": "age", "type": "int"}
+    ]}
+  ]
+}`)
+
+	var stdout, stderr bytes.Buffer
+	Stdout = &stdout
+	Stderr = &stderr
+	defer func() {
+		Stdout = os.Stdout
+		Stderr = os.Stderr
+	}()
+
+	cmd := &ValidateCommand{}
+	if exitCode := cmd.Run([]string{"--schema", path}); exitCode != 1 {
+		t.Fatalf("expected exit 1, got %d", exitCode)
+	}
+	for _, want := range []string{
+		"primary key id cannot be nullable (/tables/0/fields/0/nullable)",
+		`unknown type "int"; did you mean "Int"? (/tables/0/fields/1/type)`,
+		"partition field region does not exist (/tables/0/partition)",
+	} {
+		if !strings.Contains(stderr.String(), want) {
+			t.Fatalf("expected %q in output, got: %s", want, stderr.String())
+		}
+	}
+	if strings.Contains(stdout.String(), "Schema is valid") {
+		t.Fatalf("did not expect success message, got: %s", stdout.String())
+	}
+}
+
+func TestValidateCommandJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schema.json")
+	writeFile(t, path, `{"entities": [
+  {"name": "User", "attributes": [{"name": "id", "type": "String"}],
+   "resolvers": [{"name": "roles", "resolver": "db.from(\"Role\").list()"}]}
+]}`)
+
+	var stdout bytes.Buffer
+	Stdout = &stdout
+	Stderr = &bytes.Buffer{}
+	defer func() {
+		Stdout = os.Stdout
+		Stderr = os.Stderr
+	}()
+
+	cmd := &ValidateCommand{}
+	if exitCode := cmd.Run([]string{"--schema", path, "--json"}); exitCode != 1 {
+		t.Fatalf("expected exit 1, got %d", exitCode)
+	}
+	var diags []map[string]string
+	if err := json.Unmarshal(stdout.Bytes(), &diags); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, stdout.String())
+	}
+	want := []map[string]string{
+		{"severity": "error", "code": "missing_primary_key", "table": "User", "pointer": "/entities/0/identifier", "message": "table User has no primary key"},
+		{"severity": "error", "code": "resolver_unknown_table", "table": "User", "field": "roles", "pointer": "/entities/0/resolvers/0/resolver", "message": "table User resolver roles references unknown table Role"},
+	}
+	if !reflect.DeepEqual(diags, want) {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+}
+
+func TestValidateCommandJSONValid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schema.json")
+	writeFile(t, path, `{"tables": [{"name": "users", "fields": [{"name": "id", "type": "String", "primaryKey": true}]}]}`)
+
+	var stdout bytes.Buffer
+	Stdout = &stdout
+	Stderr = &bytes.Buffer{}
+	defer func() {
+		Stdout = os.Stdout
+		Stderr = os.Stderr
+	}()
+
+	cmd := &ValidateCommand{}
+	if exitCode := cmd.Run([]string{"--schema", path, "--json"}); exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d", exitCode)
+	}
+	if got := strings.TrimSpace(stdout.String()); got != "[]" {
+		t.Fatalf("expected empty diagnostics, got: %s", got)
+	}
+}
+
 func writeFile(t *testing.T, path, contents string) {
 	t.Helper()
 	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
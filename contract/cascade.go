@@ -2,23 +2,59 @@ package contract
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"strings"
 )
 
 // CascadeClient executes cascading save/delete operations using a cascade specification.
+//
+// Delete removes the record and, depth-first, every child row reachable through the spec's
+// graphs. A graph "name:Type(sourceField,targetField)" matches rows of Type whose sourceField
+// equals the parent's targetField; a graph without fields takes its mapping from the parent
+// table's resolver of the same name. Nested graphs ("roles.permissions") are followed from the
+// rows matched by their parent graph. An empty spec follows every resolver keyed on the parent's
+// primary key, recursively. Rows already visited are skipped, so cyclic graphs terminate.
 type CascadeClient interface {
 	Save(ctx context.Context, table string, entity any) error
 	Delete(ctx context.Context, table, id string) error
+	// DryRunDelete returns the rows Delete would remove, in deletion order, without deleting anything.
+	DryRunDelete(ctx context.Context, table, id string) ([]CascadeRow, error)
 }
 
-// CascadeSpec describes a cascade graph.
+// CascadeRow identifies a row removed (or, in a dry run, to be removed) by a cascade delete.
+type CascadeRow struct {
+	Table  string         `json:"table"`
+	ID     string         `json:"id"`
+	Path   string         `json:"path,omitempty"`
+	Record map[string]any `json:"record,omitempty"`
+}
+
+// CascadeSpec describes one or more cascade graphs.
 type CascadeSpec interface {
 	String() string
+	// Validate reports unknown resolvers, unknown tables, and missing fields in the spec.
+	Validate(schema Schema) error
+}
+
+// CascadeGraph is the parsed form of a single "name:Type(sourceField,targetField)" graph.
+// SourceField is the field on Type rows that references the parent; TargetField is the parent
+// field it matches. Name is the graph's own segment; nested graphs are held in Children.
+type CascadeGraph struct {
+	Name        string         `json:"name,omitempty"`
+	Type        string         `json:"type"`
+	SourceField string         `json:"sourceField,omitempty"`
+	TargetField string         `json:"targetField,omitempty"`
+	Children    []CascadeGraph `json:"children,omitempty"`
 }
 
 // CascadeBuilder builds CascadeSpec values programmatically.
+//
+// Calling Graph again after a graph has been described starts another top-level graph; Nested
+// starts a graph beneath the one just described. All graphs are joined into a single spec.
 type CascadeBuilder interface {
 	Graph(name string) CascadeBuilder
+	Nested(name string) CascadeBuilder
 	GraphType(table string) CascadeBuilder
 	SourceField(field string) CascadeBuilder
 	TargetField(field string) CascadeBuilder
@@ -29,60 +65,308 @@ type cascadeSpec string
 
 func (c cascadeSpec) String() string { return string(c) }
 
+func (c cascadeSpec) Validate(schema Schema) error {
+	graphs, err := ParseCascadeSpec(string(c))
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, g := range graphs {
+		errs = append(errs, validateCascadeGraph(schema, g, g.Name, nil)...)
+	}
+	return errors.Join(errs...)
+}
+
 // Cascade creates a CascadeSpec from a string representation.
 func Cascade(spec string) CascadeSpec { return cascadeSpec(spec) }
 
+// CascadeFromGraphs renders parsed graphs, including nested ones, back into a CascadeSpec.
+func CascadeFromGraphs(graphs []CascadeGraph) CascadeSpec {
+	var parts []string
+	var walk func(prefix string, gs []CascadeGraph)
+	walk = func(prefix string, gs []CascadeGraph) {
+		for _, g := range gs {
+			name := g.Name
+			if prefix != "" {
+				name = prefix + "." + g.Name
+			}
+			parts = append(parts, formatCascadeGraph(name, g.Type, g.SourceField, g.TargetField))
+			walk(name, g.Children)
+		}
+	}
+	walk("", graphs)
+	return cascadeSpec(strings.Join(parts, ","))
+}
+
+// ParseCascadeSpec parses a spec of comma-separated graphs into a tree. A graph named
+// "roles.permissions" is nested under the graph named "roles", which must also be present.
+func ParseCascadeSpec(spec string) ([]CascadeGraph, error) {
+	type entry struct {
+		path  string
+		graph CascadeGraph
+	}
+	var entries []entry
+	seen := map[string]bool{}
+	for _, part := range splitCascadeSpec(spec) {
+		path, g, err := parseCascadeGraph(part)
+		if err != nil {
+			return nil, err
+		}
+		if path != "" {
+			if seen[path] {
+				return nil, fmt.Errorf("duplicate cascade graph %q", path)
+			}
+			seen[path] = true
+		}
+		entries = append(entries, entry{path: path, graph: g})
+	}
+	if strings.Count(spec, "(") != strings.Count(spec, ")") {
+		return nil, fmt.Errorf("invalid cascade spec %q: unbalanced parentheses", spec)
+	}
+
+	for _, e := range entries {
+		if parent, _, nested := cutLast(e.path, "."); nested && !seen[parent] {
+			return nil, fmt.Errorf("cascade graph %q has no parent graph %q", e.path, parent)
+		}
+	}
+	var children func(prefix string) []CascadeGraph
+	children = func(prefix string) []CascadeGraph {
+		var out []CascadeGraph
+		for _, e := range entries {
+			parent, _, nested := cutLast(e.path, ".")
+			if (nested && parent == prefix) || (!nested && prefix == "") {
+				g := e.graph
+				if e.path != "" {
+					g.Children = children(e.path)
+				}
+				out = append(out, g)
+			}
+		}
+		return out
+	}
+	return children(""), nil
+}
+
+// splitCascadeSpec splits on commas that are not inside parentheses.
+func splitCascadeSpec(spec string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i <= len(spec); i++ {
+		if i < len(spec) {
+			switch spec[i] {
+			case '(':
+				depth++
+			case ')':
+				depth--
+			}
+			if spec[i] != ',' || depth > 0 {
+				continue
+			}
+		}
+		if part := strings.TrimSpace(spec[start:i]); part != "" {
+			parts = append(parts, part)
+		}
+		start = i + 1
+	}
+	return parts
+}
+
+func parseCascadeGraph(part string) (string, CascadeGraph, error) {
+	var g CascadeGraph
+	head := part
+	if open := strings.Index(part, "("); open >= 0 {
+		if !strings.HasSuffix(part, ")") {
+			return "", g, fmt.Errorf("invalid cascade graph %q", part)
+		}
+		// Fields are positional, so "(,target)" keeps an empty source slot.
+		fields := strings.Split(part[open+1:len(part)-1], ",")
+		if len(fields) > 2 {
+			return "", g, fmt.Errorf("invalid cascade graph %q: expected at most two fields", part)
+		}
+		g.SourceField = strings.TrimSpace(fields[0])
+		if len(fields) > 1 {
+			g.TargetField = strings.TrimSpace(fields[1])
+		}
+		head = part[:open]
+	}
+	path := ""
+	if name, typ, ok := strings.Cut(head, ":"); ok {
+		path, g.Type = strings.TrimSpace(name), strings.TrimSpace(typ)
+	} else {
+		g.Type = strings.TrimSpace(head)
+	}
+	if g.Type == "" {
+		return "", g, fmt.Errorf("invalid cascade graph %q: missing type", part)
+	}
+	_, g.Name, _ = cutLast(path, ".")
+	if path != "" && g.Name == "" {
+		return "", g, fmt.Errorf("invalid cascade graph %q: empty graph name", part)
+	}
+	return path, g, nil
+}
+
+// cutLast splits s around the last sep; without sep the whole string is returned as after.
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return "", s, false
+}
+
+// validateCascadeGraph checks g against the schema. Top-level graphs have no known parent table,
+// so the parent is taken to be any table declaring a resolver with the graph's name.
+func validateCascadeGraph(schema Schema, g CascadeGraph, path string, parent *Table) []error {
+	label := path
+	if label == "" {
+		label = g.Type
+	}
+	var errs []error
+	target, ok := schema.Table(g.Type)
+	if !ok {
+		errs = append(errs, fmt.Errorf("cascade graph %s: unknown table %s", label, g.Type))
+	}
+	if ok && g.SourceField != "" && !tableHasField(target, g.SourceField) {
+		errs = append(errs, fmt.Errorf("cascade graph %s: table %s has no field %s", label, g.Type, g.SourceField))
+	}
+
+	var parents []Table
+	if parent != nil {
+		parents = []Table{*parent}
+	} else if g.Name != "" {
+		for _, t := range schema.Tables {
+			if tableHasResolver(t, g.Name) {
+				parents = append(parents, t)
+			}
+		}
+		if len(parents) == 0 {
+			errs = append(errs, fmt.Errorf("cascade graph %s: no table has resolver %s", label, g.Name))
+		}
+	}
+	if parent != nil && g.Name != "" && !tableHasResolver(*parent, g.Name) {
+		errs = append(errs, fmt.Errorf("cascade graph %s: table %s has no resolver %s", label, parent.Name, g.Name))
+	}
+	if g.TargetField != "" && len(parents) > 0 {
+		found := false
+		var names []string
+		for _, p := range parents {
+			names = append(names, p.Name)
+			found = found || tableHasField(p, g.TargetField)
+		}
+		if !found {
+			errs = append(errs, fmt.Errorf("cascade graph %s: table %s has no field %s", label, strings.Join(names, "/"), g.TargetField))
+		}
+	}
+
+	for _, child := range g.Children {
+		childPath := child.Name
+		if path != "" {
+			childPath = path + "." + child.Name
+		}
+		if ok {
+			errs = append(errs, validateCascadeGraph(schema, child, childPath, &target)...)
+		}
+	}
+	return errs
+}
+
+func tableHasField(t Table, name string) bool {
+	for _, f := range t.Fields {
+		if f.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func tableHasResolver(t Table, name string) bool {
+	for _, r := range t.Resolvers {
+		if r.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 type cascadeBuilder struct {
-	graphName   string
+	graphs  []string
+	current cascadeDraft
+}
+
+type cascadeDraft struct {
+	name        string
 	graphType   string
 	sourceField string
 	targetField string
 }
 
+func (d cascadeDraft) empty() bool { return d == cascadeDraft{} }
+
 // NewCascadeBuilder returns a CascadeBuilder instance.
 func NewCascadeBuilder() CascadeBuilder {
 	return &cascadeBuilder{}
 }
 
 func (c *cascadeBuilder) Graph(name string) CascadeBuilder {
-	c.graphName = name
+	c.flush()
+	c.current.name = name
+	return c
+}
+
+func (c *cascadeBuilder) Nested(name string) CascadeBuilder {
+	parent := c.current.name
+	c.flush()
+	if parent != "" {
+		name = parent + "." + name
+	}
+	c.current.name = name
 	return c
 }
 
 func (c *cascadeBuilder) GraphType(table string) CascadeBuilder {
-	c.graphType = table
+	c.current.graphType = table
 	return c
 }
 
 func (c *cascadeBuilder) SourceField(field string) CascadeBuilder {
-	c.sourceField = field
+	c.current.sourceField = field
 	return c
 }
 
 func (c *cascadeBuilder) TargetField(field string) CascadeBuilder {
-	c.targetField = field
+	c.current.targetField = field
 	return c
 }
 
 func (c *cascadeBuilder) Build() CascadeSpec {
-	var base string
-	if c.graphName != "" {
-		base = c.graphName + ":" + c.graphType
-	} else {
-		base = c.graphType
+	graphs := c.graphs
+	if !c.current.empty() {
+		d := c.current
+		graphs = append(graphs[:len(graphs):len(graphs)], formatCascadeGraph(d.name, d.graphType, d.sourceField, d.targetField))
 	}
+	return cascadeSpec(strings.Join(graphs, ","))
+}
 
-	var fields []string
-	if c.sourceField != "" {
-		fields = append(fields, c.sourceField)
-	}
-	if c.targetField != "" {
-		fields = append(fields, c.targetField)
+func (c *cascadeBuilder) flush() {
+	if !c.current.empty() {
+		d := c.current
+		c.graphs = append(c.graphs, formatCascadeGraph(d.name, d.graphType, d.sourceField, d.targetField))
 	}
+	c.current = cascadeDraft{}
+}
 
-	if len(fields) > 0 {
-		base += "(" + strings.Join(fields, ",") + ")"
+func formatCascadeGraph(name, graphType, sourceField, targetField string) string {
+	var base string
+	if name != "" {
+		base = name + ":" + graphType
+	} else {
+		base = graphType
 	}
 
-	return cascadeSpec(base)
+	switch {
+	case targetField != "":
+		base += "(" + sourceField + "," + targetField + ")"
+	case sourceField != "":
+		base += "(" + sourceField + ")"
+	}
+	return base
 }
@@ -1,6 +1,10 @@
 package contract
 
-import "testing"
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
 
 func TestCascadeString(t *testing.T) {
 	spec := Cascade("userRoles:UserRole(userId,id)")
@@ -21,3 +25,92 @@ func TestCascadeBuilder(t *testing.T) {
 		t.Fatalf("unexpected builder output: %s", got)
 	}
 }
+
+func TestCascadeBuilderComposesGraphs(t *testing.T) {
+	spec := NewCascadeBuilder().
+		Graph("userRoles").GraphType("UserRole").SourceField("userId").TargetField("id").
+		Graph("roles").GraphType("Role").SourceField("id").TargetField("roleId").
+		Nested("permissions").GraphType("Permission").
+		Nested("grants").GraphType("Grant").SourceField("permissionId").
+		Build()
+
+	want := "userRoles:UserRole(userId,id),roles:Role(id,roleId),roles.permissions:Permission,roles.permissions.grants:Grant(permissionId)"
+	if spec.String() != want {
+		t.Fatalf("unexpected spec:\n got %s\nwant %s", spec.String(), want)
+	}
+}
+
+func TestParseCascadeSpecRoundTrip(t *testing.T) {
+	spec := "userRoles:UserRole(userId, id), roles:Role,roles.permissions:Permission(roleId,id),Audit"
+	graphs, err := ParseCascadeSpec(spec)
+	if err != nil {
+		t.Fatalf("parse err: %v", err)
+	}
+	if len(graphs) != 3 || graphs[0].SourceField != "userId" || graphs[0].TargetField != "id" || graphs[2].Name != "" || graphs[2].Type != "Audit" {
+		t.Fatalf("unexpected graphs: %+v", graphs)
+	}
+	nested := graphs[1].Children
+	if len(nested) != 1 || nested[0].Name != "permissions" || nested[0].Type != "Permission" {
+		t.Fatalf("unexpected nested graphs: %+v", graphs[1])
+	}
+	if got := CascadeFromGraphs(graphs).String(); got != "userRoles:UserRole(userId,id),roles:Role,roles.permissions:Permission(roleId,id),Audit" {
+		t.Fatalf("unexpected round trip: %s", got)
+	}
+
+	for _, bad := range []string{"a:B(c", "a:(x)", "a:B(x,y,z)", "a.b:C", "a:B,a:C", ".b:C"} {
+		if _, err := ParseCascadeSpec(bad); err == nil {
+			t.Fatalf("expected parse error for %q", bad)
+		}
+	}
+}
+
+func TestCascadeGraphWithOnlyTargetFieldRoundTrips(t *testing.T) {
+	graphs := []CascadeGraph{{Name: "owner", Type: "User", TargetField: "ownerId"}}
+	spec := CascadeFromGraphs(graphs).String()
+	if spec != "owner:User(,ownerId)" {
+		t.Fatalf("unexpected spec: %s", spec)
+	}
+	if got := NewCascadeBuilder().Graph("owner").GraphType("User").TargetField("ownerId").Build().String(); got != spec {
+		t.Fatalf("builder rendered %s, want %s", got, spec)
+	}
+	parsed, err := ParseCascadeSpec(spec)
+	if err != nil {
+		t.Fatalf("parse err: %v", err)
+	}
+	if !reflect.DeepEqual(parsed, graphs) {
+		t.Fatalf("round trip changed graphs: %+v", parsed)
+	}
+}
+
+func TestCascadeSpecValidate(t *testing.T) {
+	schema := Schema{Tables: []Table{
+		{Name: "User", Fields: []Field{{Name: "id"}}, Resolvers: []Resolver{{Name: "userRoles"}, {Name: "roles"}}},
+		{Name: "UserRole", Fields: []Field{{Name: "id"}, {Name: "userId"}, {Name: "roleId"}}},
+		{Name: "Role", Fields: []Field{{Name: "id"}}, Resolvers: []Resolver{{Name: "permissions"}}},
+		{Name: "Permission", Fields: []Field{{Name: "id"}, {Name: "roleId"}}},
+	}}
+
+	if err := Cascade("userRoles:UserRole(userId,id),roles:Role,roles.permissions:Permission(roleId,id)").Validate(schema); err != nil {
+		t.Fatalf("expected valid spec, got %v", err)
+	}
+
+	err := Cascade("userRoles:UserRol(userId,id),roles:Role(id,missing),roles.perms:Permission(nope,id),groups:User").Validate(schema)
+	if err == nil {
+		t.Fatalf("expected validation errors")
+	}
+	for _, want := range []string{
+		"userRoles: unknown table UserRol",
+		"roles: table User has no field missing",
+		"roles.perms: table Role has no resolver perms",
+		"roles.perms: table Permission has no field nope",
+		"groups: no table has resolver groups",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q in:\n%v", want, err)
+		}
+	}
+
+	if err := Cascade("a:B(").Validate(schema); err == nil {
+		t.Fatalf("expected parse error from Validate")
+	}
+}
@@ -13,6 +13,9 @@ type Client interface {
 	Save(ctx context.Context, table string, entity any, relationships []string) (map[string]any, error)
 	Delete(ctx context.Context, table, id string) error
 	BatchSave(ctx context.Context, table string, entities []any, batchSize int) error
+	Patch(ctx context.Context, table, id string, updates map[string]any) (PatchResult, error)
+	PatchMany(ctx context.Context, table string, ops []PatchOp) ([]PatchResult, error)
+	UnitOfWork() UnitOfWork
 
 	Schema(ctx context.Context) (Schema, error)
 	GetSchema(ctx context.Context, tables []string) (Schema, error)
@@ -20,6 +23,7 @@ type Client interface {
 	UpdateSchema(ctx context.Context, schema Schema, publish bool) error
 	ValidateSchema(ctx context.Context, schema Schema) error
 	GetSchemaHistory(ctx context.Context) ([]Schema, error)
+	GetSchemaRevisions(ctx context.Context) ([]SchemaRevision, error)
 
 	Documents() OnyxDocumentsClient
 
@@ -27,4 +31,6 @@ type Client interface {
 	GetSecret(ctx context.Context, key string) (OnyxSecret, error)
 	PutSecret(ctx context.Context, secret OnyxSecret) (OnyxSecret, error)
 	DeleteSecret(ctx context.Context, key string) error
+
+	Stats() ClientStats
 }
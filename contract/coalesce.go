@@ -0,0 +1,18 @@
+package contract
+
+import "context"
+
+type noCoalescingKey struct{}
+
+// WithoutCoalescing returns a context whose reads always send their own request, even when the
+// client has Config.CoalesceReads enabled. It applies to every read made with the context or one
+// derived from it; to opt out a single query, use Query.Fresh instead.
+func WithoutCoalescing(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noCoalescingKey{}, true)
+}
+
+// CoalescingDisabled reports whether ctx was derived from WithoutCoalescing.
+func CoalescingDisabled(ctx context.Context) bool {
+	disabled, _ := ctx.Value(noCoalescingKey{}).(bool)
+	return disabled
+}
@@ -0,0 +1,22 @@
+package contract
+
+import (
+	"context"
+	"testing"
+)
+
+func TestWithoutCoalescing(t *testing.T) {
+	ctx := context.Background()
+	if CoalescingDisabled(ctx) {
+		t.Fatalf("coalescing should be allowed by default")
+	}
+	opted := WithoutCoalescing(ctx)
+	if !CoalescingDisabled(opted) {
+		t.Fatalf("expected coalescing to be disabled")
+	}
+	child, cancel := context.WithCancel(opted)
+	defer cancel()
+	if !CoalescingDisabled(child) {
+		t.Fatalf("derived contexts should keep the opt-out")
+	}
+}
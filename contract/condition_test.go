@@ -22,11 +22,13 @@ func (s stubQuery) Delete(context.Context) (int, error)        { return 0, nil }
 func (s stubQuery) Page(context.Context, string) (PageResult, error) {
 	return PageResult{}, nil
 }
-func (s stubQuery) Stream(context.Context) (Iterator, error) { return nil, nil }
-func (s stubQuery) SetUpdates(map[string]any) Query          { return s }
-func (s stubQuery) Update(context.Context) (int, error)      { return 0, nil }
-func (s stubQuery) InPartition(string) Query                 { return s }
-func (s stubQuery) MarshalJSON() ([]byte, error)             { return []byte(`{"table":"User"}`), nil }
+func (s stubQuery) Stream(context.Context) (Iterator, error)        { return nil, nil }
+func (s stubQuery) SetUpdates(map[string]any) Query                 { return s }
+func (s stubQuery) Update(context.Context) (int, error)             { return 0, nil }
+func (s stubQuery) InPartition(string) Query                        { return s }
+func (s stubQuery) Fresh() Query                                    { return s }
+func (s stubQuery) Filter(r []map[string]any) (QueryResults, error) { return r, nil }
+func (s stubQuery) MarshalJSON() ([]byte, error)                    { return []byte(`{"table":"User"}`), nil }
 
 func TestConditionJSON(t *testing.T) {
 	sampleQuery := stubQuery{}
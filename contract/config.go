@@ -20,4 +20,15 @@ type Config struct {
 	HTTPClient      *http.Client
 	Clock           func() time.Time
 	Sleep           func(time.Duration)
+	// VersionFields enables optimistic locking for the listed tables, mapping table name to version field.
+	VersionFields map[string]string
+	// OptimisticLocking also enables it for tables whose schema Meta declares a "versionField".
+	OptimisticLocking bool
+	// PatchConcurrency bounds the number of in-flight requests issued by PatchMany (default 8).
+	PatchConcurrency int
+	// QueryCache enables the opt-in query result cache when set.
+	QueryCache *QueryCacheConfig
+	// CoalesceReads lets identical concurrent List and Page calls share one request and its
+	// decoded result. Opt a call out with WithoutCoalescing.
+	CoalesceReads bool
 }
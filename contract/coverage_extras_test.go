@@ -23,7 +23,7 @@ func TestNormalizeSchemaSortingAcrossTables(t *testing.T) {
 				},
 				Resolvers: []Resolver{{Name: "beta"}, {Name: "alpha"}},
 				Indexes:   []Index{{Name: "z"}, {Name: "a"}},
-				Triggers:  []string{"t2", "t1"},
+				Triggers:  []Trigger{{Name: "t2"}, {Name: "t1"}},
 			},
 			{Name: "A"},
 		},
@@ -101,7 +101,7 @@ func TestSchemaFromEntitiesWithMetaAndResolvers(t *testing.T) {
 	if len(tbl.Indexes) != 1 || tbl.Indexes[0].Name != "idx_label" {
 		t.Fatalf("indexes not parsed: %+v", tbl.Indexes)
 	}
-	if len(tbl.Triggers) != 2 || tbl.Triggers[1] != "t2" {
+	if len(tbl.Triggers) != 2 || tbl.Triggers[1].Name != "t2" {
 		t.Fatalf("triggers not parsed: %+v", tbl.Triggers)
 	}
 	if tbl.Meta["tag"] != "x" {
@@ -138,7 +138,7 @@ func TestSchemaFromTablesArrayFullCoverage(t *testing.T) {
 	if len(tbl.Resolvers) != 2 || tbl.Resolvers[1].Meta["x"] != "y" {
 		t.Fatalf("resolver meta missing: %+v", tbl.Resolvers)
 	}
-	if len(tbl.Triggers) != 2 || tbl.Triggers[0] != "t1" || tbl.Triggers[1] != "t2" {
+	if len(tbl.Triggers) != 2 || tbl.Triggers[0].Name != "t1" || tbl.Triggers[1].Name != "t2" {
 		t.Fatalf("triggers parsed incorrectly: %+v", tbl.Triggers)
 	}
 	if tbl.Meta["owner"] != "team" {
@@ -1,11 +1,16 @@
 package contract
 
 import (
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
 )
 
+// ErrCodeConflict is the Error code reported when an optimistic-locking save loses a race.
+// Meta carries "table", "id", "expectedVersion", and "currentVersion" (nil when the record no longer exists).
+const ErrCodeConflict = "conflict"
+
 // Error represents a structured error returned by the SDK and CLI.
 type Error struct {
 	Code    string
@@ -50,3 +55,9 @@ func NewError(code, message string, meta map[string]any) *Error {
 		Meta:    meta,
 	}
 }
+
+// IsConflict reports whether err (or any error it wraps) is an optimistic-locking conflict.
+func IsConflict(err error) bool {
+	var e *Error
+	return errors.As(err, &e) && e.Code == ErrCodeConflict
+}
@@ -1,6 +1,9 @@
 package contract
 
-import "testing"
+import (
+	"fmt"
+	"testing"
+)
 
 func TestErrorStringFormatting(t *testing.T) {
 	err := &Error{
@@ -36,3 +39,13 @@ func TestNewErrorHelper(t *testing.T) {
 		t.Fatalf("unexpected error fields: %#v", err)
 	}
 }
+
+func TestIsConflict(t *testing.T) {
+	conflict := NewError(ErrCodeConflict, "version conflict", nil)
+	if !IsConflict(conflict) || !IsConflict(fmt.Errorf("wrapped: %w", conflict)) {
+		t.Fatalf("expected conflict to be detected")
+	}
+	if IsConflict(NewError("other", "nope", nil)) || IsConflict(nil) {
+		t.Fatalf("unexpected conflict detection")
+	}
+}
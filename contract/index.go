@@ -1,6 +1,13 @@
 package contract
 
 // Index describes an index definition.
+//
+// Type is the index kind (for example "DEFAULT", "LUCENE", or "VECTOR") and Fields lists the
+// indexed attributes when they differ from the index name. Options carries any remaining
+// index settings, such as vector dimensions, so they survive a fetch and publish.
 type Index struct {
-	Name string `json:"name"`
+	Name    string         `json:"name"`
+	Type    string         `json:"type,omitempty"`
+	Fields  []string       `json:"fields,omitempty"`
+	Options map[string]any `json:"options,omitempty"`
 }
@@ -0,0 +1,355 @@
+package contract
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Matches reports whether record satisfies cond using the same semantics the service applies to
+// the condition's JSON form, so stream events and cached rows can be filtered client-side.
+//
+// Fields are looked up by name first and then as dotted paths into embedded objects. Numbers
+// compare numerically, strings that both parse as RFC 3339 timestamps compare by instant, other
+// strings lexically, and booleans with false before true. BETWEEN is inclusive; LIKE is
+// case-sensitive and anchored, with % matching any run of characters and _ exactly one; CONTAINS
+// tests substrings of strings and elements of arrays. Full-text Search conditions match when any
+// query term occurs, case-insensitively, in the record, and minScore is the number of distinct
+// terms required. A nil condition matches every record.
+//
+// Within and NotWithin depend on another table and cannot be evaluated locally; they return an
+// error.
+func Matches(cond Condition, record map[string]any) (bool, error) {
+	tree, err := conditionTree(cond)
+	if err != nil {
+		return false, err
+	}
+	return matchTree(tree, record)
+}
+
+// FilterRecords returns the records that satisfy cond, in their original order. It decodes the
+// condition once, so prefer it to calling Matches in a loop.
+func FilterRecords(cond Condition, records []map[string]any) (QueryResults, error) {
+	tree, err := conditionTree(cond)
+	if err != nil {
+		return nil, err
+	}
+	out := QueryResults{}
+	for _, record := range records {
+		ok, err := matchTree(tree, record)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			out = append(out, record)
+		}
+	}
+	return out, nil
+}
+
+// conditionTree decodes the JSON form of cond. A nil condition decodes to a nil tree.
+func conditionTree(cond Condition) (map[string]any, error) {
+	if cond == nil {
+		return nil, nil
+	}
+	raw, err := cond.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var tree map[string]any
+	if err := json.Unmarshal(raw, &tree); err != nil {
+		return nil, fmt.Errorf("decode condition: %w", err)
+	}
+	return tree, nil
+}
+
+func matchTree(cond map[string]any, record map[string]any) (bool, error) {
+	if cond == nil {
+		return true, nil
+	}
+	switch cond["conditionType"] {
+	case "CompoundCondition":
+		op, _ := cond["operator"].(string)
+		or := strings.EqualFold(op, "OR")
+		children, _ := cond["conditions"].([]any)
+		for _, child := range children {
+			childCond, _ := child.(map[string]any)
+			ok, err := matchTree(childCond, record)
+			if err != nil {
+				return false, err
+			}
+			if ok == or {
+				return ok, nil
+			}
+		}
+		return !or || len(children) == 0, nil
+	case "SingleCondition", nil:
+		criteria, _ := cond["criteria"].(map[string]any)
+		if criteria == nil {
+			return false, fmt.Errorf("condition has no criteria")
+		}
+		return matchCriteria(criteria, record)
+	}
+	return false, fmt.Errorf("unsupported condition type %v", cond["conditionType"])
+}
+
+func matchCriteria(criteria map[string]any, record map[string]any) (bool, error) {
+	field, _ := criteria["field"].(string)
+	op, _ := criteria["operator"].(string)
+	want := criteria["value"]
+
+	if op == "MATCHES" && field == fullTextField {
+		return matchFullText(want, record), nil
+	}
+
+	got, present := lookupField(record, field)
+	switch op {
+	case "EQUAL":
+		return valuesEqual(got, want), nil
+	case "NOT_EQUAL":
+		return !valuesEqual(got, want), nil
+	case "IN", "NOT_IN":
+		var values []any
+		switch t := want.(type) {
+		case []any:
+			values = t
+		case map[string]any:
+			return false, fmt.Errorf("%s on %s uses a nested query, which only the server can evaluate", op, field)
+		case nil:
+		default:
+			values = []any{t}
+		}
+		found := false
+		for _, v := range values {
+			if valuesEqual(got, v) {
+				found = true
+				break
+			}
+		}
+		return found == (op == "IN"), nil
+	case "BETWEEN":
+		bounds, _ := want.(map[string]any)
+		lo, okLo := compareValues(got, bounds["from"])
+		hi, okHi := compareValues(got, bounds["to"])
+		return okLo && okHi && lo >= 0 && hi <= 0, nil
+	case "GREATER_THAN", "GREATER_THAN_EQUAL", "LESS_THAN", "LESS_THAN_EQUAL":
+		cmp, ok := compareValues(got, want)
+		if !ok {
+			return false, nil
+		}
+		switch op {
+		case "GREATER_THAN":
+			return cmp > 0, nil
+		case "GREATER_THAN_EQUAL":
+			return cmp >= 0, nil
+		case "LESS_THAN":
+			return cmp < 0, nil
+		default:
+			return cmp <= 0, nil
+		}
+	case "LIKE":
+		s, ok := got.(string)
+		pattern, okPattern := want.(string)
+		if !ok || !okPattern {
+			return false, nil
+		}
+		return likePattern(pattern).MatchString(s), nil
+	case "CONTAINS":
+		switch container := got.(type) {
+		case string:
+			sub, ok := want.(string)
+			return ok && strings.Contains(container, sub), nil
+		case []any:
+			for _, item := range container {
+				if valuesEqual(jsonValue(item), want) {
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	case "STARTS_WITH":
+		s, ok := got.(string)
+		prefix, okPrefix := want.(string)
+		return ok && okPrefix && strings.HasPrefix(s, prefix), nil
+	case "IS_NULL":
+		return !present || got == nil, nil
+	case "NOT_NULL":
+		return present && got != nil, nil
+	case "MATCHES":
+		s, ok := got.(string)
+		pattern, okPattern := want.(string)
+		if !ok || !okPattern {
+			return false, nil
+		}
+		re, err := regexp.Compile("^(?:" + pattern + ")$")
+		if err != nil {
+			return false, fmt.Errorf("MATCHES %s: %w", field, err)
+		}
+		return re.MatchString(s), nil
+	}
+	return false, fmt.Errorf("unsupported operator %q", op)
+}
+
+// lookupField resolves field in record, trying the literal key before walking a dotted path.
+// Values are returned in their JSON form so typed Go values compare like decoded ones.
+func lookupField(record map[string]any, field string) (any, bool) {
+	if v, ok := record[field]; ok {
+		return jsonValue(v), true
+	}
+	cur := any(record)
+	for _, part := range strings.Split(field, ".") {
+		obj, ok := jsonValue(cur).(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if cur, ok = obj[part]; !ok {
+			return nil, false
+		}
+	}
+	return jsonValue(cur), true
+}
+
+// jsonValue converts v to the value encoding/json would decode it as.
+func jsonValue(v any) any {
+	switch t := v.(type) {
+	case nil, string, bool, float64, []any, map[string]any:
+		return v
+	case int:
+		return float64(t)
+	case int64:
+		return float64(t)
+	case json.Number:
+		if f, err := t.Float64(); err == nil {
+			return f
+		}
+		return t.String()
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var out any
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return v
+	}
+	return out
+}
+
+func valuesEqual(a, b any) bool {
+	if cmp, ok := compareValues(a, b); ok {
+		return cmp == 0
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// compareValues orders two JSON scalars of the same kind. ok is false when they are not
+// comparable.
+func compareValues(a, b any) (int, bool) {
+	switch x := a.(type) {
+	case float64:
+		if y, ok := b.(float64); ok {
+			switch {
+			case x < y:
+				return -1, true
+			case x > y:
+				return 1, true
+			}
+			return 0, true
+		}
+	case string:
+		y, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+		if tx, err := time.Parse(time.RFC3339Nano, x); err == nil {
+			if ty, err := time.Parse(time.RFC3339Nano, y); err == nil {
+				return tx.Compare(ty), true
+			}
+		}
+		return strings.Compare(x, y), true
+	case bool:
+		if y, ok := b.(bool); ok {
+			switch {
+			case x == y:
+				return 0, true
+			case y:
+				return -1, true
+			}
+			return 1, true
+		}
+	}
+	return 0, false
+}
+
+func likePattern(pattern string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("(?s)^")
+	for _, r := range pattern {
+		switch r {
+		case '%':
+			b.WriteString(".*")
+		case '_':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+// matchFullText approximates the service's Lucene matching: each distinct query term, optionally
+// scoped as field:term, counts once when it occurs in the record. Repeating a term adds nothing.
+func matchFullText(value any, record map[string]any) bool {
+	q, _ := value.(map[string]any)
+	text, _ := q["queryText"].(string)
+	var score float64
+	seen := map[string]bool{}
+	for _, term := range strings.Fields(text) {
+		var scope any = record
+		field := ""
+		if f, t, ok := strings.Cut(term, ":"); ok && f != "" {
+			scope, _ = lookupField(record, f)
+			field, term = f, t
+		}
+		term = strings.ToLower(strings.TrimFunc(term, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }))
+		if term == "" || term == "and" || term == "or" || term == "not" || seen[field+":"+term] {
+			continue
+		}
+		seen[field+":"+term] = true
+		if textContains(jsonValue(scope), term) {
+			score++
+		}
+	}
+	if score == 0 {
+		return false
+	}
+	minScore, ok := q["minScore"].(float64)
+	return !ok || score >= minScore
+}
+
+func textContains(v any, term string) bool {
+	switch t := v.(type) {
+	case string:
+		return strings.Contains(strings.ToLower(t), term)
+	case float64:
+		return strings.Contains(fmt.Sprint(t), term)
+	case map[string]any:
+		for _, item := range t {
+			if textContains(jsonValue(item), term) {
+				return true
+			}
+		}
+	case []any:
+		for _, item := range t {
+			if textContains(jsonValue(item), term) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,192 @@
+package contract
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type matchProfile struct {
+	City string `json:"city"`
+	Zip  int    `json:"zip"`
+}
+
+func TestMatchesOperators(t *testing.T) {
+	joined := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	record := map[string]any{
+		"name":     "Ada Lovelace",
+		"email":    "ada@example.com",
+		"age":      36,
+		"score":    json.Number("9.5"),
+		"active":   true,
+		"joined":   joined,
+		"tags":     []string{"admin", "ops"},
+		"ids":      []any{1, 2, 3},
+		"nickname": nil,
+		"profile":  map[string]any{"address": matchProfile{City: "London", Zip: 1815}},
+	}
+
+	cases := []struct {
+		name string
+		cond Condition
+		want bool
+	}{
+		{"eq string", Eq("name", "Ada Lovelace"), true},
+		{"eq number across types", Eq("age", 36.0), true},
+		{"eq json number", Eq("score", 9.5), true},
+		{"eq bool", Eq("active", true), true},
+		{"neq", Neq("age", 36), false},
+		{"in", In("age", []any{30, 36}), true},
+		{"not in", NotIn("age", []any{30, 36}), false},
+		{"in empty", In("age", nil), false},
+		{"between numbers inclusive", Between("age", 36, 40), true},
+		{"between numbers outside", Between("age", 37, 40), false},
+		{"between strings", Between("name", "A", "B"), true},
+		{"between timestamps", Between("joined", joined.Add(-time.Hour), joined), true},
+		{"between timestamps with offsets", Between("joined", "2024-03-01T13:00:00+01:00", "2024-03-01T12:30:00Z"), true},
+		{"gt", Gt("age", 35), true},
+		{"gte", Gte("age", 36), true},
+		{"lt", Lt("age", 36), false},
+		{"lte", Lte("joined", joined), true},
+		{"gt mismatched kinds", Gt("age", "35"), false},
+		{"bool ordering", Gt("active", false), true},
+		{"like percent", Like("email", "%@example.com"), true},
+		{"like underscore", Like("name", "Ad_ Lovelace"), true},
+		{"like anchored", Like("email", "ada@example"), false},
+		{"like case sensitive", Like("name", "ada%"), false},
+		{"like escapes regexp", Like("email", "ada@example.c.m"), false},
+		{"contains substring", Contains("email", "@example"), true},
+		{"contains element", Contains("tags", "ops"), true},
+		{"contains number element", Contains("ids", 2), true},
+		{"contains missing element", Contains("tags", "dev"), false},
+		{"starts with", StartsWith("email", "ada@"), true},
+		{"is null explicit", IsNull("nickname"), true},
+		{"is null missing", IsNull("missing"), true},
+		{"not null", NotNull("name"), true},
+		{"not null missing", NotNull("missing"), false},
+		{"dotted path into struct", Eq("profile.address.city", "London"), true},
+		{"dotted path number", Gte("profile.address.zip", 1800), true},
+		{"dotted path missing", Eq("profile.address.country", "UK"), false},
+		{"search term", Search("lovelace"), true},
+		{"search scoped term", Search("email:example"), true},
+		{"search scoped miss", Search("name:example"), false},
+		{"search min score", Search("ada ops babbage", 3), false},
+		{"search min score met", Search("ada ops babbage", 2), true},
+		{"search repeated term counts once", Search("ada ada Ada", 2), false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Matches(tc.cond, record)
+			if err != nil {
+				t.Fatalf("matches: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("Matches(%s) = %v, want %v", mustJSON(t, tc.cond), got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMatchesCompoundConditions(t *testing.T) {
+	record := map[string]any{"age": 30, "tenant": "a", "active": false}
+	compound := func(op string, conds ...Condition) Condition {
+		children := make([]json.RawMessage, len(conds))
+		for i, c := range conds {
+			children[i] = mustJSON(t, c)
+		}
+		raw, _ := json.Marshal(map[string]any{"conditionType": "CompoundCondition", "operator": op, "conditions": children})
+		return json.RawMessage(raw)
+	}
+
+	cases := []struct {
+		name string
+		cond Condition
+		want bool
+	}{
+		{"and", compound("AND", Eq("tenant", "a"), Gt("age", 18)), true},
+		{"and short circuits", compound("AND", Eq("tenant", "b"), Gt("age", 18)), false},
+		{"or", compound("OR", Eq("tenant", "b"), Eq("active", false)), true},
+		{"or none", compound("OR", Eq("tenant", "b"), Eq("active", true)), false},
+		{"nested", compound("AND", compound("OR", Eq("tenant", "b"), Lt("age", 40)), NotNull("tenant")), true},
+		{"empty and", compound("AND"), true},
+		{"empty or", compound("OR"), true},
+		{"nil", nil, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Matches(tc.cond, record)
+			if err != nil || got != tc.want {
+				t.Fatalf("Matches = %v, %v; want %v", got, err, tc.want)
+			}
+		})
+	}
+}
+
+func TestMatchesErrors(t *testing.T) {
+	record := map[string]any{"id": "u1", "name": "ada"}
+	cases := []struct {
+		name string
+		cond Condition
+		want string
+	}{
+		{"within", Within("id", stubQuery{}), "nested query"},
+		{"not within", NotWithin("id", stubQuery{}), "nested query"},
+		{"bad marshal", condition{op: "within", field: "id", query: errQuery{}}, "boom"},
+		{"unknown operator", json.RawMessage(`{"conditionType":"SingleCondition","criteria":{"field":"id","operator":"SOUNDS_LIKE","value":"x"}}`), "unsupported operator"},
+		{"unknown type", json.RawMessage(`{"conditionType":"Other"}`), "unsupported condition type"},
+		{"no criteria", json.RawMessage(`{"conditionType":"SingleCondition"}`), "no criteria"},
+		{"bad regexp", json.RawMessage(`{"conditionType":"SingleCondition","criteria":{"field":"name","operator":"MATCHES","value":"("}}`), "MATCHES name"},
+		{"not an object", json.RawMessage(`[1]`), "decode condition"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Matches(tc.cond, record)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+
+	ok, err := Matches(json.RawMessage(`{"conditionType":"SingleCondition","criteria":{"field":"name","operator":"MATCHES","value":"a.a"}}`), record)
+	if err != nil || !ok {
+		t.Fatalf("expected regexp match, got %v %v", ok, err)
+	}
+}
+
+func TestFilterRecords(t *testing.T) {
+	records := []map[string]any{
+		{"id": "u1", "age": 20},
+		{"id": "u2", "age": 40},
+		{"id": "u3", "age": 30},
+	}
+	got, err := FilterRecords(Gte("age", 30), records)
+	if err != nil {
+		t.Fatalf("filter: %v", err)
+	}
+	if want := (QueryResults{records[1], records[2]}); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected records %v", got)
+	}
+
+	all, err := FilterRecords(nil, records)
+	if err != nil || len(all) != 3 {
+		t.Fatalf("nil condition should keep every record: %v %v", all, err)
+	}
+	none, err := FilterRecords(Eq("id", "missing"), records)
+	if err != nil || none == nil || len(none) != 0 {
+		t.Fatalf("expected empty results, got %#v %v", none, err)
+	}
+	if _, err := FilterRecords(Within("id", stubQuery{}), records); err == nil {
+		t.Fatalf("expected nested query error")
+	}
+}
+
+func mustJSON(t *testing.T, v json.Marshaler) json.RawMessage {
+	t.Helper()
+	raw, err := v.MarshalJSON()
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	return raw
+}
@@ -0,0 +1,26 @@
+package contract
+
+// PatchOp describes a partial update applied to the record with the given primary key.
+type PatchOp struct {
+	ID      string         `json:"id"`
+	Updates map[string]any `json:"updates"`
+}
+
+// PatchStatus reports the outcome of patching a single record.
+type PatchStatus string
+
+const (
+	// PatchUpdated indicates the record existed and the updates were applied.
+	PatchUpdated PatchStatus = "updated"
+	// PatchMissing indicates no record matched the primary key.
+	PatchMissing PatchStatus = "missing"
+	// PatchFailed indicates the update request failed; see PatchResult.Err.
+	PatchFailed PatchStatus = "error"
+)
+
+// PatchResult captures the outcome of a single patch.
+type PatchResult struct {
+	ID     string      `json:"id"`
+	Status PatchStatus `json:"status"`
+	Err    error       `json:"-"`
+}
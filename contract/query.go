@@ -15,6 +15,9 @@ type Query interface {
 	Limit(limit int) Query
 	SetUpdates(updates map[string]any) Query
 	InPartition(partition string) Query
+	// Fresh returns a copy of the query whose reads skip the client's query cache and read
+	// coalescing, so each List or Page sends its own request.
+	Fresh() Query
 	Update(ctx context.Context) (int, error)
 
 	List(ctx context.Context) (QueryResults, error)
@@ -22,5 +25,9 @@ type Query interface {
 	Stream(ctx context.Context) (Iterator, error)
 	Delete(ctx context.Context) (int, error)
 
+	// Filter applies the query's conditions to records locally, using Matches semantics. Sorts,
+	// limits, projections, and partitions are not applied.
+	Filter(records []map[string]any) (QueryResults, error)
+
 	MarshalJSON() ([]byte, error)
 }
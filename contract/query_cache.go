@@ -0,0 +1,46 @@
+package contract
+
+import "time"
+
+// QueryCacheConfig enables the client-side cache of List and Page results. Entries are keyed
+// by the canonical query payload, so equal queries built in different places share them.
+type QueryCacheConfig struct {
+	// TTL applies to tables without an entry in TableTTLs. Zero leaves those tables uncached.
+	TTL time.Duration
+	// TableTTLs overrides TTL per table; a zero or negative value disables caching for the table.
+	TableTTLs map[string]time.Duration
+	// MaxEntries bounds the cache; the least recently used entry is evicted first (default 1000).
+	MaxEntries int
+	// WatchTables opens a background stream per table that drops the table's entries whenever
+	// another client changes it. Streams reconnect until the context passed to Init is done or
+	// the client is closed.
+	WatchTables []string
+}
+
+// QueryCacheStats reports query cache activity since the client was initialized.
+type QueryCacheStats struct {
+	Hits   int64 `json:"hits"`
+	Misses int64 `json:"misses"`
+	// Evictions counts entries dropped because they expired or the cache was full.
+	Evictions int64 `json:"evictions"`
+	// Invalidations counts entries dropped because their tables changed.
+	Invalidations int64 `json:"invalidations"`
+	Entries       int   `json:"entries"`
+}
+
+// CoalescingStats reports read coalescing activity since the client was initialized.
+type CoalescingStats struct {
+	// Requests counts the reads that went to the network on behalf of one or more callers.
+	Requests int64 `json:"requests"`
+	// Coalesced counts the callers that joined a read already in flight instead of sending
+	// their own.
+	Coalesced int64 `json:"coalesced"`
+	// InFlight counts the reads new callers can currently join.
+	InFlight int `json:"inFlight"`
+}
+
+// ClientStats reports counters for the client's optional request features.
+type ClientStats struct {
+	QueryCache QueryCacheStats `json:"queryCache"`
+	Coalescing CoalescingStats `json:"coalescing"`
+}
@@ -1,12 +1,21 @@
 package contract
 
+import "encoding/json"
+
 // Field represents a single column in a table definition.
+//
+// Generator is the identifier generator (for example "UUID", "Sequence", or "None") and is only
+// meaningful on the primary-key field. MaxSize is the maximum length for strings, and Default is
+// the value the server assigns when the field is omitted.
 type Field struct {
-	Name     string `json:"name"`
-	Type     string `json:"type"`
-	Nullable bool   `json:"nullable,omitempty"`
-	Primary  bool   `json:"primaryKey,omitempty"`
-	Unique   bool   `json:"unique,omitempty"`
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Nullable  bool   `json:"nullable,omitempty"`
+	Primary   bool   `json:"primaryKey,omitempty"`
+	Unique    bool   `json:"unique,omitempty"`
+	Generator string `json:"generator,omitempty"`
+	MaxSize   int    `json:"maxSize,omitempty"`
+	Default   any    `json:"defaultValue,omitempty"`
 }
 
 // Resolver represents a resolver definition on a table.
@@ -16,13 +25,31 @@ type Resolver struct {
 	Meta     map[string]any `json:"meta,omitempty"`
 }
 
+// Trigger represents a trigger definition on a table: the event it fires on and its script body.
+type Trigger struct {
+	Name    string `json:"name"`
+	Event   string `json:"event,omitempty"`
+	Trigger string `json:"trigger,omitempty"`
+}
+
+// UnmarshalJSON accepts either a trigger object or a bare trigger name.
+func (t *Trigger) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*t = Trigger{Name: name}
+		return nil
+	}
+	type plain Trigger
+	return json.Unmarshal(data, (*plain)(t))
+}
+
 // Table represents a database table with fields.
 type Table struct {
 	Name      string         `json:"name"`
 	Fields    []Field        `json:"fields"`
 	Resolvers []Resolver     `json:"resolvers,omitempty"`
 	Indexes   []Index        `json:"indexes,omitempty"`
-	Triggers  []string       `json:"triggers,omitempty"`
+	Triggers  []Trigger      `json:"triggers,omitempty"`
 	Partition string         `json:"partition,omitempty"`
 	Meta      map[string]any `json:"meta,omitempty"`
 }
@@ -0,0 +1,100 @@
+package contract
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// SchemaRevision is one published schema from a database's history, oldest first.
+//
+// Revision identifies the entry for history commands. When the API does not report one, it is
+// the entry's 1-based position in the history.
+type SchemaRevision struct {
+	Revision  string    `json:"revision"`
+	Author    string    `json:"author,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	Schema    Schema    `json:"schema"`
+}
+
+// ParseSchemaHistoryJSON parses a schema history response: a JSON array of schema documents, or
+// an object holding one under "schemas" or "history". Each entry may be in either schema format
+// and may carry metadata at the top level or in a nested "meta" object: revisionId (or revision,
+// version), author (or createdBy, publishedBy) and createdAt (or publishedAt, updatedAt) as an
+// RFC 3339 string or epoch milliseconds.
+func ParseSchemaHistoryJSON(data []byte) ([]SchemaRevision, error) {
+	var raw any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	items, _ := raw.([]any)
+	if obj, ok := raw.(map[string]any); ok {
+		if list, ok := obj["schemas"].([]any); ok {
+			items = list
+		} else {
+			items, _ = obj["history"].([]any)
+		}
+	}
+
+	revisions := make([]SchemaRevision, 0, len(items))
+	for _, item := range items {
+		obj, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		entry, err := json.Marshal(obj)
+		if err != nil {
+			return nil, err
+		}
+		schema, err := ParseSchemaJSON(entry)
+		if err != nil {
+			return nil, err
+		}
+		meta := mapValue(obj["meta"])
+		rev := SchemaRevision{
+			Revision:  revisionValue(firstValue(obj, meta, "revisionId", "revision", "version")),
+			Author:    stringValue(firstValue(obj, meta, "author", "createdBy", "publishedBy")),
+			CreatedAt: timeValue(firstValue(obj, meta, "createdAt", "publishedAt", "updatedAt")),
+			Schema:    schema,
+		}
+		if rev.Revision == "" {
+			rev.Revision = strconv.Itoa(len(revisions) + 1)
+		}
+		revisions = append(revisions, rev)
+	}
+	return revisions, nil
+}
+
+// firstValue returns the first of keys set on obj, then on meta.
+func firstValue(obj, meta map[string]any, keys ...string) any {
+	for _, m := range []map[string]any{obj, meta} {
+		for _, k := range keys {
+			if v, ok := m[k]; ok && v != nil {
+				return v
+			}
+		}
+	}
+	return nil
+}
+
+func revisionValue(v any) string {
+	switch n := v.(type) {
+	case string:
+		return n
+	case float64:
+		return strconv.FormatFloat(n, 'f', -1, 64)
+	}
+	return ""
+}
+
+func timeValue(v any) time.Time {
+	switch t := v.(type) {
+	case string:
+		if parsed, err := time.Parse(time.RFC3339Nano, t); err == nil {
+			return parsed.UTC()
+		}
+	case float64:
+		return time.UnixMilli(int64(t)).UTC()
+	}
+	return time.Time{}
+}
@@ -0,0 +1,64 @@
+package contract
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSchemaHistoryJSONMetadata(t *testing.T) {
+	data := []byte(`[
+  {"revisionId": "r1", "author": "ada", "createdAt": "2024-05-01T10:00:00Z",
+   "entities": [{"name": "User", "identifier": {"name": "id"}, "attributes": [{"name": "id", "type": "String"}]}]},
+  "skipped",
+  {"meta": {"revision": 2, "createdBy": "grace", "publishedAt": 1717236000000},
+   "schema": {"tables": [{"name": "User", "fields": [{"name": "id", "type": "String", "primaryKey": true}]}, {"name": "Role"}]}},
+  {"tables": []}
+]`)
+
+	revs, err := ParseSchemaHistoryJSON(data)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(revs) != 3 {
+		t.Fatalf("expected 3 revisions, got %d", len(revs))
+	}
+
+	first := revs[0]
+	if first.Revision != "r1" || first.Author != "ada" || !first.CreatedAt.Equal(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected first revision: %+v", first)
+	}
+	if len(first.Schema.Tables) != 1 || !first.Schema.Tables[0].Fields[0].Primary {
+		t.Fatalf("unexpected first schema: %+v", first.Schema)
+	}
+
+	second := revs[1]
+	if second.Revision != "2" || second.Author != "grace" || second.CreatedAt.UnixMilli() != 1717236000000 {
+		t.Fatalf("unexpected second revision: %+v", second)
+	}
+	if len(second.Schema.Tables) != 2 {
+		t.Fatalf("unexpected second schema: %+v", second.Schema)
+	}
+
+	if revs[2].Revision != "3" || !revs[2].CreatedAt.IsZero() {
+		t.Fatalf("expected positional revision without metadata, got %+v", revs[2])
+	}
+}
+
+func TestParseSchemaHistoryJSONWrapped(t *testing.T) {
+	for _, doc := range []string{
+		`{"schemas": [{"tables": [{"name": "A"}]}]}`,
+		`{"history": [{"tables": [{"name": "A"}]}]}`,
+	} {
+		revs, err := ParseSchemaHistoryJSON([]byte(doc))
+		if err != nil || len(revs) != 1 || revs[0].Schema.Tables[0].Name != "A" || revs[0].Revision != "1" {
+			t.Fatalf("%s: unexpected result %+v, err %v", doc, revs, err)
+		}
+	}
+
+	if revs, err := ParseSchemaHistoryJSON([]byte(`{}`)); err != nil || len(revs) != 0 {
+		t.Fatalf("expected empty history, got %+v, err %v", revs, err)
+	}
+	if _, err := ParseSchemaHistoryJSON([]byte(`[`)); err == nil {
+		t.Fatalf("expected parse error")
+	}
+}
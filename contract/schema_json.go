@@ -5,6 +5,40 @@ import (
 	"sort"
 )
 
+// Entity is the API's "entities" representation of a table, as returned by the schema
+// endpoints and sent when publishing. Indexes are kept as objects so index options sit
+// alongside the index name and type, as the API expects.
+type Entity struct {
+	Name       string            `json:"name"`
+	Identifier *EntityIdentifier `json:"identifier,omitempty"`
+	Attributes []EntityAttribute `json:"attributes,omitempty"`
+	Partition  string            `json:"partition,omitempty"`
+	Indexes    []map[string]any  `json:"indexes,omitempty"`
+	Resolvers  []Resolver        `json:"resolvers,omitempty"`
+	Triggers   []Trigger         `json:"triggers,omitempty"`
+	Meta       map[string]any    `json:"meta,omitempty"`
+}
+
+// EntityIdentifier names an entity's primary key and how it is generated.
+type EntityIdentifier struct {
+	Name      string `json:"name"`
+	Generator string `json:"generator,omitempty"`
+	Type      string `json:"type,omitempty"`
+}
+
+// EntityAttribute is a field in the entities representation.
+type EntityAttribute struct {
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+	MaxSize      int    `json:"maxSize,omitempty"`
+	IsNullable   bool   `json:"isNullable,omitempty"`
+	IsUnique     bool   `json:"isUnique,omitempty"`
+	DefaultValue any    `json:"defaultValue,omitempty"`
+}
+
+// defaultIdentifierGenerator is sent for primary keys that do not declare a generator.
+const defaultIdentifierGenerator = "None"
+
 func normalizeResolvers(res []Resolver) []Resolver {
 	out := make([]Resolver, len(res))
 	copy(out, res)
@@ -13,17 +47,21 @@ func normalizeResolvers(res []Resolver) []Resolver {
 }
 
 // ParseSchemaJSON parses a schema document from JSON bytes.
+//
+// Both the tables format written by the CLI and the entities format used by the API are
+// accepted, optionally wrapped in {"schema": ...} or a {"schemas": [...]} history list (the
+// latest entry wins).
 func ParseSchemaJSON(data []byte) (Schema, error) {
-	var s Schema
-	if err := json.Unmarshal(data, &s); err == nil && len(s.Tables) > 0 {
-		return s, nil
-	}
-
 	var raw map[string]any
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return Schema{}, err
 	}
 
+	if list, ok := raw["schemas"].([]any); ok && len(list) > 0 {
+		if latest, ok := list[len(list)-1].(map[string]any); ok {
+			raw = latest
+		}
+	}
 	if nested, ok := raw["schema"].(map[string]any); ok {
 		raw = nested
 	}
@@ -36,10 +74,59 @@ func ParseSchemaJSON(data []byte) (Schema, error) {
 		return schemaFromEntities(entities), nil
 	}
 
-	return s, nil
+	return Schema{}, nil
 }
 
-// NormalizeSchema returns a copy of the schema with deterministic ordering.
+// SchemaToEntities converts a schema into the API's entities representation. It is the inverse
+// of parsing an entities document, so a fetched schema can be published without loss.
+func SchemaToEntities(s Schema) []Entity {
+	entities := make([]Entity, 0, len(s.Tables))
+	for _, t := range s.Tables {
+		ent := Entity{
+			Name:      t.Name,
+			Partition: t.Partition,
+			Resolvers: t.Resolvers,
+			Triggers:  t.Triggers,
+			Meta:      t.Meta,
+		}
+		for _, f := range t.Fields {
+			ent.Attributes = append(ent.Attributes, EntityAttribute{
+				Name:         f.Name,
+				Type:         f.Type,
+				MaxSize:      f.MaxSize,
+				IsNullable:   f.Nullable,
+				IsUnique:     f.Unique,
+				DefaultValue: f.Default,
+			})
+			if f.Primary {
+				generator := f.Generator
+				if generator == "" {
+					generator = defaultIdentifierGenerator
+				}
+				ent.Identifier = &EntityIdentifier{Name: f.Name, Generator: generator, Type: f.Type}
+			}
+		}
+		for _, idx := range t.Indexes {
+			entry := make(map[string]any, len(idx.Options)+3)
+			for k, v := range idx.Options {
+				entry[k] = v
+			}
+			entry["name"] = idx.Name
+			if idx.Type != "" {
+				entry["type"] = idx.Type
+			}
+			if len(idx.Fields) > 0 {
+				entry["fields"] = idx.Fields
+			}
+			ent.Indexes = append(ent.Indexes, entry)
+		}
+		entities = append(entities, ent)
+	}
+	return entities
+}
+
+// NormalizeSchema returns a copy of the schema with deterministic ordering. A primary key without
+// a generator is given the server default, "None", so local and fetched schemas compare equal.
 func NormalizeSchema(s Schema) Schema {
 	normalized := Schema{Tables: make([]Table, len(s.Tables))}
 	copy(normalized.Tables, s.Tables)
@@ -54,6 +141,11 @@ func NormalizeSchema(s Schema) Schema {
 		sort.Slice(fields, func(a, b int) bool {
 			return fields[a].Name < fields[b].Name
 		})
+		for f := range fields {
+			if fields[f].Primary && fields[f].Generator == "" {
+				fields[f].Generator = defaultIdentifierGenerator
+			}
+		}
 		normalized.Tables[i].Fields = fields
 
 		if len(normalized.Tables[i].Resolvers) > 0 {
@@ -66,9 +158,9 @@ func NormalizeSchema(s Schema) Schema {
 			normalized.Tables[i].Indexes = indexes
 		}
 		if len(normalized.Tables[i].Triggers) > 0 {
-			trigs := make([]string, len(normalized.Tables[i].Triggers))
+			trigs := make([]Trigger, len(normalized.Tables[i].Triggers))
 			copy(trigs, normalized.Tables[i].Triggers)
-			sort.Strings(trigs)
+			sort.SliceStable(trigs, func(a, b int) bool { return trigs[a].Name < trigs[b].Name })
 			normalized.Tables[i].Triggers = trigs
 		}
 	}
@@ -83,14 +175,9 @@ func schemaFromEntities(items []any) Schema {
 		if !ok {
 			continue
 		}
-		name, _ := obj["name"].(string)
-		table := Table{Name: name}
-		var idName string
-		if ident, ok := obj["identifier"].(map[string]any); ok {
-			if n, ok := ident["name"].(string); ok {
-				idName = n
-			}
-		}
+		table := Table{Name: stringValue(obj["name"]), Partition: stringValue(obj["partition"])}
+		ident := mapValue(obj["identifier"])
+		idName := stringValue(ident["name"])
 		if attrs, ok := obj["attributes"].([]any); ok {
 			for _, a := range attrs {
 				attrObj, ok := a.(map[string]any)
@@ -98,56 +185,24 @@ func schemaFromEntities(items []any) Schema {
 					continue
 				}
 				field := Field{
-					Name: stringValue(attrObj["name"]),
-					Type: stringValue(attrObj["type"]),
+					Name:     stringValue(attrObj["name"]),
+					Type:     stringValue(attrObj["type"]),
+					Nullable: boolValue(attrObj["isNullable"]),
+					Unique:   boolValue(attrObj["isUnique"]),
+					MaxSize:  intValue(attrObj["maxSize"]),
+					Default:  attrObj["defaultValue"],
 				}
-				if n, ok := attrObj["isNullable"].(bool); ok {
-					field.Nullable = n
-				}
-				if field.Name == idName {
+				if idName != "" && field.Name == idName {
 					field.Primary = true
+					field.Generator = stringValue(ident["generator"])
 				}
 				table.Fields = append(table.Fields, field)
 			}
 		}
-		if res, ok := obj["resolvers"].([]any); ok {
-			for _, r := range res {
-				if rm, ok := r.(map[string]any); ok {
-					if name, ok := rm["name"].(string); ok {
-						table.Resolvers = append(table.Resolvers, Resolver{
-							Name:     name,
-							Resolver: stringValue(rm["resolver"]),
-							Meta:     mapValue(rm["meta"]),
-						})
-					}
-				} else if name, ok := r.(string); ok {
-					table.Resolvers = append(table.Resolvers, Resolver{Name: name})
-				}
-			}
-		}
-		if idxs, ok := obj["indexes"].([]any); ok {
-			for _, idx := range idxs {
-				if im, ok := idx.(map[string]any); ok {
-					if name, ok := im["name"].(string); ok {
-						table.Indexes = append(table.Indexes, Index{Name: name})
-					}
-				}
-			}
-		}
-		if trigs, ok := obj["triggers"].([]any); ok {
-			for _, t := range trigs {
-				if name, ok := t.(string); ok {
-					table.Triggers = append(table.Triggers, name)
-				} else if tm, ok := t.(map[string]any); ok {
-					if name, ok := tm["name"].(string); ok {
-						table.Triggers = append(table.Triggers, name)
-					}
-				}
-			}
-		}
-		if meta, ok := obj["meta"].(map[string]any); ok {
-			table.Meta = meta
-		}
+		table.Resolvers = resolversFrom(obj["resolvers"])
+		table.Indexes = indexesFrom(obj["indexes"])
+		table.Triggers = triggersFrom(obj["triggers"])
+		table.Meta = mapValue(obj["meta"])
 		tables = append(tables, table)
 	}
 	return Schema{Tables: tables}
@@ -160,7 +215,7 @@ func schemaFromTablesArray(items []any) Schema {
 		if !ok {
 			continue
 		}
-		t := Table{Name: stringValue(obj["name"])}
+		t := Table{Name: stringValue(obj["name"]), Partition: stringValue(obj["partition"])}
 		if fields, ok := obj["fields"].([]any); ok {
 			for _, f := range fields {
 				fm, ok := f.(map[string]any)
@@ -168,57 +223,103 @@ func schemaFromTablesArray(items []any) Schema {
 					continue
 				}
 				field := Field{
-					Name:     stringValue(fm["name"]),
-					Type:     stringValue(fm["type"]),
-					Nullable: boolValue(fm["nullable"]),
-					Primary:  boolValue(fm["primaryKey"]),
-					Unique:   boolValue(fm["unique"]),
+					Name:      stringValue(fm["name"]),
+					Type:      stringValue(fm["type"]),
+					Nullable:  boolValue(fm["nullable"]),
+					Primary:   boolValue(fm["primaryKey"]),
+					Unique:    boolValue(fm["unique"]),
+					Generator: stringValue(fm["generator"]),
+					MaxSize:   intValue(fm["maxSize"]),
+					Default:   fm["defaultValue"],
 				}
 				t.Fields = append(t.Fields, field)
 			}
 		}
-		if res, ok := obj["resolvers"].([]any); ok {
-			for _, r := range res {
-				switch v := r.(type) {
-				case string:
-					t.Resolvers = append(t.Resolvers, Resolver{Name: v})
-				case map[string]any:
-					if name, ok := v["name"].(string); ok {
-						t.Resolvers = append(t.Resolvers, Resolver{
-							Name:     name,
-							Resolver: stringValue(v["resolver"]),
-							Meta:     mapValue(v["meta"]),
-						})
-					}
-				}
+		t.Resolvers = resolversFrom(obj["resolvers"])
+		t.Indexes = indexesFrom(obj["indexes"])
+		t.Triggers = triggersFrom(obj["triggers"])
+		t.Meta = mapValue(obj["meta"])
+		tables = append(tables, t)
+	}
+	return Schema{Tables: tables}
+}
+
+func resolversFrom(v any) []Resolver {
+	items, _ := v.([]any)
+	var out []Resolver
+	for _, r := range items {
+		switch rv := r.(type) {
+		case string:
+			out = append(out, Resolver{Name: rv})
+		case map[string]any:
+			if name, ok := rv["name"].(string); ok {
+				out = append(out, Resolver{
+					Name:     name,
+					Resolver: stringValue(rv["resolver"]),
+					Meta:     mapValue(rv["meta"]),
+				})
 			}
 		}
-		if idxs, ok := obj["indexes"].([]any); ok {
-			for _, idx := range idxs {
-				if im, ok := idx.(map[string]any); ok {
-					if name, ok := im["name"].(string); ok {
-						t.Indexes = append(t.Indexes, Index{Name: name})
-					}
-				}
-			}
+	}
+	return out
+}
+
+// indexesFrom reads indexes from either format. Keys other than name, type, and fields are kept
+// as options; a nested "options" object (the tables format) is merged in as well.
+func indexesFrom(v any) []Index {
+	items, _ := v.([]any)
+	var out []Index
+	for _, idx := range items {
+		im, ok := idx.(map[string]any)
+		if !ok {
+			continue
 		}
-		if trigs, ok := obj["triggers"].([]any); ok {
-			for _, trg := range trigs {
-				if tm, ok := trg.(map[string]any); ok {
-					if name, ok := tm["name"].(string); ok {
-						t.Triggers = append(t.Triggers, name)
-					}
-				} else if name, ok := trg.(string); ok {
-					t.Triggers = append(t.Triggers, name)
+		name, ok := im["name"].(string)
+		if !ok {
+			continue
+		}
+		index := Index{Name: name, Type: stringValue(im["type"]), Fields: stringsValue(im["fields"])}
+		for k, val := range im {
+			switch k {
+			case "name", "type", "fields":
+			case "options":
+				for key, ov := range mapValue(val) {
+					index.setOption(key, ov)
 				}
+			default:
+				index.setOption(k, val)
 			}
 		}
-		if meta, ok := obj["meta"].(map[string]any); ok {
-			t.Meta = meta
+		out = append(out, index)
+	}
+	return out
+}
+
+func (idx *Index) setOption(key string, value any) {
+	if idx.Options == nil {
+		idx.Options = map[string]any{}
+	}
+	idx.Options[key] = value
+}
+
+func triggersFrom(v any) []Trigger {
+	items, _ := v.([]any)
+	var out []Trigger
+	for _, trg := range items {
+		switch tv := trg.(type) {
+		case string:
+			out = append(out, Trigger{Name: tv})
+		case map[string]any:
+			if name, ok := tv["name"].(string); ok {
+				out = append(out, Trigger{
+					Name:    name,
+					Event:   stringValue(tv["event"]),
+					Trigger: stringValue(tv["trigger"]),
+				})
+			}
 		}
-		tables = append(tables, t)
 	}
-	return Schema{Tables: tables}
+	return out
 }
 
 func stringValue(v any) string {
@@ -235,6 +336,30 @@ func boolValue(v any) bool {
 	return false
 }
 
+func intValue(v any) int {
+	switch n := v.(type) {
+	case float64:
+		return int(n)
+	case int:
+		return n
+	case json.Number:
+		i, _ := n.Int64()
+		return int(i)
+	}
+	return 0
+}
+
+func stringsValue(v any) []string {
+	items, _ := v.([]any)
+	var out []string
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
 func mapValue(v any) map[string]any {
 	if m, ok := v.(map[string]any); ok {
 		return m
@@ -34,7 +34,7 @@ func TestNormalizeResolversSortsAndConverts(t *testing.T) {
 	if err != nil {
 		t.Fatalf("parse schema json: %v", err)
 	}
-	if len(parsed.Tables) != 1 || parsed.Tables[0].Indexes[0].Name != "idx" || parsed.Tables[0].Triggers[0] != "tr" {
+	if len(parsed.Tables) != 1 || parsed.Tables[0].Indexes[0].Name != "idx" || parsed.Tables[0].Triggers[0].Name != "tr" {
 		t.Fatalf("expected indexes/triggers/meta parsed: %+v", parsed.Tables[0])
 	}
 }
@@ -1,6 +1,11 @@
 package contract
 
-import "testing"
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+)
 
 func TestParseSchemaJSONTables(t *testing.T) {
 	data := []byte(`{
@@ -94,3 +99,82 @@ func TestParseSchemaJSONNestedSchemaObject(t *testing.T) {
 		t.Fatalf("unexpected tables: %+v", schema.Tables)
 	}
 }
+
+func loadEntitiesFixture(t *testing.T) ([]byte, Schema) {
+	t.Helper()
+	data, err := os.ReadFile("testdata/entities_full.json")
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	schema, err := ParseSchemaJSON(data)
+	if err != nil {
+		t.Fatalf("parse fixture: %v", err)
+	}
+	return data, schema
+}
+
+func decodeAny(t *testing.T, data []byte) any {
+	t.Helper()
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	return v
+}
+
+func TestSchemaEntitiesRoundTripIsLossless(t *testing.T) {
+	data, schema := loadEntitiesFixture(t)
+
+	doc := schema.Tables[0]
+	if doc.Fields[0].Generator != "UUID" || doc.Fields[2].MaxSize != 64 || doc.Fields[3].Default != "draft" {
+		t.Fatalf("field attributes not parsed: %+v", doc.Fields)
+	}
+	if doc.Indexes[0].Type != "LUCENE" || doc.Indexes[0].Options["minScore"] != 0.5 {
+		t.Fatalf("index attributes not parsed: %+v", doc.Indexes)
+	}
+	if doc.Triggers[0].Event != "PreInsert" || doc.Partition != "ownerId" {
+		t.Fatalf("triggers/partition not parsed: %+v", doc)
+	}
+
+	out, err := json.Marshal(map[string]any{"entities": SchemaToEntities(schema)})
+	if err != nil {
+		t.Fatalf("marshal entities: %v", err)
+	}
+	if got, want := decodeAny(t, out), decodeAny(t, data); !reflect.DeepEqual(got, want) {
+		t.Fatalf("entities round trip mismatch:\n got %s\nwant %s", out, data)
+	}
+}
+
+func TestSchemaTablesRoundTripIsLossless(t *testing.T) {
+	_, schema := loadEntitiesFixture(t)
+
+	data, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatalf("marshal tables: %v", err)
+	}
+	again, err := ParseSchemaJSON(data)
+	if err != nil {
+		t.Fatalf("parse tables: %v", err)
+	}
+	// Defaults decode as float64 either way, so the schemas compare directly.
+	if !reflect.DeepEqual(again, schema) {
+		t.Fatalf("tables round trip mismatch:\n got %+v\nwant %+v", again, schema)
+	}
+}
+
+func TestSchemaToEntitiesDefaultsGenerator(t *testing.T) {
+	entities := SchemaToEntities(Schema{Tables: []Table{{Name: "T", Fields: []Field{{Name: "id", Type: "String", Primary: true}}}}})
+	if entities[0].Identifier == nil || entities[0].Identifier.Generator != "None" {
+		t.Fatalf("expected default generator, got %+v", entities[0].Identifier)
+	}
+}
+
+func TestTriggerAcceptsBareName(t *testing.T) {
+	var trg []Trigger
+	if err := json.Unmarshal([]byte(`["audit", {"name":"stamp","event":"PreSave"}]`), &trg); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if trg[0].Name != "audit" || trg[1].Event != "PreSave" {
+		t.Fatalf("unexpected triggers: %+v", trg)
+	}
+}
@@ -54,7 +54,7 @@ func TestParseAndNormalizeSchema(t *testing.T) {
 		t.Fatalf("marshal normalized: %v", err)
 	}
 
-	expected := `{"tables":[{"name":"Order","fields":[{"name":"id","type":"string","primaryKey":true},{"name":"total","type":"float"}]},{"name":"User","fields":[{"name":"email","type":"string","unique":true},{"name":"id","type":"string","primaryKey":true}]}]}`
+	expected := `{"tables":[{"name":"Order","fields":[{"name":"id","type":"string","primaryKey":true,"generator":"None"},{"name":"total","type":"float"}]},{"name":"User","fields":[{"name":"email","type":"string","unique":true},{"name":"id","type":"string","primaryKey":true,"generator":"None"}]}]}`
 	if string(normalizedJSON) != expected {
 		t.Fatalf("unexpected normalized json: %s", string(normalizedJSON))
 	}
@@ -0,0 +1,95 @@
+package contract
+
+import "context"
+
+// UnitOfWork collects saves, updates, and deletes across tables and applies them together.
+//
+// Operations are ordered by the dependency graph implied by the schema's resolvers: writes to
+// parent tables run before writes to the tables that reference them, and deletes run afterwards
+// in the reverse order. The server has no multi-table transactions, so if any step fails the
+// steps already applied are compensated in reverse: inserted rows are deleted and updated or
+// deleted rows are restored from snapshots captured before they were changed. The cascade
+// children written by a Save with relationships are compensated the same way.
+//
+// A UnitOfWork is single-use and is not safe for concurrent use.
+type UnitOfWork interface {
+	// Save queues an insert or full replace of entity, optionally cascading relationships.
+	Save(table string, entity any, relationships ...string) UnitOfWork
+	// Update queues a partial update of the record with the given primary key.
+	Update(table, id string, updates map[string]any) UnitOfWork
+	// Delete queues removal of the record with the given primary key.
+	Delete(table, id string) UnitOfWork
+	// Commit applies the queued operations. The report is returned even when err is non-nil.
+	Commit(ctx context.Context) (UnitOfWorkReport, error)
+}
+
+// UnitOfWorkAction identifies the kind of queued operation.
+type UnitOfWorkAction string
+
+const (
+	UnitOfWorkSave   UnitOfWorkAction = "save"
+	UnitOfWorkUpdate UnitOfWorkAction = "update"
+	UnitOfWorkDelete UnitOfWorkAction = "delete"
+)
+
+// UnitOfWorkStatus reports what happened to a single step.
+type UnitOfWorkStatus string
+
+const (
+	// UnitOfWorkPending indicates the step was never attempted because an earlier step failed.
+	UnitOfWorkPending UnitOfWorkStatus = "pending"
+	// UnitOfWorkApplied indicates the step was applied and remains in effect.
+	UnitOfWorkApplied UnitOfWorkStatus = "applied"
+	// UnitOfWorkFailed indicates the step itself failed; see UnitOfWorkStep.Err.
+	UnitOfWorkFailed UnitOfWorkStatus = "failed"
+	// UnitOfWorkRolledBack indicates the step was applied and then compensated.
+	UnitOfWorkRolledBack UnitOfWorkStatus = "rolled_back"
+	// UnitOfWorkRollbackFailed indicates compensation failed; see UnitOfWorkStep.RollbackErr.
+	UnitOfWorkRollbackFailed UnitOfWorkStatus = "rollback_failed"
+)
+
+// Compensations recorded on UnitOfWorkStep.
+const (
+	// CompensateDelete removes a row the step inserted.
+	CompensateDelete = "delete"
+	// CompensateRestore writes back the snapshot captured before the step ran.
+	CompensateRestore = "restore"
+)
+
+// UnitOfWorkStep describes one operation in execution order.
+type UnitOfWorkStep struct {
+	Action       UnitOfWorkAction `json:"action"`
+	Table        string           `json:"table"`
+	ID           string           `json:"id,omitempty"`
+	Status       UnitOfWorkStatus `json:"status"`
+	Compensation string           `json:"compensation,omitempty"`
+	Err          error            `json:"-"`
+	RollbackErr  error            `json:"-"`
+}
+
+// UnitOfWorkReport details what a commit applied and what was rolled back.
+type UnitOfWorkReport struct {
+	Steps     []UnitOfWorkStep `json:"steps"`
+	Committed bool             `json:"committed"`
+}
+
+// Failed returns the step that aborted the commit, if any.
+func (r UnitOfWorkReport) Failed() (UnitOfWorkStep, bool) {
+	for _, s := range r.Steps {
+		if s.Status == UnitOfWorkFailed {
+			return s, true
+		}
+	}
+	return UnitOfWorkStep{}, false
+}
+
+// WithStatus returns the steps that ended in the given status, in execution order.
+func (r UnitOfWorkReport) WithStatus(status UnitOfWorkStatus) []UnitOfWorkStep {
+	var out []UnitOfWorkStep
+	for _, s := range r.Steps {
+		if s.Status == status {
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -1,6 +0,0 @@
-// Code generated by onyx gen; DO NOT EDIT.
-// Generated at: 2026-01-10T00:37:50Z
-
-package onyx
-
-//go:generate onyx gen --go --source file --schema ../../api/onyx.schema.json --out . --package onyx
@@ -1,5 +1,5 @@
-// Code generated by onyx gen --go; DO NOT EDIT.
-// Generated at: 2026-02-01T09:16:07Z
+// Code generated by onyx-go gen; DO NOT EDIT.
+// Generated at: 1970-01-01T00:00:00Z
 
 package onyx
 
@@ -25,6 +25,27 @@ type User struct {
 	UserRoles   any        `json:"userRoles,omitempty"`
 }
 
+// UserFields holds typed descriptors of the User attributes for conditions, sorts and selects.
+var UserFields = struct {
+	CreatedAt   onyx.TimeField
+	DeletedAt   onyx.TimeField
+	Email       onyx.StringField
+	Id          onyx.RefField[string]
+	IsActive    onyx.BoolField
+	LastLoginAt onyx.NullableTimeField
+	UpdatedAt   onyx.TimeField
+	Username    onyx.StringField
+}{
+	CreatedAt:   onyx.NewTimeField("createdAt"),
+	DeletedAt:   onyx.NewTimeField("deletedAt"),
+	Email:       onyx.NewStringField("email"),
+	Id:          onyx.NewRefField[string]("id"),
+	IsActive:    onyx.NewBoolField("isActive"),
+	LastLoginAt: onyx.NewTimeField("lastLoginAt").Nullable(),
+	UpdatedAt:   onyx.NewTimeField("updatedAt"),
+	Username:    onyx.NewStringField("username"),
+}
+
 // UserUpdates provides typed setters for update operations on User.
 type UserUpdates struct{ values map[string]any }
 
@@ -140,9 +161,6 @@ type UserRepository interface {
 	DeleteByID(ctx context.Context, id string) (int, error)
 	DeleteByIDs(ctx context.Context, ids []string) (int, error)
 	FindByID(ctx context.Context, id string) (User, error)
-	FindByEmail(ctx context.Context, email string) (User, error)
-	FindActiveUsers(ctx context.Context) ([]User, error)
-	CountActive(ctx context.Context) (int, error)
 }
 
 // UsersClient provides a fluent API for querying and manipulating User records.
@@ -463,40 +481,6 @@ func (c UsersClient) FindByID(ctx context.Context, id string) (User, error) {
 	}
 	return items[0], nil
 }
-func (c UsersClient) FindByEmail(ctx context.Context, email string) (User, error) {
-	if email == "" {
-		return User{}, fmt.Errorf("email cannot be empty")
-	}
-	items, err := c.Where(onyx.Eq("email", email)).Limit(1).List(ctx)
-	if err != nil {
-		return User{}, fmt.Errorf("failed to find user by email %s: %w", email, err)
-	}
-	if len(items) == 0 {
-		return User{}, nil
-	}
-	return items[0], nil
-}
-func (c UsersClient) FindActiveUsers(ctx context.Context) ([]User, error) {
-	items, err := c.Where(onyx.Eq("isActive", true)).List(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list active user: %w", err)
-	}
-	return items, nil
-}
-func (c UsersClient) CountActive(ctx context.Context) (int, error) {
-	res, err := c.Where(onyx.Eq("isActive", true)).Select("count(id)").List(ctx)
-	if err != nil {
-		return 0, fmt.Errorf("failed to count active user: %w", err)
-	}
-	if len(res) == 0 {
-		return 0, nil
-	}
-	count, err := parseCount(res[0]["count(id)"])
-	if err != nil {
-		return 0, fmt.Errorf("failed to parse active user count: %w", err)
-	}
-	return count, nil
-}
 func (c UsersMapClient) Where(cond onyx.Condition) UsersMapClient { c.q = c.q.Where(cond); return c }
 func (c UsersMapClient) And(cond onyx.Condition) UsersMapClient   { c.q = c.q.And(cond); return c }
 func (c UsersMapClient) Or(cond onyx.Condition) UsersMapClient    { c.q = c.q.Or(cond); return c }
@@ -0,0 +1,55 @@
+package onyx
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/OnyxDevTools/onyx-database-go/onyx"
+)
+
+// UserLookups lists the hand-written User helpers on UsersClient. They live outside the
+// generated files, which onyx-go gen overwrites, so regenerating the example keeps them.
+type UserLookups interface {
+	FindByEmail(ctx context.Context, email string) (User, error)
+	FindActiveUsers(ctx context.Context) ([]User, error)
+	CountActive(ctx context.Context) (int, error)
+}
+
+var _ UserLookups = UsersClient{}
+
+func (c UsersClient) FindByEmail(ctx context.Context, email string) (User, error) {
+	if email == "" {
+		return User{}, fmt.Errorf("email cannot be empty")
+	}
+	items, err := c.Where(onyx.Eq("email", email)).Limit(1).List(ctx)
+	if err != nil {
+		return User{}, fmt.Errorf("failed to find user by email %s: %w", email, err)
+	}
+	if len(items) == 0 {
+		return User{}, nil
+	}
+	return items[0], nil
+}
+
+func (c UsersClient) FindActiveUsers(ctx context.Context) ([]User, error) {
+	items, err := c.Where(onyx.Eq("isActive", true)).List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active user: %w", err)
+	}
+	return items, nil
+}
+
+func (c UsersClient) CountActive(ctx context.Context) (int, error) {
+	res, err := c.Where(onyx.Eq("isActive", true)).Select("count(id)").List(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count active user: %w", err)
+	}
+	if len(res) == 0 {
+		return 0, nil
+	}
+	count, err := parseCount(res[0]["count(id)"])
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse active user count: %w", err)
+	}
+	return count, nil
+}
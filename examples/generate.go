@@ -3,4 +3,4 @@
 
 package codegen
 
-//go:generate onyx gen --go --source file --schema ./api/onyx.schema.json --out ./gen/onyx --package onyx
+//go:generate go run github.com/OnyxDevTools/onyx-database-go/cmd/onyx-go gen --schema ./api/onyx.schema.json --out ./gen/onyx --package onyx
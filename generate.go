@@ -1,5 +1,5 @@
-//go:generate env ONYX_GEN_TIMESTAMP=1970-01-01T00:00:00Z onyx gen --go --source file --schema ./examples/api/onyx.schema.json --out ./examples/gen/onyx --package onyx
+//go:generate env ONYX_GEN_TIMESTAMP=1970-01-01T00:00:00Z go run ./cmd/onyx-go gen --schema ./examples/api/onyx.schema.json --out ./examples/gen/onyx --package onyx
 
 package tools
 
-// This file anchors go generate for deterministic example client output via the onyx CLI.
+// This file anchors go generate for deterministic example client output via onyx-go gen.
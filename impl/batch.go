@@ -25,6 +25,7 @@ func batchSave(ctx context.Context, c *client, table string, entities []any, bat
 	}
 
 	path := c.tablePath(table)
+	defer c.invalidate(table)
 
 	for start := 0; start < len(entities); start += batchSize {
 		end := start + batchSize
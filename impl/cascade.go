@@ -2,6 +2,8 @@ package impl
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/OnyxDevTools/onyx-database-go/contract"
 )
@@ -16,8 +18,205 @@ func (c *cascadeClient) Save(ctx context.Context, table string, entity any) erro
 	return err
 }
 
+// Delete removes the record and its cascade children depth-first, children before parents.
+// Every row is collected before the first delete so a lookup failure leaves the data untouched.
 func (c *cascadeClient) Delete(ctx context.Context, table, id string) error {
-	// Cascade deletes reuse the same Delete endpoint with relationship spec.
-	// The API currently only supports relationship filters via resolvers; no extra payload needed.
-	return c.client.Delete(ctx, table, id)
+	rows, err := c.DryRunDelete(ctx, table, id)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := c.client.Delete(ctx, row.Table, row.ID); err != nil {
+			return fmt.Errorf("cascade delete %s %s: %w", row.Table, row.ID, err)
+		}
+	}
+	return nil
+}
+
+func (c *cascadeClient) DryRunDelete(ctx context.Context, table, id string) ([]contract.CascadeRow, error) {
+	graphs, err := contract.ParseCascadeSpec(c.spec.String())
+	if err != nil {
+		return nil, err
+	}
+	pk, err := c.client.primaryKey(ctx, table)
+	if err != nil {
+		return nil, err
+	}
+	record, exists, err := c.client.fetchRecord(ctx, table, pk, id)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("%s %s not found", table, id)
+	}
+
+	w := &cascadeWalk{client: c.client, visited: map[string]bool{}, tables: map[string]contract.Table{}}
+	if err := w.visit(ctx, table, id, "", record, graphs, len(graphs) == 0); err != nil {
+		return nil, err
+	}
+	return w.rows, nil
+}
+
+// cascadeRows collects the rows reachable from record through the relationships of a cascade
+// save, children before parents. The record itself is not included.
+func (c *client) cascadeRows(ctx context.Context, table string, record map[string]any, relationships []string) ([]contract.CascadeRow, error) {
+	graphs, err := contract.ParseCascadeSpec(strings.Join(relationships, ","))
+	if err != nil {
+		return nil, err
+	}
+	pk, err := c.primaryKey(ctx, table)
+	if err != nil {
+		return nil, err
+	}
+	var id string
+	if v, ok := record[pk]; ok && v != nil {
+		id = fmt.Sprint(v)
+	}
+
+	w := &cascadeWalk{client: c, visited: map[string]bool{}, tables: map[string]contract.Table{}}
+	if err := w.visit(ctx, table, id, "", record, graphs, false); err != nil {
+		return nil, err
+	}
+	return w.rows[:len(w.rows)-1], nil
+}
+
+// cascadeEdge links a parent row to child rows whose ChildField equals the parent's ParentField.
+// Graphs holds the nested spec graphs to follow from the matched children.
+type cascadeEdge struct {
+	Name        string
+	Table       string
+	ChildField  string
+	ParentField string
+	Graphs      []contract.CascadeGraph
+}
+
+type cascadeWalk struct {
+	client  *client
+	visited map[string]bool
+	tables  map[string]contract.Table
+	rows    []contract.CascadeRow
+}
+
+// visit collects children before the row itself. With followResolvers set, children are found
+// through the table's resolvers at every level; otherwise only the spec's graphs, and their
+// nested graphs, are followed.
+func (w *cascadeWalk) visit(ctx context.Context, table, id, path string, record map[string]any, graphs []contract.CascadeGraph, followResolvers bool) error {
+	key := table + "\x00" + id
+	if w.visited[key] {
+		return nil
+	}
+	w.visited[key] = true
+
+	var edges []cascadeEdge
+	var err error
+	if followResolvers {
+		edges, err = w.resolverEdges(ctx, table)
+	} else {
+		edges, err = w.specEdges(ctx, table, graphs)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, edge := range edges {
+		value, ok := record[edge.ParentField]
+		if !ok || value == nil {
+			continue
+		}
+		childPK, err := w.client.primaryKey(ctx, edge.Table)
+		if err != nil {
+			return err
+		}
+		children, err := w.client.freshQuery(edge.Table).Where(contract.Eq(edge.ChildField, value)).List(ctx)
+		if err != nil {
+			return err
+		}
+		childPath := edge.Name
+		if path != "" {
+			childPath = path + "." + edge.Name
+		}
+		for _, child := range children {
+			childID, ok := child[childPK]
+			if !ok || childID == nil {
+				return fmt.Errorf("cascade child in %s has no %s", edge.Table, childPK)
+			}
+			if err := w.visit(ctx, edge.Table, fmt.Sprint(childID), childPath, child, edge.Graphs, followResolvers); err != nil {
+				return err
+			}
+		}
+	}
+
+	w.rows = append(w.rows, contract.CascadeRow{Table: table, ID: id, Path: path, Record: record})
+	return nil
+}
+
+// resolverEdges returns the resolvers that own child rows, i.e. those keyed on the table's primary key.
+func (w *cascadeWalk) resolverEdges(ctx context.Context, table string) ([]cascadeEdge, error) {
+	t, err := w.table(ctx, table)
+	if err != nil {
+		return nil, err
+	}
+	pk := primaryField(t)
+	var edges []cascadeEdge
+	seen := map[resolverLink]bool{}
+	for _, link := range resolverLinks(t) {
+		if pk == "" || link.SourceField != pk || seen[link] {
+			continue
+		}
+		seen[link] = true
+		edges = append(edges, cascadeEdge{Name: link.Resolver, Table: link.Target, ChildField: link.TargetField, ParentField: link.SourceField})
+	}
+	return edges, nil
+}
+
+// specEdges maps spec graphs to edges. A graph without a target field matches the parent's primary
+// key; a graph without any fields takes its mapping from the parent's resolver of the same name.
+func (w *cascadeWalk) specEdges(ctx context.Context, table string, graphs []contract.CascadeGraph) ([]cascadeEdge, error) {
+	edges := make([]cascadeEdge, 0, len(graphs))
+	for _, g := range graphs {
+		edge := cascadeEdge{Name: g.Name, Table: g.Type, ChildField: g.SourceField, ParentField: g.TargetField, Graphs: g.Children}
+		if edge.Name == "" {
+			edge.Name = g.Type
+		}
+		switch {
+		case edge.ChildField != "" && edge.ParentField == "":
+			pk, err := w.client.primaryKey(ctx, table)
+			if err != nil {
+				return nil, err
+			}
+			edge.ParentField = pk
+		case edge.ChildField == "":
+			t, err := w.table(ctx, table)
+			if err != nil {
+				return nil, err
+			}
+			for _, link := range resolverLinks(t) {
+				if link.Resolver == g.Name && link.Target == g.Type {
+					edge.ChildField, edge.ParentField = link.TargetField, link.SourceField
+					break
+				}
+			}
+			if edge.ChildField == "" {
+				return nil, fmt.Errorf("cascade graph %s on %s has no field mapping", edge.Name, table)
+			}
+		}
+		edges = append(edges, edge)
+	}
+	return edges, nil
+}
+
+func (w *cascadeWalk) table(ctx context.Context, table string) (contract.Table, error) {
+	if t, ok := w.tables[table]; ok {
+		return t, nil
+	}
+	schema, err := w.client.GetSchema(ctx, []string{table})
+	if err != nil {
+		return contract.Table{}, err
+	}
+	t, ok := schema.Table(table)
+	if !ok {
+		return contract.Table{}, fmt.Errorf("table %s not found in schema", table)
+	}
+	w.tables[table] = t
+	return t, nil
 }
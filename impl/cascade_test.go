@@ -0,0 +1,109 @@
+package impl
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+func cascadeRowKeys(rows []contract.CascadeRow) []string {
+	keys := make([]string, 0, len(rows))
+	for _, r := range rows {
+		keys = append(keys, r.Path+"|"+r.Table+" "+r.ID)
+	}
+	return keys
+}
+
+func seedUserRoles(srv *uowServer) {
+	srv.rows["User"]["u1"] = map[string]any{"id": "u1"}
+	srv.rows["UserRole"]["ur1"] = map[string]any{"id": "ur1", "userId": "u1", "roleId": "r1"}
+	srv.rows["UserRole"]["ur2"] = map[string]any{"id": "ur2", "userId": "u1", "roleId": "r2"}
+	srv.rows["UserRole"]["ur3"] = map[string]any{"id": "ur3", "userId": "u2", "roleId": "r1"}
+	srv.rows["Role"]["r1"] = map[string]any{"id": "r1"}
+}
+
+func TestCascadeDeleteFollowsSpec(t *testing.T) {
+	for _, spec := range []string{"userRoles:UserRole(userId,id)", "userRoles:UserRole"} {
+		srv := newUOWServer(t)
+		seedUserRoles(srv)
+		c := newTestClient(t, srv.handle)
+		cc := c.Cascade(contract.Cascade(spec))
+
+		rows, err := cc.DryRunDelete(context.Background(), "User", "u1")
+		if err != nil {
+			t.Fatalf("%s: dry run err: %v", spec, err)
+		}
+		want := []string{"userRoles|UserRole ur1", "userRoles|UserRole ur2", "|User u1"}
+		if got := cascadeRowKeys(rows); !reflect.DeepEqual(got, want) {
+			t.Fatalf("%s: unexpected plan %v", spec, got)
+		}
+		if rows[0].Record["roleId"] != "r1" {
+			t.Fatalf("%s: expected dry run to include records, got %+v", spec, rows[0])
+		}
+		if len(srv.writes) != 0 {
+			t.Fatalf("%s: dry run must not write: %v", spec, srv.writes)
+		}
+
+		if err := cc.Delete(context.Background(), "User", "u1"); err != nil {
+			t.Fatalf("%s: delete err: %v", spec, err)
+		}
+		if want := []string{"delete UserRole ur1", "delete UserRole ur2", "delete User u1"}; !reflect.DeepEqual(srv.writes, want) {
+			t.Fatalf("%s: unexpected deletes %v", spec, srv.writes)
+		}
+		if _, ok := srv.rows["UserRole"]["ur3"]; !ok || len(srv.rows["Role"]) != 1 {
+			t.Fatalf("%s: unrelated rows must survive", spec)
+		}
+	}
+}
+
+func TestCascadeDeleteWalksResolversWithCycles(t *testing.T) {
+	srv := newUOWServer(t)
+	srv.schema = `{"tables":[{"name":"Node","fields":[{"name":"id","primaryKey":true},{"name":"parentId"}],
+		"resolvers":[{"name":"children","resolver":"db.from(\"Node\").where(eq(\"parentId\", this.id)).list()"},
+		             {"name":"parent","resolver":"db.from(\"Node\").where(eq(\"id\", this.parentId)).firstOrNull()"}]}]}`
+	srv.rows["Node"] = map[string]map[string]any{
+		"n1": {"id": "n1", "parentId": "n2"},
+		"n2": {"id": "n2", "parentId": "n1"},
+		"n3": {"id": "n3", "parentId": "n2"},
+		"n4": {"id": "n4"},
+	}
+	c := newTestClient(t, srv.handle)
+
+	want := []string{"children.children|Node n3", "children|Node n2", "|Node n1"}
+	specs := map[string][]string{
+		"": want,
+		"children:Node(parentId,id),children.children:Node(parentId,id)": want,
+		"children:Node(parentId,id)":                                     {"children|Node n2", "|Node n1"},
+	}
+	for spec, want := range specs {
+		rows, err := c.Cascade(contract.Cascade(spec)).DryRunDelete(context.Background(), "Node", "n1")
+		if err != nil {
+			t.Fatalf("%q: dry run err: %v", spec, err)
+		}
+		if got := cascadeRowKeys(rows); !reflect.DeepEqual(got, want) {
+			t.Fatalf("%q: unexpected plan %v", spec, got)
+		}
+	}
+}
+
+func TestCascadeDeleteErrorsLeaveDataIntact(t *testing.T) {
+	srv := newUOWServer(t)
+	seedUserRoles(srv)
+	c := newTestClient(t, srv.handle)
+
+	if err := c.Cascade(contract.Cascade("roles:Role")).Delete(context.Background(), "User", "u1"); err == nil || !strings.Contains(err.Error(), "no field mapping") {
+		t.Fatalf("expected missing mapping error, got %v", err)
+	}
+	if err := c.Cascade(contract.Cascade("userRoles:UserRole(userId,id)")).Delete(context.Background(), "User", "missing"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+	if _, err := c.Cascade(contract.Cascade("bad:UserRole(userId")).DryRunDelete(context.Background(), "User", "u1"); err == nil {
+		t.Fatalf("expected parse error")
+	}
+	if len(srv.writes) != 0 {
+		t.Fatalf("failed cascades must not write: %v", srv.writes)
+	}
+}
@@ -2,6 +2,7 @@ package impl
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
@@ -20,11 +21,21 @@ import (
 type Config = contract.Config
 
 type client struct {
-	cfg        resolver.ResolvedConfig
-	httpClient *httpclient.Client
-	aiClient   *httpclient.Client
-	now        func() time.Time
-	sleep      func(time.Duration)
+	cfg          resolver.ResolvedConfig
+	httpClient   *httpclient.Client
+	aiClient     *httpclient.Client
+	now          func() time.Time
+	sleep        func(time.Duration)
+	patchWorkers int
+	tables       sync.Map
+	cache        *queryCache
+	flights      *flightGroup
+	stopWatchers context.CancelFunc
+	watchers     sync.WaitGroup
+
+	versionFields     map[string]string
+	schemaVersioning  bool
+	versionFieldCache sync.Map
 }
 
 var (
@@ -149,12 +160,25 @@ func Init(ctx context.Context, cfg Config) (contract.Client, error) {
 		nowFn = cfg.Clock
 	}
 
-	c := &client{cfg: resolved, httpClient: hc, aiClient: ai, now: nowFn}
+	c := &client{
+		cfg:              resolved,
+		httpClient:       hc,
+		aiClient:         ai,
+		now:              nowFn,
+		patchWorkers:     cfg.PatchConcurrency,
+		versionFields:    cfg.VersionFields,
+		schemaVersioning: cfg.OptimisticLocking,
+		cache:            newQueryCache(cfg.QueryCache, nowFn),
+		flights:          newFlightGroup(cfg.CoalesceReads),
+	}
 	if cfg.Sleep != nil {
 		c.sleep = cfg.Sleep
 	} else {
 		c.sleep = time.Sleep
 	}
+	if cfg.QueryCache != nil {
+		c.startWatchers(ctx, cfg.QueryCache.WatchTables)
+	}
 
 	return c, nil
 }
@@ -183,8 +207,22 @@ func (c *client) Cascade(spec contract.CascadeSpec) contract.CascadeClient {
 }
 
 func (c *client) Save(ctx context.Context, table string, entity any, relationships []string) (map[string]any, error) {
+	field, versioned, err := c.versionField(ctx, table)
+	if err != nil {
+		return nil, err
+	}
+	if versioned {
+		return c.saveVersioned(ctx, table, field, entity, relationships)
+	}
+	return c.putEntity(ctx, table, entity, relationships)
+}
+
+func (c *client) putEntity(ctx context.Context, table string, entity any, relationships []string) (map[string]any, error) {
 	path := c.tablePath(table)
-	if len(relationships) > 0 {
+	if len(relationships) == 0 {
+		defer c.invalidate(table)
+	} else {
+		defer c.invalidate(relationshipTables(table, relationships)...)
 		params := url.Values{}
 		params.Set("relationships", strings.Join(relationships, ","))
 		path += "?" + params.Encode()
@@ -203,6 +241,7 @@ func (c *client) Delete(ctx context.Context, table, id string) error {
 		params.Set("partition", strings.TrimSpace(c.cfg.Partition))
 		path += "?" + params.Encode()
 	}
+	defer c.invalidate(table)
 	return c.httpClient.DoJSON(ctx, http.MethodDelete, path, nil, nil)
 }
 
@@ -210,6 +249,11 @@ func (c *client) BatchSave(ctx context.Context, table string, entities []any, ba
 	return batchSave(ctx, c, table, entities, batchSize)
 }
 
+// Stats reports activity of the client's optional request features.
+func (c *client) Stats() contract.ClientStats {
+	return contract.ClientStats{QueryCache: c.cache.snapshot(), Coalescing: c.flights.snapshot()}
+}
+
 func (c *client) Schema(ctx context.Context) (contract.Schema, error) {
 	return c.GetSchema(ctx, nil)
 }
@@ -233,10 +277,25 @@ func (c *client) ValidateSchema(ctx context.Context, schema contract.Schema) err
 }
 
 func (c *client) GetSchemaHistory(ctx context.Context) ([]contract.Schema, error) {
-	var history []contract.Schema
-	path := "/schemas/" + tableEscape(c.cfg.DatabaseID) + "/history"
-	if err := c.httpClient.DoJSON(ctx, http.MethodGet, path, nil, &history); err != nil {
+	revisions, err := c.GetSchemaRevisions(ctx)
+	if err != nil {
 		return nil, err
 	}
+	history := make([]contract.Schema, 0, len(revisions))
+	for _, rev := range revisions {
+		history = append(history, rev.Schema)
+	}
 	return history, nil
 }
+
+func (c *client) GetSchemaRevisions(ctx context.Context) ([]contract.SchemaRevision, error) {
+	var raw json.RawMessage
+	path := "/schemas/" + tableEscape(c.cfg.DatabaseID) + "/history"
+	if err := c.httpClient.DoJSON(ctx, http.MethodGet, path, nil, &raw); err != nil {
+		return nil, err
+	}
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	return contract.ParseSchemaHistoryJSON(raw)
+}
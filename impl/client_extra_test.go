@@ -52,6 +52,20 @@ func TestClientSchemaEndpoints(t *testing.T) {
 	if err != nil || len(history) != 1 {
 		t.Fatalf("GetSchemaHistory err: %v hist: %+v", err, history)
 	}
+	revisions, err := c.GetSchemaRevisions(context.Background())
+	if err != nil || len(revisions) != 1 || revisions[0].Revision != "1" || revisions[0].Schema.Tables[0].Name != "T" {
+		t.Fatalf("GetSchemaRevisions err: %v revisions: %+v", err, revisions)
+	}
+}
+
+func TestGetSchemaRevisionsEmptyBody(t *testing.T) {
+	c := newSchemaTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	revisions, err := c.GetSchemaRevisions(context.Background())
+	if err != nil || len(revisions) != 0 {
+		t.Fatalf("expected empty history, got %+v, err %v", revisions, err)
+	}
 }
 
 func TestClientFromConstructsQuery(t *testing.T) {
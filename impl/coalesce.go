@@ -0,0 +1,119 @@
+package impl
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+// flightGroup runs one request per key at a time and hands its decoded result to every caller
+// that asked for the same key meanwhile. A nil *flightGroup disables coalescing.
+//
+// The request runs on a context detached from any single caller, so the caller that started it
+// can give up without failing the others. It is cancelled once every caller has given up.
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[flightKey]*flight
+	stats   contract.CoalescingStats
+}
+
+// flightKey pairs a request key with the type its result decodes into. Callers decoding into
+// different types never share a flight, so a result is only ever assigned to its own type.
+type flightKey struct {
+	typ reflect.Type
+	key string
+}
+
+type flight struct {
+	done    chan struct{}
+	result  reflect.Value
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+func newFlightGroup(enabled bool) *flightGroup {
+	if !enabled {
+		return nil
+	}
+	return &flightGroup{flights: map[flightKey]*flight{}}
+}
+
+// do decodes the result of fetch for key into dest, a non-nil pointer. Callers sharing a key
+// receive the same decoded value, so results must be treated as read-only.
+func (g *flightGroup) do(ctx context.Context, key string, dest any, fetch func(ctx context.Context, dest any) error) error {
+	fk := flightKey{typ: reflect.TypeOf(dest).Elem(), key: key}
+	g.mu.Lock()
+	f, joined := g.flights[fk]
+	if joined {
+		f.waiters++
+		g.stats.Coalesced++
+	} else {
+		callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		f = &flight{done: make(chan struct{}), waiters: 1, cancel: cancel}
+		g.flights[fk] = f
+		g.stats.Requests++
+		go g.run(callCtx, fk, f, fetch)
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-f.done:
+		if f.err != nil {
+			return f.err
+		}
+		reflect.ValueOf(dest).Elem().Set(f.result)
+		return nil
+	case <-ctx.Done():
+		g.mu.Lock()
+		if f.waiters--; f.waiters == 0 {
+			f.cancel()
+			g.forget(fk, f)
+		}
+		g.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+func (g *flightGroup) run(ctx context.Context, key flightKey, f *flight, fetch func(ctx context.Context, dest any) error) {
+	out := reflect.New(key.typ)
+	err := fetch(ctx, out.Interface())
+	g.mu.Lock()
+	g.forget(key, f)
+	g.mu.Unlock()
+	f.result, f.err = out.Elem(), err
+	f.cancel()
+	close(f.done)
+}
+
+// forget removes f from the in-flight set unless a newer flight already replaced it. The
+// caller holds g.mu.
+func (g *flightGroup) forget(key flightKey, f *flight) {
+	if g.flights[key] == f {
+		delete(g.flights, key)
+	}
+}
+
+// detach stops new callers from joining the flights in progress; their current callers still
+// receive the results.
+func (g *flightGroup) detach() {
+	if g == nil {
+		return
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.flights = map[flightKey]*flight{}
+}
+
+func (g *flightGroup) snapshot() contract.CoalescingStats {
+	if g == nil {
+		return contract.CoalescingStats{}
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	stats := g.stats
+	stats.InFlight = len(g.flights)
+	return stats
+}
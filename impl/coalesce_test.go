@@ -0,0 +1,310 @@
+package impl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+// blockingServer holds query requests until release is closed, counting those that arrive.
+type blockingServer struct {
+	mu       sync.Mutex
+	queries  int
+	release  chan struct{}
+	canceled chan struct{}
+	status   int
+}
+
+func newCoalescingClient(t *testing.T) (*client, *blockingServer) {
+	t.Helper()
+	s := &blockingServer{release: make(chan struct{}), canceled: make(chan struct{}, 8), status: http.StatusOK}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		// The server only notices a client hanging up once the body has been read.
+		_, _ = io.Copy(io.Discard, r.Body)
+		if r.Method == http.MethodPut && r.URL.Path == "/data/db_test/query/Role" {
+			s.mu.Lock()
+			s.queries++
+			s.mu.Unlock()
+			select {
+			case <-s.release:
+			case <-r.Context().Done():
+				s.canceled <- struct{}{}
+				return
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(s.status)
+		if s.status != http.StatusOK {
+			_, _ = io.WriteString(w, `{"code":"unavailable","message":"try later"}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"records":[{"id":"r1"}]}`)
+	})
+	c.flights = newFlightGroup(true)
+	return c, s
+}
+
+func (s *blockingServer) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.queries
+}
+
+func waitUntil(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(2 * time.Millisecond)
+	}
+}
+
+func TestCoalescingSharesOneRequest(t *testing.T) {
+	c, srv := newCoalescingClient(t)
+	const callers = 5
+	results := make(chan contract.QueryResults, callers)
+	errs := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			rows, err := c.From("Role").Where(contract.Eq("name", "admin")).List(context.Background())
+			results <- rows
+			errs <- err
+		}()
+	}
+	waitUntil(t, "callers to join", func() bool { return c.Stats().Coalescing.Coalesced == callers-1 })
+	if stats := c.Stats().Coalescing; stats.InFlight != 1 || stats.Requests != 1 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+	close(srv.release)
+	for i := 0; i < callers; i++ {
+		if err := <-errs; err != nil {
+			t.Fatalf("list: %v", err)
+		}
+		if rows := <-results; len(rows) != 1 || rows[0]["id"] != "r1" {
+			t.Fatalf("unexpected rows %v", rows)
+		}
+	}
+	if srv.count() != 1 {
+		t.Fatalf("expected one request, got %d", srv.count())
+	}
+
+	// Different payloads and opted-out callers send their own requests.
+	if _, err := c.From("Role").Where(contract.Eq("name", "ops")).List(context.Background()); err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if _, err := c.From("Role").Where(contract.Eq("name", "admin")).List(contract.WithoutCoalescing(context.Background())); err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if stats := c.Stats().Coalescing; srv.count() != 3 || stats.Requests != 2 || stats.InFlight != 0 {
+		t.Fatalf("unexpected requests %d, stats %+v", srv.count(), stats)
+	}
+}
+
+func TestFreshQueryOptsOutOfCoalescing(t *testing.T) {
+	c, srv := newCoalescingClient(t)
+	base := c.From("Role").Where(contract.Eq("name", "admin"))
+	fresh := base.Fresh()
+
+	errs := make(chan error, 4)
+	for _, q := range []contract.Query{base, base, fresh, fresh} {
+		go func(q contract.Query) {
+			_, err := q.List(context.Background())
+			errs <- err
+		}(q)
+	}
+	// The two base reads share a request; each read of the reused fresh query sends its own.
+	waitUntil(t, "requests to arrive", func() bool { return srv.count() == 3 && c.Stats().Coalescing.Coalesced == 1 })
+	close(srv.release)
+	for i := 0; i < 4; i++ {
+		if err := <-errs; err != nil {
+			t.Fatalf("list: %v", err)
+		}
+	}
+	if stats := c.Stats().Coalescing; srv.count() != 3 || stats.Requests != 1 || stats.Coalesced != 1 {
+		t.Fatalf("unexpected requests %d, stats %+v", srv.count(), stats)
+	}
+}
+
+func TestCoalescingKeepsListAndPageApart(t *testing.T) {
+	c, srv := newCoalescingClient(t)
+	ctx := context.Background()
+	// Without a limit List and Page send the same request but decode different shapes.
+	listErr := make(chan error, 1)
+	go func() {
+		rows, err := c.From("Role").List(ctx)
+		if err == nil && (len(rows) != 1 || rows[0]["id"] != "r1") {
+			err = fmt.Errorf("unexpected rows %v", rows)
+		}
+		listErr <- err
+	}()
+	pageErr := make(chan error, 1)
+	go func() {
+		page, err := c.From("Role").Page(ctx, "")
+		if err == nil && len(page.Items) != 1 {
+			err = fmt.Errorf("unexpected page %+v", page)
+		}
+		pageErr <- err
+	}()
+	waitUntil(t, "both requests", func() bool { return srv.count() == 2 })
+	close(srv.release)
+	if err := <-listErr; err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if err := <-pageErr; err != nil {
+		t.Fatalf("page: %v", err)
+	}
+	if stats := c.Stats().Coalescing; stats.Requests != 2 || stats.Coalesced != 0 {
+		t.Fatalf("List and Page must not share a flight: %+v", stats)
+	}
+
+	// The group itself never hands a result to a caller decoding into another type.
+	g := newFlightGroup(true)
+	gate := make(chan struct{})
+	fetches := make(chan struct{}, 2)
+	fetch := func(ctx context.Context, dest any) error {
+		fetches <- struct{}{}
+		<-gate
+		return json.Unmarshal([]byte(`{"records":[]}`), dest)
+	}
+	errs := make(chan error, 2)
+	go func() { errs <- g.do(ctx, "same", new(contract.QueryResults), fetch) }()
+	go func() { errs <- g.do(ctx, "same", new(contract.PageResult), fetch) }()
+	for i := 0; i < 2; i++ {
+		select {
+		case <-fetches:
+		case <-time.After(2 * time.Second):
+			close(gate)
+			t.Fatalf("each result type should fetch on its own")
+		}
+	}
+	close(gate)
+	for i := 0; i < 2; i++ {
+		if err := <-errs; err != nil {
+			t.Fatalf("do: %v", err)
+		}
+	}
+}
+
+func TestCoalescingHonorsEachCallersContext(t *testing.T) {
+	c, srv := newCoalescingClient(t)
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := c.From("Role").List(leaderCtx)
+		leaderErr <- err
+	}()
+	waitUntil(t, "the leader's request", func() bool { return srv.count() == 1 })
+	followerErr := make(chan error, 1)
+	go func() {
+		_, err := c.From("Role").List(context.Background())
+		followerErr <- err
+	}()
+	waitUntil(t, "the follower to join", func() bool { return c.Stats().Coalescing.Coalesced == 1 })
+
+	cancelLeader()
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Fatalf("leader should see its own cancellation, got %v", err)
+	}
+	close(srv.release)
+	if err := <-followerErr; err != nil {
+		t.Fatalf("follower should not inherit the leader's cancellation: %v", err)
+	}
+	if srv.count() != 1 {
+		t.Fatalf("expected one request, got %d", srv.count())
+	}
+}
+
+func TestCoalescingCancelsWhenEveryCallerLeaves(t *testing.T) {
+	c, srv := newCoalescingClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.From("Role").List(ctx); !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("expected deadline error, got %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	select {
+	case <-srv.canceled:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("abandoned request was not cancelled")
+	}
+	if stats := c.Stats().Coalescing; stats.InFlight != 0 {
+		t.Fatalf("abandoned flight should be forgotten: %+v", stats)
+	}
+}
+
+func TestCoalescingSharesErrorsAndStopsAtWrites(t *testing.T) {
+	c, srv := newCoalescingClient(t)
+	srv.status = http.StatusServiceUnavailable
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := c.From("Role").List(context.Background())
+			errs <- err
+		}()
+	}
+	waitUntil(t, "callers to join", func() bool { return c.Stats().Coalescing.Coalesced == 1 })
+	close(srv.release)
+	for i := 0; i < 2; i++ {
+		var cerr *contract.Error
+		if err := <-errs; !errors.As(err, &cerr) || cerr.Code != "unavailable" {
+			t.Fatalf("expected the shared error, got %v", err)
+		}
+	}
+
+	srv.status = http.StatusOK
+	srv.release = make(chan struct{})
+	before := make(chan error, 1)
+	go func() {
+		_, err := c.From("Role").List(context.Background())
+		before <- err
+	}()
+	waitUntil(t, "the read before the write", func() bool { return srv.count() == 2 })
+	if err := c.Delete(context.Background(), "Role", "r1"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	after := make(chan error, 1)
+	go func() {
+		_, err := c.From("Role").List(context.Background())
+		after <- err
+	}()
+	waitUntil(t, "a new request after the write", func() bool { return srv.count() == 3 })
+	close(srv.release)
+	if err := <-before; err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if err := <-after; err != nil {
+		t.Fatalf("list: %v", err)
+	}
+}
+
+func TestCoalescingDisabledByDefault(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `[]`)
+	})
+	if c.flights != nil || newFlightGroup(false) != nil {
+		t.Fatalf("coalescing should be opt-in")
+	}
+	if _, err := c.From("Role").List(context.Background()); err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if stats := c.Stats().Coalescing; stats != (contract.CoalescingStats{}) {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
@@ -412,7 +412,7 @@ func TestSchemaFromEntitiesAndTablesSkipInvalid(t *testing.T) {
 			"resolvers":  []any{123},
 		},
 	}
-	schema := schemaFromEntities(entities)
+	schema := parseRawSchema(t, "entities", entities)
 	if len(schema.Tables) != 1 || len(schema.Tables[0].Fields) != 0 || len(schema.Tables[0].Resolvers) != 0 {
 		t.Fatalf("expected invalid entries skipped, got %+v", schema.Tables)
 	}
@@ -425,7 +425,7 @@ func TestSchemaFromEntitiesAndTablesSkipInvalid(t *testing.T) {
 			"resolvers": []any{123},
 		},
 	}
-	schema2 := schemaFromTablesArray(tables)
+	schema2 := parseRawSchema(t, "tables", tables)
 	if len(schema2.Tables) != 1 || len(schema2.Tables[0].Fields) != 0 || len(schema2.Tables[0].Resolvers) != 0 {
 		t.Fatalf("expected invalid table entries skipped, got %+v", schema2.Tables)
 	}
@@ -91,10 +91,16 @@ func TestCascadeOperations(t *testing.T) {
 	cascadeSpec := contract.Cascade("graph:type(field)")
 
 	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
-		if r.Method == http.MethodPut {
+		switch {
+		case r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"tables":[{"name":"users","fields":[{"name":"id","primaryKey":true}]},{"name":"type","fields":[{"name":"id","primaryKey":true},{"name":"field"}]}]}`))
+		case r.URL.Path == "/data/db_test/query/users":
+			_, _ = w.Write([]byte(`[{"id":"id"}]`))
+		case r.URL.Path == "/data/db_test/query/type":
+			_, _ = w.Write([]byte(`[]`))
+		case r.Method == http.MethodPut:
 			saved = true
-		}
-		if r.Method == http.MethodDelete {
+		case r.Method == http.MethodDelete:
 			deleted = true
 		}
 	})
@@ -0,0 +1,95 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+const defaultPatchWorkers = 8
+
+func (c *client) Patch(ctx context.Context, table, id string, updates map[string]any) (contract.PatchResult, error) {
+	pk, err := c.primaryKeyField(ctx, table)
+	if err != nil {
+		return contract.PatchResult{ID: id, Status: contract.PatchFailed, Err: err}, err
+	}
+	res := c.patchOne(ctx, table, pk, contract.PatchOp{ID: id, Updates: updates})
+	return res, res.Err
+}
+
+func (c *client) PatchMany(ctx context.Context, table string, ops []contract.PatchOp) ([]contract.PatchResult, error) {
+	if len(ops) == 0 {
+		return nil, nil
+	}
+	pk, err := c.primaryKeyField(ctx, table)
+	if err != nil {
+		return nil, err
+	}
+
+	workers := c.patchWorkers
+	if workers <= 0 {
+		workers = defaultPatchWorkers
+	}
+	if workers > len(ops) {
+		workers = len(ops)
+	}
+
+	results := make([]contract.PatchResult, len(ops))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = c.patchOne(ctx, table, pk, ops[i])
+			}
+		}()
+	}
+	for i := range ops {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results, ctx.Err()
+}
+
+// patchOne issues a conditional update keyed on the primary key; zero rows updated means the record is missing.
+// The id is converted to the key's schema type first so numeric keys match, and versioned tables
+// have their version advanced.
+func (c *client) patchOne(ctx context.Context, table string, pk contract.Field, op contract.PatchOp) contract.PatchResult {
+	res := contract.PatchResult{ID: op.ID}
+	if err := ctx.Err(); err != nil {
+		res.Status, res.Err = contract.PatchFailed, err
+		return res
+	}
+	if strings.TrimSpace(op.ID) == "" {
+		res.Status, res.Err = contract.PatchFailed, fmt.Errorf("patch id is required")
+		return res
+	}
+	if len(op.Updates) == 0 {
+		res.Status, res.Err = contract.PatchFailed, fmt.Errorf("patch updates are required")
+		return res
+	}
+
+	id, err := keyValue(pk, op.ID)
+	if err != nil {
+		res.Status, res.Err = contract.PatchFailed, err
+		return res
+	}
+
+	updated, err := c.updateByKey(ctx, table, pk.Name, id, op.Updates)
+	switch {
+	case err != nil:
+		res.Status, res.Err = contract.PatchFailed, err
+	case updated == 0:
+		res.Status = contract.PatchMissing
+	default:
+		res.Status = contract.PatchUpdated
+	}
+	return res
+}
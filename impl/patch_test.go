@@ -0,0 +1,171 @@
+package impl
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+const userSchemaResponse = `{"tables":[{"name":"User","fields":[{"name":"userId","type":"String","primaryKey":true},{"name":"email","type":"String"}]}]}`
+
+func TestPatchResolvesPrimaryKey(t *testing.T) {
+	schemaCalls := 0
+	var payload map[string]any
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/database/db_test/schema":
+			schemaCalls++
+			_, _ = w.Write([]byte(userSchemaResponse))
+		case r.URL.Path == "/data/db_test/query/update/User":
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			_, _ = w.Write([]byte(`1`))
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	for i := 0; i < 2; i++ {
+		res, err := c.Patch(context.Background(), "User", "u1", map[string]any{"email": "new@example.com"})
+		if err != nil {
+			t.Fatalf("patch err: %v", err)
+		}
+		if res.Status != contract.PatchUpdated || res.ID != "u1" {
+			t.Fatalf("unexpected result: %+v", res)
+		}
+	}
+	if schemaCalls != 1 {
+		t.Fatalf("expected primary key to be cached, got %d schema calls", schemaCalls)
+	}
+
+	crit := payload["conditions"].(map[string]any)["criteria"].(map[string]any)
+	if crit["field"] != "userId" || crit["value"] != "u1" {
+		t.Fatalf("unexpected criteria: %+v", crit)
+	}
+	if payload["updates"].(map[string]any)["email"] != "new@example.com" {
+		t.Fatalf("unexpected updates: %+v", payload["updates"])
+	}
+}
+
+func TestPatchManyReportsPerRecordOutcomes(t *testing.T) {
+	var inFlight, peak int32
+	var mu sync.Mutex
+	seen := map[string]bool{}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(userSchemaResponse))
+			return
+		}
+		cur := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if cur <= p || atomic.CompareAndSwapInt32(&peak, p, cur) {
+				break
+			}
+		}
+
+		var payload map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		id := payload["conditions"].(map[string]any)["criteria"].(map[string]any)["value"].(string)
+		mu.Lock()
+		seen[id] = true
+		mu.Unlock()
+		switch {
+		case strings.HasPrefix(id, "missing"):
+			_, _ = w.Write([]byte(`0`))
+		case strings.HasPrefix(id, "bad"):
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"code":"invalid","message":"bad update"}`))
+		default:
+			_, _ = w.Write([]byte(`1`))
+		}
+	})
+	c.patchWorkers = 2
+
+	ops := []contract.PatchOp{
+		{ID: "u1", Updates: map[string]any{"email": "a"}},
+		{ID: "missing1", Updates: map[string]any{"email": "b"}},
+		{ID: "bad1", Updates: map[string]any{"email": "c"}},
+		{ID: "u2", Updates: map[string]any{"email": "d"}},
+		{ID: "", Updates: map[string]any{"email": "e"}},
+		{ID: "u3"},
+	}
+	results, err := c.PatchMany(context.Background(), "User", ops)
+	if err != nil {
+		t.Fatalf("patch many err: %v", err)
+	}
+
+	want := []contract.PatchStatus{
+		contract.PatchUpdated,
+		contract.PatchMissing,
+		contract.PatchFailed,
+		contract.PatchUpdated,
+		contract.PatchFailed,
+		contract.PatchFailed,
+	}
+	for i, res := range results {
+		if res.ID != ops[i].ID || res.Status != want[i] {
+			t.Fatalf("result %d: got %+v want %s", i, res, want[i])
+		}
+		if (res.Status == contract.PatchFailed) != (res.Err != nil) {
+			t.Fatalf("result %d: error mismatch %+v", i, res)
+		}
+	}
+	if peak > 2 {
+		t.Fatalf("expected at most 2 concurrent patches, saw %d", peak)
+	}
+	if seen["u3"] || seen[""] {
+		t.Fatalf("invalid ops should not be sent: %+v", seen)
+	}
+}
+
+func TestPatchManyPrimaryKeyErrors(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"tables":[{"name":"User","fields":[{"name":"email","type":"String"}]}]}`))
+	})
+
+	if _, err := c.PatchMany(context.Background(), "User", []contract.PatchOp{{ID: "u1", Updates: map[string]any{"a": 1}}}); err == nil || !strings.Contains(err.Error(), "no primary key") {
+		t.Fatalf("expected missing primary key error, got %v", err)
+	}
+	if _, err := c.Patch(context.Background(), "Role", "r1", map[string]any{"a": 1}); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected missing table error, got %v", err)
+	}
+	if res, err := c.PatchMany(context.Background(), "User", nil); err != nil || res != nil {
+		t.Fatalf("expected no-op for empty ops, got %v %v", res, err)
+	}
+}
+
+func TestPatchConvertsNumericPrimaryKey(t *testing.T) {
+	var value any
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"tables":[{"name":"Order","fields":[{"name":"orderId","type":"Long","primaryKey":true},{"name":"status","type":"String"}]}]}`))
+			return
+		}
+		var payload map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		value = payload["conditions"].(map[string]any)["criteria"].(map[string]any)["value"]
+		_, _ = w.Write([]byte(`1`))
+	})
+
+	res, err := c.Patch(context.Background(), "Order", "42", map[string]any{"status": "shipped"})
+	if err != nil || res.Status != contract.PatchUpdated {
+		t.Fatalf("unexpected result: %+v %v", res, err)
+	}
+	if value != float64(42) {
+		t.Fatalf("expected numeric key in criteria, got %#v", value)
+	}
+
+	res, _ = c.Patch(context.Background(), "Order", "abc", map[string]any{"status": "shipped"})
+	if res.Status != contract.PatchFailed || res.Err == nil || !strings.Contains(res.Err.Error(), "Long key") {
+		t.Fatalf("expected conversion failure, got %+v", res)
+	}
+}
@@ -0,0 +1,70 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+// primaryKey resolves the primary-key field for a table from the schema, caching the result per client.
+func (c *client) primaryKey(ctx context.Context, table string) (string, error) {
+	f, err := c.primaryKeyField(ctx, table)
+	if err != nil {
+		return "", err
+	}
+	return f.Name, nil
+}
+
+// primaryKeyField resolves the full primary-key field definition for a table, including its type.
+func (c *client) primaryKeyField(ctx context.Context, table string) (contract.Field, error) {
+	t, err := c.tableDef(ctx, table)
+	if err != nil {
+		return contract.Field{}, err
+	}
+	pk, ok := t.Field(primaryField(t))
+	if !ok {
+		return contract.Field{}, fmt.Errorf("table %s has no primary key", table)
+	}
+	return pk, nil
+}
+
+// tableDef resolves a table's definition from the schema, caching the result per client.
+func (c *client) tableDef(ctx context.Context, table string) (contract.Table, error) {
+	if cached, ok := c.tables.Load(table); ok {
+		return cached.(contract.Table), nil
+	}
+
+	schema, err := c.GetSchema(ctx, []string{table})
+	if err != nil {
+		return contract.Table{}, err
+	}
+	t, ok := schema.Table(table)
+	if !ok {
+		return contract.Table{}, fmt.Errorf("table %s not found in schema", table)
+	}
+	c.tables.Store(table, t)
+	return t, nil
+}
+
+// keyValue converts a primary key given as a string into the Go value matching the field's schema
+// type, so conditions on numeric keys compare numbers rather than strings.
+func keyValue(pk contract.Field, id string) (any, error) {
+	switch pk.Type {
+	case "Byte", "Short", "Int", "Long":
+		n, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s is a %s key: %w", pk.Name, pk.Type, err)
+		}
+		return n, nil
+	case "Float", "Double":
+		n, err := strconv.ParseFloat(id, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s is a %s key: %w", pk.Name, pk.Type, err)
+		}
+		return n, nil
+	default:
+		return id, nil
+	}
+}
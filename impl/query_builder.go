@@ -22,6 +22,7 @@ type query struct {
 	limit         *int
 	updates       map[string]any
 	partition     *string
+	bypassCache   bool
 }
 
 func newQuery(client *client, table string) contract.Query {
@@ -112,6 +113,12 @@ func (q *query) SetUpdates(updates map[string]any) contract.Query {
 	return nq
 }
 
+func (q *query) Fresh() contract.Query {
+	nq := q.clone()
+	nq.bypassCache = true
+	return nq
+}
+
 func (q *query) InPartition(partition string) contract.Query {
 	nq := q.clone()
 	trimmed := strings.TrimSpace(partition)
@@ -126,3 +133,12 @@ func (q *query) InPartition(partition string) contract.Query {
 func (q *query) MarshalJSON() ([]byte, error) {
 	return buildQueryPayload(q, true).MarshalJSON()
 }
+
+// Filter evaluates the query's conditions against records without contacting the server.
+func (q *query) Filter(records []map[string]any) (contract.QueryResults, error) {
+	var cond contract.Condition
+	if raw := buildConditions(q.clauses); raw != nil {
+		cond = raw
+	}
+	return contract.FilterRecords(cond, records)
+}
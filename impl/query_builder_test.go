@@ -107,3 +107,35 @@ func TestPartitionDefaultsAndOverrides(t *testing.T) {
 		t.Fatalf("expected clearing partition when empty")
 	}
 }
+
+func TestQueryFilterAppliesClausesLocally(t *testing.T) {
+	records := []map[string]any{
+		{"id": "u1", "tenant": "a", "age": 20},
+		{"id": "u2", "tenant": "b", "age": 40},
+		{"id": "u3", "tenant": "a", "age": 35, "profile": map[string]any{"city": "Oslo"}},
+	}
+	q := newQuery(nil, "users").
+		Where(contract.Eq("tenant", "a")).
+		And(contract.Gte("age", 30)).
+		Or(contract.Eq("id", "u2")).
+		OrderBy(contract.Desc("age")).
+		Limit(1)
+	got, err := q.Filter(records)
+	if err != nil {
+		t.Fatalf("filter: %v", err)
+	}
+	if want := (contract.QueryResults{records[1], records[2]}); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected records %v", got)
+	}
+
+	got, err = newQuery(nil, "users").Where(contract.Eq("profile.city", "Oslo")).Filter(records)
+	if err != nil || len(got) != 1 || got[0]["id"] != "u3" {
+		t.Fatalf("dotted path filter: %v %v", got, err)
+	}
+	if all, err := newQuery(nil, "users").Filter(records); err != nil || len(all) != 3 {
+		t.Fatalf("unfiltered query should keep every record: %v %v", all, err)
+	}
+	if _, err := newQuery(nil, "users").Where(contract.Within("id", newQuery(nil, "roles"))).Filter(records); err == nil {
+		t.Fatalf("expected nested query error")
+	}
+}
@@ -0,0 +1,338 @@
+package impl
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+const (
+	defaultQueryCacheEntries = 1000
+	watchRetryMin            = time.Second
+	watchRetryMax            = 30 * time.Second
+)
+
+// queryCache holds List and Page responses keyed by result type, request path and canonical
+// payload. A nil *queryCache is a disabled cache, so callers never need to check.
+//
+// Every invalidation bumps gen. A read records gen before going to the network and only
+// stores its response if gen is unchanged, so a write that lands mid-read cannot leave the
+// pre-write result behind.
+type queryCache struct {
+	ttl       time.Duration
+	tableTTLs map[string]time.Duration
+	max       int
+	now       func() time.Time
+
+	mu      sync.Mutex
+	lru     *list.List // of *cacheEntry, most recently used first
+	entries map[string]*list.Element
+	gen     uint64
+	stats   contract.QueryCacheStats
+}
+
+type cacheEntry struct {
+	key string
+	// tables lists the tables the result depends on; nil means it may depend on any table.
+	tables  []string
+	expires time.Time
+	data    []byte
+}
+
+func newQueryCache(cfg *contract.QueryCacheConfig, now func() time.Time) *queryCache {
+	if cfg == nil {
+		return nil
+	}
+	max := cfg.MaxEntries
+	if max <= 0 {
+		max = defaultQueryCacheEntries
+	}
+	return &queryCache{
+		ttl:       cfg.TTL,
+		tableTTLs: cfg.TableTTLs,
+		max:       max,
+		now:       now,
+		lru:       list.New(),
+		entries:   map[string]*list.Element{},
+	}
+}
+
+func (qc *queryCache) ttlFor(table string) time.Duration {
+	if ttl, ok := qc.tableTTLs[table]; ok {
+		return ttl
+	}
+	return qc.ttl
+}
+
+// enabled reports whether results for table are cached at all.
+func (qc *queryCache) enabled(table string) bool {
+	return qc != nil && qc.ttlFor(table) > 0
+}
+
+// lookup decodes a live entry for key into dest. On a miss it returns the generation the
+// caller must hand back to store.
+func (qc *queryCache) lookup(key string, dest any) (bool, uint64) {
+	qc.mu.Lock()
+	defer qc.mu.Unlock()
+	if el, ok := qc.entries[key]; ok {
+		entry := el.Value.(*cacheEntry)
+		if qc.now().Before(entry.expires) && json.Unmarshal(entry.data, dest) == nil {
+			qc.lru.MoveToFront(el)
+			qc.stats.Hits++
+			return true, qc.gen
+		}
+		qc.remove(el)
+		qc.stats.Evictions++
+	}
+	qc.stats.Misses++
+	return false, qc.gen
+}
+
+// store caches value for the TTL of table unless the cache was invalidated since gen.
+func (qc *queryCache) store(key, table string, tables []string, gen uint64, value any) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+	qc.mu.Lock()
+	defer qc.mu.Unlock()
+	if gen != qc.gen {
+		return
+	}
+	if el, ok := qc.entries[key]; ok {
+		qc.remove(el)
+	}
+	entry := &cacheEntry{key: key, tables: tables, expires: qc.now().Add(qc.ttlFor(table)), data: data}
+	qc.entries[key] = qc.lru.PushFront(entry)
+	for qc.lru.Len() > qc.max {
+		qc.remove(qc.lru.Back())
+		qc.stats.Evictions++
+	}
+}
+
+// invalidate drops the entries that depend on any of tables, or every entry when tables is
+// empty.
+func (qc *queryCache) invalidate(tables ...string) {
+	if qc == nil {
+		return
+	}
+	qc.mu.Lock()
+	defer qc.mu.Unlock()
+	qc.gen++
+	for el := qc.lru.Front(); el != nil; {
+		next := el.Next()
+		if len(tables) == 0 || dependsOn(el.Value.(*cacheEntry).tables, tables) {
+			qc.remove(el)
+			qc.stats.Invalidations++
+		}
+		el = next
+	}
+}
+
+func dependsOn(deps, changed []string) bool {
+	if deps == nil {
+		return true
+	}
+	for _, d := range deps {
+		for _, t := range changed {
+			if d == t {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (qc *queryCache) remove(el *list.Element) {
+	qc.lru.Remove(el)
+	delete(qc.entries, el.Value.(*cacheEntry).key)
+}
+
+func (qc *queryCache) snapshot() contract.QueryCacheStats {
+	if qc == nil {
+		return contract.QueryCacheStats{}
+	}
+	qc.mu.Lock()
+	defer qc.mu.Unlock()
+	stats := qc.stats
+	stats.Entries = qc.lru.Len()
+	return stats
+}
+
+// cacheTables lists the tables a query's result depends on: its own table plus those of any
+// nested Within queries. Resolvers and cross-table searches can read anything, so they
+// return nil.
+func (q *query) cacheTables(payload queryPayload) []string {
+	if q.table == "ALL" || len(q.resolveFields) > 0 {
+		return nil
+	}
+	tables := []string{q.table}
+	if len(payload.Conditions) > 0 {
+		var conds any
+		if err := json.Unmarshal(payload.Conditions, &conds); err != nil {
+			return nil
+		}
+		tables = append(tables, nestedTables(conds)...)
+	}
+	return tables
+}
+
+func nestedTables(v any) []string {
+	var out []string
+	switch t := v.(type) {
+	case map[string]any:
+		if t["type"] == "SelectQuery" {
+			if table, ok := t["table"].(string); ok {
+				out = append(out, table)
+			}
+		}
+		for _, child := range t {
+			out = append(out, nestedTables(child)...)
+		}
+	case []any:
+		for _, child := range t {
+			out = append(out, nestedTables(child)...)
+		}
+	}
+	return out
+}
+
+// read issues a List or Page request. It is answered from the query cache when possible, and
+// otherwise joins an identical request already in flight when coalescing is enabled.
+func (q *query) read(ctx context.Context, path string, payload queryPayload, dest any) error {
+	cache := q.client.cache
+	useCache := !q.bypassCache && cache.enabled(q.table)
+	coalesce := !q.bypassCache && q.client.flights != nil && !contract.CoalescingDisabled(ctx)
+	fetch := func(ctx context.Context, dest any) error {
+		return q.client.httpClient.DoJSON(ctx, http.MethodPut, path, payload, dest)
+	}
+	if !useCache && !coalesce {
+		return fetch(ctx, dest)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	// List and Page of an unlimited query send the same request but decode different shapes,
+	// so the result type is part of the key.
+	key := fmt.Sprintf("%T %s %s %s", dest, http.MethodPut, path, body)
+	var gen uint64
+	if useCache {
+		var hit bool
+		if hit, gen = cache.lookup(key, dest); hit {
+			return nil
+		}
+	}
+	if coalesce {
+		err = q.client.flights.do(ctx, key, dest, fetch)
+	} else {
+		err = fetch(ctx, dest)
+	}
+	if err != nil {
+		return err
+	}
+	if useCache {
+		cache.store(key, q.table, q.cacheTables(payload), gen, dest)
+	}
+	return nil
+}
+
+// freshQuery starts a query that skips the query cache and read coalescing, for reads that
+// must see the latest data.
+func (c *client) freshQuery(table string) contract.Query {
+	return newQuery(c, table).Fresh()
+}
+
+// invalidate records that tables changed, or that anything may have when none are given. Cached
+// results for them are dropped and reads already in flight stop accepting new callers, so
+// every read issued afterwards observes the change.
+func (c *client) invalidate(tables ...string) {
+	c.cache.invalidate(tables...)
+	c.flights.detach()
+}
+
+// relationshipTables returns the tables a cascade save may write besides its own. A spec that
+// does not parse yields nil, which callers treat as touching every table.
+func relationshipTables(table string, relationships []string) []string {
+	graphs, err := contract.ParseCascadeSpec(strings.Join(relationships, ","))
+	if err != nil {
+		return nil
+	}
+	tables := []string{table}
+	var walk func([]contract.CascadeGraph)
+	walk = func(gs []contract.CascadeGraph) {
+		for _, g := range gs {
+			tables = append(tables, g.Type)
+			walk(g.Children)
+		}
+	}
+	walk(graphs)
+	return tables
+}
+
+// startWatchers runs watchTable for each table until ctx is done or the client is closed.
+func (c *client) startWatchers(ctx context.Context, tables []string) {
+	if len(tables) == 0 {
+		return
+	}
+	ctx, c.stopWatchers = context.WithCancel(ctx)
+	for _, table := range tables {
+		c.watchers.Add(1)
+		go func(table string) {
+			defer c.watchers.Done()
+			c.watchTable(ctx, table)
+		}(table)
+	}
+}
+
+// Close stops the client's table watchers and waits for their streams to shut down. It is safe to
+// call more than once; a client without watchers has nothing to stop.
+func (c *client) Close() error {
+	if c.stopWatchers != nil {
+		c.stopWatchers()
+	}
+	c.watchers.Wait()
+	return nil
+}
+
+// watchTable drops table's cache entries whenever a keep-alive stream reports a change,
+// reconnecting with backoff until ctx is done. Entries are also dropped on every connect
+// because changes made while disconnected were missed.
+func (c *client) watchTable(ctx context.Context, table string) {
+	payload := buildQueryPayload(newQuery(c, table).(*query), true)
+	params := url.Values{}
+	params.Set("includeQueryResults", "false")
+	params.Set("keepAlive", "true")
+	path := "/data/" + url.PathEscape(c.cfg.DatabaseID) + "/query/stream/" + url.PathEscape(table) + "?" + params.Encode()
+
+	delay := watchRetryMin
+	for {
+		resp, err := c.httpClient.DoStream(ctx, http.MethodPut, path, payload)
+		if err == nil {
+			c.invalidate(table)
+			it := newStreamIterator(resp)
+			for it.Next() {
+				c.invalidate(table)
+			}
+			it.Close()
+			delay = watchRetryMin
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		if delay *= 2; delay > watchRetryMax {
+			delay = watchRetryMax
+		}
+	}
+}
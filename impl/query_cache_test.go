@@ -0,0 +1,347 @@
+package impl
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+	"github.com/OnyxDevTools/onyx-database-go/impl/resolver"
+	"github.com/OnyxDevTools/onyx-database-go/internal/httpclient"
+)
+
+// cacheTestClient serves every request with body and counts them per method and path.
+func cacheTestClient(t *testing.T, cfg contract.QueryCacheConfig, body string) (*client, *time.Time, func(string) int) {
+	t.Helper()
+	var mu sync.Mutex
+	calls := map[string]int{}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls[r.Method+" "+r.URL.Path]++
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, body)
+	})
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return now }
+	c.cache = newQueryCache(&cfg, func() time.Time { return now })
+	return c, &now, func(key string) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return calls[key]
+	}
+}
+
+func TestQueryCacheHitsMissesAndTTL(t *testing.T) {
+	c, now, calls := cacheTestClient(t, contract.QueryCacheConfig{
+		TTL:       time.Minute,
+		TableTTLs: map[string]time.Duration{"Role": time.Hour, "Audit": 0},
+	}, `{"records":[{"id":"1"}]}`)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		rows, err := c.From("Role").Where(contract.Eq("name", "admin")).List(ctx)
+		if err != nil || len(rows) != 1 {
+			t.Fatalf("list: %v %v", rows, err)
+		}
+		rows[0]["id"] = "mutated"
+	}
+	if n := calls("PUT /data/db_test/query/Role"); n != 1 {
+		t.Fatalf("expected one request, got %d", n)
+	}
+	rows, _ := c.From("Role").Where(contract.Eq("name", "admin")).List(ctx)
+	if rows[0]["id"] != "1" {
+		t.Fatalf("cached rows must not share maps with callers: %v", rows)
+	}
+	if _, err := c.From("Role").Where(contract.Eq("name", "ops")).List(ctx); err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if _, err := c.From("Role").Limit(5).Page(ctx, ""); err != nil {
+		t.Fatalf("page: %v", err)
+	}
+	if _, err := c.From("Role").Limit(5).Page(ctx, ""); err != nil {
+		t.Fatalf("page: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		_, _ = c.From("Audit").List(ctx)
+	}
+	if n := calls("PUT /data/db_test/query/Audit"); n != 2 {
+		t.Fatalf("a zero table TTL should disable caching, got %d requests", n)
+	}
+
+	_, _ = c.From("User").List(ctx)
+	*now = now.Add(2 * time.Minute)
+	_, _ = c.From("User").List(ctx)
+	_, _ = c.From("Role").Where(contract.Eq("name", "admin")).List(ctx)
+	if n := calls("PUT /data/db_test/query/User"); n != 2 {
+		t.Fatalf("expired entry should be refetched, got %d requests", n)
+	}
+
+	want := contract.QueryCacheStats{Hits: 5, Misses: 5, Evictions: 1, Entries: 4}
+	if got := c.Stats().QueryCache; got != want {
+		t.Fatalf("stats = %+v, want %+v", got, want)
+	}
+}
+
+func TestQueryCacheSeparatesListAndPage(t *testing.T) {
+	c, _, calls := cacheTestClient(t, contract.QueryCacheConfig{TTL: time.Minute}, `{"records":[{"id":"1"}]}`)
+	ctx := context.Background()
+
+	// Without a limit both send the same request, but each must get its own entry.
+	for i := 0; i < 2; i++ {
+		page, err := c.From("Role").Page(ctx, "")
+		if err != nil || len(page.Items) != 1 {
+			t.Fatalf("page: %+v %v", page, err)
+		}
+		rows, err := c.From("Role").List(ctx)
+		if err != nil || len(rows) != 1 || rows[0]["id"] != "1" {
+			t.Fatalf("list after page: %v %v", rows, err)
+		}
+	}
+	if n := calls("PUT /data/db_test/query/Role"); n != 2 {
+		t.Fatalf("expected one request per operation, got %d", n)
+	}
+	if stats := c.Stats().QueryCache; stats.Hits != 2 || stats.Misses != 2 || stats.Entries != 2 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestQueryCacheLRUBound(t *testing.T) {
+	c, _, calls := cacheTestClient(t, contract.QueryCacheConfig{TTL: time.Minute, MaxEntries: 2}, `[]`)
+	ctx := context.Background()
+	list := func(name string) {
+		if _, err := c.From("Role").Where(contract.Eq("name", name)).List(ctx); err != nil {
+			t.Fatalf("list: %v", err)
+		}
+	}
+	list("a")
+	list("b")
+	list("a") // a is now the most recently used
+	list("c") // evicts b
+	list("a")
+	list("b")
+	if n := calls("PUT /data/db_test/query/Role"); n != 4 {
+		t.Fatalf("expected 4 requests, got %d", n)
+	}
+	if stats := c.Stats().QueryCache; stats.Entries != 2 || stats.Evictions != 2 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestQueryCacheInvalidatesOnWrites(t *testing.T) {
+	c, _, calls := cacheTestClient(t, contract.QueryCacheConfig{TTL: time.Minute}, `[]`)
+	ctx := context.Background()
+	warm := func() {
+		t.Helper()
+		for _, table := range []string{"User", "Role", "Permission"} {
+			if _, err := c.From(table).List(ctx); err != nil {
+				t.Fatalf("list: %v", err)
+			}
+		}
+	}
+	expect := func(step string, want map[string]int) {
+		t.Helper()
+		for table, n := range want {
+			if got := calls("PUT /data/db_test/query/" + table); got != n {
+				t.Fatalf("%s: %s fetched %d times, want %d", step, table, got, n)
+			}
+		}
+	}
+
+	warm()
+	warm()
+	expect("warm", map[string]int{"User": 1, "Role": 1, "Permission": 1})
+
+	_, _ = c.Save(ctx, "User", map[string]any{"id": "u1"}, nil)
+	warm()
+	expect("save", map[string]int{"User": 2, "Role": 1, "Permission": 1})
+
+	_, _ = c.Save(ctx, "User", map[string]any{"id": "u1"}, []string{"roles:Role(userId,id)"})
+	warm()
+	expect("cascade save", map[string]int{"User": 3, "Role": 2, "Permission": 1})
+
+	_ = c.Delete(ctx, "Role", "r1")
+	_ = c.BatchSave(ctx, "Permission", []any{map[string]any{"id": "p1"}}, 10)
+	warm()
+	expect("delete and batch", map[string]int{"User": 3, "Role": 3, "Permission": 2})
+
+	_, _ = c.From("Role").Where(contract.Eq("id", "r1")).SetUpdates(map[string]any{"name": "x"}).Update(ctx)
+	_, _ = c.From("Permission").Delete(ctx)
+	warm()
+	expect("query writes", map[string]int{"User": 3, "Role": 4, "Permission": 3})
+
+	_ = c.PublishSchema(ctx, contract.Schema{})
+	warm()
+	expect("schema", map[string]int{"User": 4, "Role": 5, "Permission": 4})
+
+	if stats := c.Stats().QueryCache; stats.Invalidations != 10 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestQueryCacheDependencies(t *testing.T) {
+	c, _, calls := cacheTestClient(t, contract.QueryCacheConfig{TTL: time.Minute}, `[]`)
+	ctx := context.Background()
+	within := func() contract.Query {
+		return c.From("User").Where(contract.Within("id", c.From("Role").Select("userId")))
+	}
+	resolved := func() contract.Query { return c.From("User").Resolve("roles") }
+
+	_, _ = within().List(ctx)
+	_, _ = resolved().List(ctx)
+	c.cache.invalidate("Role")
+	_, _ = within().List(ctx)
+	_, _ = resolved().List(ctx)
+	if n := calls("PUT /data/db_test/query/User"); n != 4 {
+		t.Fatalf("nested and resolver queries should depend on Role, got %d requests", n)
+	}
+	c.cache.invalidate("Permission")
+	_, _ = within().List(ctx)
+	_, _ = resolved().List(ctx)
+	if n := calls("PUT /data/db_test/query/User"); n != 5 {
+		t.Fatalf("only the resolver query may read Permission, got %d requests", n)
+	}
+}
+
+func TestQueryCacheSkipsResultsRacingAWrite(t *testing.T) {
+	var c *client
+	c = newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		c.cache.invalidate("Role") // a write lands while the read is in flight
+		_, _ = io.WriteString(w, `[]`)
+	})
+	c.cache = newQueryCache(&contract.QueryCacheConfig{TTL: time.Minute}, time.Now)
+	if _, err := c.From("Role").List(context.Background()); err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if stats := c.Stats().QueryCache; stats.Entries != 0 {
+		t.Fatalf("a result read before a write must not be cached: %+v", stats)
+	}
+
+	internal := c.freshQuery("Role")
+	for i := 0; i < 2; i++ {
+		_, _ = internal.List(context.Background())
+	}
+	if stats := c.Stats().QueryCache; stats.Misses != 1 || stats.Entries != 0 {
+		t.Fatalf("fresh queries should bypass the cache: %+v", stats)
+	}
+}
+
+func TestQueryCacheWatchEvictsOnRemoteChanges(t *testing.T) {
+	events := make(chan string)
+	var mu sync.Mutex
+	var streamQueries []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Path, "/query/stream/") {
+			_, _ = io.WriteString(w, `[]`)
+			return
+		}
+		mu.Lock()
+		streamQueries = append(streamQueries, r.URL.RawQuery)
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case ev, ok := <-events:
+				if !ok {
+					return
+				}
+				_, _ = io.WriteString(w, ev+"\n")
+				w.(http.Flusher).Flush()
+			}
+		}
+	})
+	c.cache = newQueryCache(&contract.QueryCacheConfig{TTL: time.Minute}, time.Now)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		c.watchTable(ctx, "Role")
+		close(done)
+	}()
+
+	waitFor := func(cond func() bool) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for !cond() {
+			if time.Now().After(deadline) {
+				t.Fatalf("timed out; stats %+v", c.Stats().QueryCache)
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+	// Connecting invalidates once, since changes made while disconnected were missed.
+	waitFor(func() bool { c.cache.mu.Lock(); defer c.cache.mu.Unlock(); return c.cache.gen == 1 })
+	mu.Lock()
+	queries := append([]string(nil), streamQueries...)
+	mu.Unlock()
+	if len(queries) != 1 || queries[0] != "includeQueryResults=false&keepAlive=true" {
+		t.Fatalf("unexpected stream queries %q", queries)
+	}
+
+	_, _ = c.From("Role").List(context.Background())
+	_, _ = c.From("User").List(context.Background())
+	if entries := c.Stats().QueryCache.Entries; entries != 2 {
+		t.Fatalf("expected both lists cached, got %d", entries)
+	}
+	events <- `{"action":"UPDATE","entity":{"id":"r1"}}`
+	waitFor(func() bool { return c.Stats().QueryCache.Entries == 1 })
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("watch did not stop with its context")
+	}
+}
+
+func TestCloseStopsTableWatchers(t *testing.T) {
+	var open int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&open, 1)
+		defer atomic.AddInt32(&open, -1)
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	waitFor := func(what string, cond func() bool) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for !cond() {
+			if time.Now().After(deadline) {
+				t.Fatalf("timed out waiting for %s", what)
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+
+	before := runtime.NumGoroutine()
+	c := &client{
+		httpClient: httpclient.New(srv.URL, srv.Client(), httpclient.Options{}),
+		cfg:        resolver.ResolvedConfig{DatabaseID: "db_test"},
+		cache:      newQueryCache(&contract.QueryCacheConfig{TTL: time.Minute}, time.Now),
+	}
+	c.startWatchers(context.Background(), []string{"Role", "Permission"})
+	waitFor("both streams to open", func() bool { return atomic.LoadInt32(&open) == 2 })
+
+	if err := c.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	waitFor("streams to close", func() bool { return atomic.LoadInt32(&open) == 0 })
+	srv.Client().CloseIdleConnections()
+	waitFor("watcher goroutines to exit", func() bool { return runtime.NumGoroutine() <= before })
+
+	if err := c.Close(); err != nil {
+		t.Fatalf("second close: %v", err)
+	}
+}
@@ -16,7 +16,7 @@ func (q *query) queryPath() string {
 func (q *query) List(ctx context.Context) (contract.QueryResults, error) {
 	payload := buildQueryPayload(q, true)
 	var resp contract.QueryResults
-	if err := q.client.httpClient.DoJSON(ctx, http.MethodPut, q.queryPath(), payload, &resp); err != nil {
+	if err := q.read(ctx, q.queryPath(), payload, &resp); err != nil {
 		return nil, err
 	}
 	return resp, nil
@@ -37,7 +37,7 @@ func (q *query) Page(ctx context.Context, cursor string) (contract.PageResult, e
 	if len(params) > 0 {
 		path += "?" + params.Encode()
 	}
-	if err := q.client.httpClient.DoJSON(ctx, http.MethodPut, path, payload, &resp); err != nil {
+	if err := q.read(ctx, path, payload, &resp); err != nil {
 		return contract.PageResult{}, err
 	}
 	return resp, nil
@@ -56,6 +56,7 @@ func (q *query) Stream(ctx context.Context) (contract.Iterator, error) {
 func (q *query) Update(ctx context.Context) (int, error) {
 	payload := buildUpdatePayload(q)
 	path := "/data/" + url.PathEscape(q.client.cfg.DatabaseID) + "/query/update/" + url.PathEscape(q.table)
+	defer q.client.invalidate(q.table)
 	var updated int
 	if err := q.client.httpClient.DoJSON(ctx, http.MethodPut, path, payload, &updated); err != nil {
 		return 0, err
@@ -66,6 +67,7 @@ func (q *query) Update(ctx context.Context) (int, error) {
 func (q *query) Delete(ctx context.Context) (int, error) {
 	payload := buildQueryPayload(q, true)
 	path := "/data/" + url.PathEscape(q.client.cfg.DatabaseID) + "/query/delete/" + url.PathEscape(q.table)
+	defer q.client.invalidate(q.table)
 	var deleted int
 	if err := q.client.httpClient.DoJSON(ctx, http.MethodPut, path, payload, &deleted); err != nil {
 		return 0, err
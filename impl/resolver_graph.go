@@ -0,0 +1,154 @@
+package impl
+
+import (
+	"regexp"
+	"sort"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+var (
+	resolverFromRe = regexp.MustCompile(`db\.from\(\s*["']([^"']+)["']\s*\)`)
+	resolverEqRe   = regexp.MustCompile(`eq\(\s*["']([^"']+)["']\s*,\s*this\.([A-Za-z_][A-Za-z0-9_]*)\s*\)`)
+)
+
+// resolverLink is a join extracted from a resolver script: rows of Target whose TargetField
+// equals the owning record's SourceField.
+type resolverLink struct {
+	Resolver    string
+	Target      string
+	TargetField string
+	SourceField string
+}
+
+// resolverLinks extracts joins from a table's resolvers. Each eq(field, this.x) is attributed to
+// the nearest preceding db.from(...), which handles nested lookups such as join tables in inOp.
+func resolverLinks(t contract.Table) []resolverLink {
+	var links []resolverLink
+	for _, r := range t.Resolvers {
+		froms := resolverFromRe.FindAllStringSubmatchIndex(r.Resolver, -1)
+		for _, eq := range resolverEqRe.FindAllStringSubmatchIndex(r.Resolver, -1) {
+			target := ""
+			for _, f := range froms {
+				if f[0] > eq[0] {
+					break
+				}
+				target = r.Resolver[f[2]:f[3]]
+			}
+			if target == "" {
+				continue
+			}
+			links = append(links, resolverLink{
+				Resolver:    r.Name,
+				Target:      target,
+				TargetField: r.Resolver[eq[2]:eq[3]],
+				SourceField: r.Resolver[eq[4]:eq[5]],
+			})
+		}
+	}
+	return links
+}
+
+// tableDependencies maps each table to the tables that must be written before it.
+// A link keyed on the owner's primary key means the target holds the foreign key, so the target
+// depends on the owner; any other link means the owner holds the reference and depends on the target.
+func tableDependencies(schema contract.Schema) map[string]map[string]bool {
+	deps := map[string]map[string]bool{}
+	add := func(child, parent string) {
+		if child == parent {
+			return
+		}
+		if deps[child] == nil {
+			deps[child] = map[string]bool{}
+		}
+		deps[child][parent] = true
+	}
+	for _, t := range schema.Tables {
+		pk := primaryField(t)
+		for _, link := range resolverLinks(t) {
+			if pk != "" && link.SourceField == pk {
+				add(link.Target, t.Name)
+			} else {
+				add(t.Name, link.Target)
+			}
+		}
+	}
+	return deps
+}
+
+// orderTables sorts tables so that parents precede the tables that depend on them, following
+// dependencies transitively through tables that are not in the list. Ties and tables caught in a
+// cycle keep their original relative order.
+func orderTables(schema contract.Schema, tables []string) []string {
+	deps := tableDependencies(schema)
+	position := make(map[string]int, len(tables))
+	for i, t := range tables {
+		position[t] = i
+	}
+
+	// requires[t] holds the listed tables that t depends on, directly or transitively.
+	requires := make(map[string]map[string]bool, len(tables))
+	for _, t := range tables {
+		seen := map[string]bool{t: true}
+		stack := []string{t}
+		requires[t] = map[string]bool{}
+		for len(stack) > 0 {
+			cur := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for parent := range deps[cur] {
+				if seen[parent] {
+					continue
+				}
+				seen[parent] = true
+				stack = append(stack, parent)
+				if _, listed := position[parent]; listed {
+					requires[t][parent] = true
+				}
+			}
+		}
+	}
+
+	var ordered []string
+	placed := map[string]bool{}
+	for len(ordered) < len(tables) {
+		var ready []string
+		for _, t := range tables {
+			if placed[t] {
+				continue
+			}
+			blocked := false
+			for parent := range requires[t] {
+				// Mutual requirements form a cycle; neither side can block the other.
+				if !placed[parent] && !requires[parent][t] {
+					blocked = true
+					break
+				}
+			}
+			if !blocked {
+				ready = append(ready, t)
+			}
+		}
+		if len(ready) == 0 {
+			for _, t := range tables {
+				if !placed[t] {
+					ready = append(ready, t)
+				}
+			}
+		}
+		sort.SliceStable(ready, func(i, j int) bool { return position[ready[i]] < position[ready[j]] })
+		for _, t := range ready {
+			placed[t] = true
+		}
+		ordered = append(ordered, ready...)
+	}
+	return ordered
+}
+
+func primaryField(t contract.Table) string {
+	for _, f := range t.Fields {
+		if f.Primary {
+			return f.Name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,21 @@
+package schema
+
+import internal "github.com/OnyxDevTools/onyx-database-go/internal/schema"
+
+// Change re-exports the classification of a single diff entry.
+type Change = internal.Change
+
+// Severity re-exports the change severity grades.
+type Severity = internal.Severity
+
+// Change severities, from least to most severe.
+const (
+	SeveritySafe     = internal.SeveritySafe
+	SeverityRisky    = internal.SeverityRisky
+	SeverityBreaking = internal.SeverityBreaking
+)
+
+// ParseSeverity validates a severity name.
+func ParseSeverity(name string) (Severity, error) {
+	return internal.ParseSeverity(name)
+}
@@ -14,6 +14,21 @@ type TableDiff = internal.TableDiff
 // FieldDiff re-exports field-level differences.
 type FieldDiff = internal.FieldDiff
 
+// ResolverDiff re-exports resolver-level differences.
+type ResolverDiff = internal.ResolverDiff
+
+// IndexDiff re-exports index-level differences.
+type IndexDiff = internal.IndexDiff
+
+// TriggerDiff re-exports trigger-level differences.
+type TriggerDiff = internal.TriggerDiff
+
+// PartitionDiff re-exports a partition change.
+type PartitionDiff = internal.PartitionDiff
+
+// MetaDiff re-exports a table metadata change.
+type MetaDiff = internal.MetaDiff
+
 // DiffSchemas reports the differences between two schemas.
 func DiffSchemas(a, b contract.Schema) SchemaDiff {
 	return internal.DiffSchemas(a, b)
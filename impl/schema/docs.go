@@ -0,0 +1,28 @@
+package schema
+
+import (
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+	internal "github.com/OnyxDevTools/onyx-database-go/internal/schema"
+)
+
+// Relationship re-exports an inferred edge of the table graph.
+type Relationship = internal.Relationship
+
+// Relationship kinds.
+const (
+	RelationshipResolver  = internal.RelationshipResolver
+	RelationshipReference = internal.RelationshipReference
+)
+
+// DocFormats lists the formats RenderDocs accepts.
+var DocFormats = internal.DocFormats
+
+// InferRelationships derives table edges from resolvers and Id-suffixed fields.
+func InferRelationships(s contract.Schema) []Relationship {
+	return internal.InferRelationships(s)
+}
+
+// RenderDocs renders the schema as markdown, mermaid or dot.
+func RenderDocs(s contract.Schema, format string) (string, error) {
+	return internal.RenderDocs(s, format)
+}
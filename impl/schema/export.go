@@ -0,0 +1,30 @@
+package schema
+
+import (
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+	internal "github.com/OnyxDevTools/onyx-database-go/internal/schema"
+)
+
+// JSONSchemaDialect is the $schema of exported JSON Schema documents.
+const JSONSchemaDialect = internal.JSONSchemaDialect
+
+// ExportFormats lists the formats Export accepts.
+var ExportFormats = internal.ExportFormats
+
+// ExportOptions re-exports the exported document settings.
+type ExportOptions = internal.ExportOptions
+
+// Export renders the schema as a JSON Schema or OpenAPI document.
+func Export(s contract.Schema, format string, opts ExportOptions) (map[string]any, error) {
+	return internal.Export(s, format, opts)
+}
+
+// ExportJSONSchema renders the tables as JSON Schema 2020-12 definitions.
+func ExportJSONSchema(s contract.Schema, opts ExportOptions) map[string]any {
+	return internal.ExportJSONSchema(s, opts)
+}
+
+// ExportOpenAPI renders the tables as OpenAPI 3.1 component schemas.
+func ExportOpenAPI(s contract.Schema, opts ExportOptions) map[string]any {
+	return internal.ExportOpenAPI(s, opts)
+}
@@ -0,0 +1,22 @@
+package schema
+
+import (
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+	internal "github.com/OnyxDevTools/onyx-database-go/internal/schema"
+)
+
+// PatchOperation re-exports a single RFC 6902 operation.
+type PatchOperation = internal.PatchOperation
+
+// PatchConflictError re-exports the error returned when a patch does not match its target.
+type PatchConflictError = internal.PatchConflictError
+
+// DiffJSONPatch returns an RFC 6902 patch from a to b.
+func DiffJSONPatch(a, b contract.Schema) ([]PatchOperation, error) {
+	return internal.DiffJSONPatch(a, b)
+}
+
+// ApplyJSONPatch applies an RFC 6902 patch to s.
+func ApplyJSONPatch(s contract.Schema, ops []PatchOperation) (contract.Schema, error) {
+	return internal.ApplyJSONPatch(s, ops)
+}
@@ -0,0 +1,43 @@
+package schema
+
+import (
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+	internal "github.com/OnyxDevTools/onyx-database-go/internal/schema"
+)
+
+// MigrationPlan re-exports the ordered migration plan.
+type MigrationPlan = internal.MigrationPlan
+
+// MigrationStep re-exports a single migration step.
+type MigrationStep = internal.MigrationStep
+
+// MigrationStepKind re-exports the migration step kinds.
+type MigrationStepKind = internal.MigrationStepKind
+
+// Migration step kinds, in the order PlanMigration emits them.
+const (
+	StepAddTable           = internal.StepAddTable
+	StepAddField           = internal.StepAddField
+	StepRelaxNullability   = internal.StepRelaxNullability
+	StepBackfill           = internal.StepBackfill
+	StepTightenNullability = internal.StepTightenNullability
+	StepChangeField        = internal.StepChangeField
+	StepUpdateResolvers    = internal.StepUpdateResolvers
+	StepDropField          = internal.StepDropField
+	StepDropTable          = internal.StepDropTable
+)
+
+// PlanMigration orders the steps that move base to target.
+func PlanMigration(base, target contract.Schema) MigrationPlan {
+	return internal.PlanMigration(base, target)
+}
+
+// ApplyMigrationStep applies a schema step to s.
+func ApplyMigrationStep(s contract.Schema, step MigrationStep) (contract.Schema, error) {
+	return internal.ApplyMigrationStep(s, step)
+}
+
+// SchemaChecksum returns a stable hash of the normalized schema.
+func SchemaChecksum(s contract.Schema) string {
+	return internal.SchemaChecksum(s)
+}
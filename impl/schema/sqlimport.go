@@ -0,0 +1,14 @@
+package schema
+
+import (
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+	internal "github.com/OnyxDevTools/onyx-database-go/internal/schema"
+)
+
+// SQLWarning re-exports a construct ImportSQL skipped or approximated.
+type SQLWarning = internal.SQLWarning
+
+// ImportSQL converts SQL DDL into a schema, returning warnings for unsupported constructs.
+func ImportSQL(src string) (contract.Schema, []SQLWarning, error) {
+	return internal.ImportSQL(src)
+}
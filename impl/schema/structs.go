@@ -0,0 +1,29 @@
+package schema
+
+import (
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+	internal "github.com/OnyxDevTools/onyx-database-go/internal/schema"
+)
+
+// ResolverTag names the struct tag holding an explicit resolver script for FromStructs.
+const ResolverTag = internal.ResolverTag
+
+// FromStructs derives a schema from Go struct models and their onyx tags.
+func FromStructs(models ...any) (contract.Schema, error) {
+	return internal.FromStructs(models...)
+}
+
+// RegisterModels adds models to a named set for FromRegistered and onyx-schema-go --from-go.
+func RegisterModels(set string, models ...any) {
+	internal.RegisterModels(set, models...)
+}
+
+// RegisteredModelSets lists the registered model set names.
+func RegisteredModelSets() []string {
+	return internal.RegisteredModelSets()
+}
+
+// FromRegistered derives the schema for a registered model set.
+func FromRegistered(set string) (contract.Schema, error) {
+	return internal.FromRegistered(set)
+}
@@ -0,0 +1,30 @@
+package schema
+
+import (
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+	internal "github.com/OnyxDevTools/onyx-database-go/internal/schema"
+)
+
+// Diagnostic re-exports a single schema validation problem.
+type Diagnostic = internal.Diagnostic
+
+// DiagnosticSeverity re-exports the diagnostic severity levels.
+type DiagnosticSeverity = internal.DiagnosticSeverity
+
+// DiagnosticError marks a diagnostic that makes the schema invalid.
+const DiagnosticError = internal.DiagnosticError
+
+// ValidateSchema checks a schema, with pointers into its tables representation.
+func ValidateSchema(s contract.Schema) []Diagnostic {
+	return internal.ValidateSchema(s)
+}
+
+// ValidateSchemaJSON parses and checks a schema document, with pointers into data.
+func ValidateSchemaJSON(data []byte) ([]Diagnostic, error) {
+	return internal.ValidateSchemaJSON(data)
+}
+
+// HasErrors reports whether any diagnostic makes the schema invalid.
+func HasErrors(diags []Diagnostic) bool {
+	return internal.HasErrors(diags)
+}
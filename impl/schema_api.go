@@ -10,29 +10,6 @@ import (
 	"github.com/OnyxDevTools/onyx-database-go/contract"
 )
 
-type schemaEntity struct {
-	Name       string            `json:"name"`
-	Identifier *schemaIdentifier `json:"identifier,omitempty"`
-	Attributes []schemaAttribute `json:"attributes,omitempty"`
-	Partition  string            `json:"partition,omitempty"`
-	Indexes    []map[string]any  `json:"indexes,omitempty"`
-	Resolvers  []map[string]any  `json:"resolvers,omitempty"`
-	Triggers   []map[string]any  `json:"triggers,omitempty"`
-	Meta       map[string]any    `json:"meta,omitempty"`
-}
-
-type schemaIdentifier struct {
-	Name      string `json:"name"`
-	Generator string `json:"generator,omitempty"`
-	Type      string `json:"type,omitempty"`
-}
-
-type schemaAttribute struct {
-	Name       string `json:"name"`
-	Type       string `json:"type"`
-	IsNullable bool   `json:"isNullable,omitempty"`
-}
-
 func fetchSchema(ctx context.Context, c *client, tables []string) (contract.Schema, error) {
 	var raw map[string]any
 	params := url.Values{}
@@ -64,26 +41,6 @@ func fetchSchema(ctx context.Context, c *client, tables []string) (contract.Sche
 	if cleaned, ok := stripEntityText(raw).(map[string]any); ok {
 		raw = cleaned
 	}
-	if list, ok := raw["schemas"].([]any); ok && len(list) > 0 {
-		// take latest entry
-		if obj, ok := list[len(list)-1].(map[string]any); ok {
-			return schemaFromEntities(obj["entities"].([]any)), nil
-		}
-	}
-	if schemaObj, ok := raw["schema"].(map[string]any); ok {
-		if entities, ok := schemaObj["entities"].([]any); ok {
-			return schemaFromEntities(entities), nil
-		}
-		if tableSlice, ok := schemaObj["tables"].([]any); ok {
-			return schemaFromTablesArray(tableSlice), nil
-		}
-	}
-	if entities, ok := raw["entities"].([]any); ok {
-		return schemaFromEntities(entities), nil
-	}
-	if tableSlice, ok := raw["tables"].([]any); ok {
-		return schemaFromTablesArray(tableSlice), nil
-	}
 	if tablesMap, ok := raw["tables"].(map[string]any); ok {
 		tables := make([]map[string]any, 0, len(tablesMap))
 		for name, val := range tablesMap {
@@ -117,6 +74,7 @@ var jsonMarshal = json.Marshal
 // publishSchema sends the schema using the TS-style endpoint /schemas/{databaseId}.
 func publishSchema(ctx context.Context, c *client, schema contract.Schema, publish bool) error {
 	normalized := contract.NormalizeSchema(schema)
+	defer c.invalidate()
 	params := url.Values{}
 	if publish {
 		params.Set("publish", "true")
@@ -131,176 +89,8 @@ func publishSchema(ctx context.Context, c *client, schema contract.Schema, publi
 func schemaUpsertPayload(schema contract.Schema, databaseID string) map[string]any {
 	return map[string]any{
 		"databaseId": databaseID,
-		"entities":   toEntities(schema),
-	}
-}
-
-func toEntities(s contract.Schema) []schemaEntity {
-	entities := make([]schemaEntity, 0, len(s.Tables))
-	for _, t := range s.Tables {
-		ent := schemaEntity{Name: t.Name}
-		if len(t.Indexes) > 0 {
-			for _, idx := range t.Indexes {
-				ent.Indexes = append(ent.Indexes, map[string]any{"name": idx.Name})
-			}
-		}
-		if len(t.Triggers) > 0 {
-			for _, trig := range t.Triggers {
-				ent.Triggers = append(ent.Triggers, map[string]any{"name": trig})
-			}
-		}
-		if t.Meta != nil {
-			ent.Meta = t.Meta
-		}
-		for _, f := range t.Fields {
-			attr := schemaAttribute{
-				Name:       f.Name,
-				Type:       f.Type,
-				IsNullable: f.Nullable,
-			}
-			ent.Attributes = append(ent.Attributes, attr)
-			if f.Primary {
-				ent.Identifier = &schemaIdentifier{Name: f.Name, Generator: "None", Type: f.Type}
-			}
-		}
-		if len(t.Resolvers) > 0 {
-			for _, r := range t.Resolvers {
-				entry := map[string]any{"name": r.Name}
-				if r.Resolver != "" {
-					entry["resolver"] = r.Resolver
-				}
-				if r.Meta != nil {
-					entry["meta"] = r.Meta
-				}
-				ent.Resolvers = append(ent.Resolvers, entry)
-			}
-		}
-		entities = append(entities, ent)
-	}
-	return entities
-}
-
-func schemaFromEntities(items []any) contract.Schema {
-	var tables []contract.Table
-	for _, raw := range items {
-		obj, ok := raw.(map[string]any)
-		if !ok {
-			continue
-		}
-		name, _ := obj["name"].(string)
-		table := contract.Table{Name: name}
-		var idName string
-		if ident, ok := obj["identifier"].(map[string]any); ok {
-			if n, ok := ident["name"].(string); ok {
-				idName = n
-			}
-		}
-		if attrs, ok := obj["attributes"].([]any); ok {
-			for _, a := range attrs {
-				attrObj, ok := a.(map[string]any)
-				if !ok {
-					continue
-				}
-				field := contract.Field{
-					Name: attrObj["name"].(string),
-				}
-				if t, ok := attrObj["type"].(string); ok {
-					field.Type = t
-				}
-				if n, ok := attrObj["isNullable"].(bool); ok {
-					field.Nullable = n
-				}
-				if field.Name == idName {
-					field.Primary = true
-				}
-				table.Fields = append(table.Fields, field)
-			}
-		}
-		if res, ok := obj["resolvers"].([]any); ok {
-			for _, r := range res {
-				switch rv := r.(type) {
-				case string:
-					table.Resolvers = append(table.Resolvers, contract.Resolver{Name: rv})
-				case map[string]any:
-					if name, ok := rv["name"].(string); ok {
-						table.Resolvers = append(table.Resolvers, contract.Resolver{
-							Name:     name,
-							Resolver: stringValue(rv["resolver"]),
-							Meta:     mapValue(rv["meta"]),
-						})
-					}
-				}
-			}
-		}
-		tables = append(tables, table)
-	}
-	return contract.Schema{Tables: tables}
-}
-
-func schemaFromTablesArray(items []any) contract.Schema {
-	var tables []contract.Table
-	for _, raw := range items {
-		obj, ok := raw.(map[string]any)
-		if !ok {
-			continue
-		}
-		t := contract.Table{Name: stringValue(obj["name"])}
-		if fields, ok := obj["fields"].([]any); ok {
-			for _, f := range fields {
-				fm, ok := f.(map[string]any)
-				if !ok {
-					continue
-				}
-				field := contract.Field{
-					Name:     stringValue(fm["name"]),
-					Type:     stringValue(fm["type"]),
-					Nullable: boolValue(fm["nullable"]),
-					Primary:  boolValue(fm["primaryKey"]),
-					Unique:   boolValue(fm["unique"]),
-				}
-				t.Fields = append(t.Fields, field)
-			}
-		}
-		if res, ok := obj["resolvers"].([]any); ok {
-			for _, r := range res {
-				switch rv := r.(type) {
-				case string:
-					t.Resolvers = append(t.Resolvers, contract.Resolver{Name: rv})
-				case map[string]any:
-					if name, ok := rv["name"].(string); ok {
-						t.Resolvers = append(t.Resolvers, contract.Resolver{
-							Name:     name,
-							Resolver: stringValue(rv["resolver"]),
-							Meta:     mapValue(rv["meta"]),
-						})
-					}
-				}
-			}
-		}
-		tables = append(tables, t)
-	}
-	return contract.Schema{Tables: tables}
-}
-
-func stringValue(v any) string {
-	if s, ok := v.(string); ok {
-		return s
-	}
-	return ""
-}
-
-func boolValue(v any) bool {
-	if b, ok := v.(bool); ok {
-		return b
-	}
-	return false
-}
-
-func mapValue(v any) map[string]any {
-	if m, ok := v.(map[string]any); ok {
-		return m
+		"entities":   contract.SchemaToEntities(schema),
 	}
-	return nil
 }
 
 func stripEntityText(v any) any {
@@ -59,14 +59,4 @@ func TestStripEntityTextAndHelpers(t *testing.T) {
 	if inner, ok := cleaned["nested"].([]any); !ok || inner[0].(map[string]any)["entityText"] != nil {
 		t.Fatalf("expected nested entityText removed")
 	}
-
-	if stringValue(nil) != "" {
-		t.Fatalf("stringValue default mismatch")
-	}
-	if boolValue("nope") {
-		t.Fatalf("boolValue default mismatch")
-	}
-	if mapValue(123) != nil {
-		t.Fatalf("mapValue default mismatch")
-	}
 }
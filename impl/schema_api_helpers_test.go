@@ -1,6 +1,7 @@
 package impl
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/OnyxDevTools/onyx-database-go/contract"
@@ -20,7 +21,7 @@ func TestSchemaHelperConversions(t *testing.T) {
 			"resolvers": []any{"roles"},
 		},
 	}
-	schema := schemaFromEntities(entities)
+	schema := parseRawSchema(t, "entities", entities)
 	if len(schema.Tables) != 1 || schema.Tables[0].Fields[0].Primary != true {
 		t.Fatalf("expected primary key detected: %+v", schema.Tables[0].Fields)
 	}
@@ -36,15 +37,10 @@ func TestSchemaHelperConversions(t *testing.T) {
 			},
 		},
 	}
-	schema2 := schemaFromTablesArray(tablesArray)
+	schema2 := parseRawSchema(t, "tables", tablesArray)
 	if len(schema2.Tables) != 1 || !schema2.Tables[0].Fields[0].Primary {
 		t.Fatalf("expected primary key from tables array")
 	}
-
-	// coverage for helper wrappers
-	_ = stringValue("x")
-	_ = boolValue(true)
-	_ = mapValue(map[string]any{"a": 1})
 }
 
 func TestToEntitiesIncludesResolversMeta(t *testing.T) {
@@ -60,14 +56,14 @@ func TestToEntitiesIncludesResolversMeta(t *testing.T) {
 					{Name: "r", Resolver: "db.from(\"X\")", Meta: map[string]any{"a": 1}},
 				},
 				Indexes: []contract.Index{{Name: "idx"}},
-				Triggers: []string{
-					"trg",
+				Triggers: []contract.Trigger{
+					{Name: "trg", Event: "PreSave", Trigger: "return true"},
 				},
 				Meta: map[string]any{"m": "v"},
 			},
 		},
 	}
-	entities := toEntities(schema)
+	entities := contract.SchemaToEntities(schema)
 	if len(entities) != 1 || len(entities[0].Resolvers) != 1 {
 		t.Fatalf("expected resolver exported: %+v", entities)
 	}
@@ -75,3 +71,17 @@ func TestToEntitiesIncludesResolversMeta(t *testing.T) {
 		t.Fatalf("expected indexes/triggers/meta exported: %+v", entities[0])
 	}
 }
+
+// parseRawSchema runs decoded schema items through the shared contract codec.
+func parseRawSchema(t *testing.T, key string, items []any) contract.Schema {
+	t.Helper()
+	data, err := json.Marshal(map[string]any{key: items})
+	if err != nil {
+		t.Fatalf("marshal %s: %v", key, err)
+	}
+	schema, err := contract.ParseSchemaJSON(data)
+	if err != nil {
+		t.Fatalf("parse %s: %v", key, err)
+	}
+	return schema
+}
@@ -18,7 +18,7 @@ func TestSchemaFromEntitiesCoverage(t *testing.T) {
 			"meta": map[string]any{"owner": "team"},
 		},
 	}
-	schema := schemaFromEntities(raw)
+	schema := parseRawSchema(t, "entities", raw)
 	if len(schema.Tables) != 1 || schema.Tables[0].Fields[0].Primary != true {
 		t.Fatalf("expected primary id")
 	}
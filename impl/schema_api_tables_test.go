@@ -17,7 +17,7 @@ func TestSchemaFromTablesArrayCoversAllBranches(t *testing.T) {
 		},
 	}
 
-	schema := schemaFromTablesArray(raw)
+	schema := parseRawSchema(t, "tables", raw)
 	if len(schema.Tables) != 1 {
 		t.Fatalf("expected one table, got %d", len(schema.Tables))
 	}
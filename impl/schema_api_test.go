@@ -2,7 +2,11 @@ package impl
 
 import (
 	"context"
+	"encoding/json"
+	"io"
 	"net/http"
+	"os"
+	"reflect"
 	"testing"
 
 	"github.com/OnyxDevTools/onyx-database-go/contract"
@@ -47,3 +51,38 @@ func TestSchemaPublish(t *testing.T) {
 		t.Fatalf("expected publish request")
 	}
 }
+
+func TestSchemaFetchPublishRoundTrip(t *testing.T) {
+	fixture, err := os.ReadFile("../contract/testdata/entities_full.json")
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	var published []byte
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			published, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_, _ = w.Write(fixture)
+	})
+
+	schema, err := c.Schema(context.Background())
+	if err != nil {
+		t.Fatalf("schema err: %v", err)
+	}
+	if err := c.PublishSchema(context.Background(), schema); err != nil {
+		t.Fatalf("publish err: %v", err)
+	}
+
+	var want, got map[string]any
+	if err := json.Unmarshal(fixture, &want); err != nil {
+		t.Fatalf("decode fixture: %v", err)
+	}
+	if err := json.Unmarshal(published, &got); err != nil {
+		t.Fatalf("decode published: %v", err)
+	}
+	if !reflect.DeepEqual(got["entities"], want["entities"]) {
+		t.Fatalf("published entities differ from fetched:\n got %s\nwant %s", published, fixture)
+	}
+}
@@ -0,0 +1,349 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+type unitOfWork struct {
+	client    *client
+	ops       []uowOp
+	committed bool
+}
+
+type uowOp struct {
+	action        contract.UnitOfWorkAction
+	table         string
+	id            string
+	entity        any
+	relationships []string
+	updates       map[string]any
+}
+
+// uowStep pairs a report step with what is needed to compensate it. For a save with
+// relationships, saved is the record as written and children are the cascade rows that existed
+// before the save.
+type uowStep struct {
+	op       uowOp
+	pk       string
+	record   map[string]any
+	snapshot map[string]any
+	undoID   any
+	saved    map[string]any
+	children []contract.CascadeRow
+}
+
+func (c *client) UnitOfWork() contract.UnitOfWork {
+	return &unitOfWork{client: c}
+}
+
+func (u *unitOfWork) Save(table string, entity any, relationships ...string) contract.UnitOfWork {
+	u.ops = append(u.ops, uowOp{action: contract.UnitOfWorkSave, table: table, entity: entity, relationships: relationships})
+	return u
+}
+
+func (u *unitOfWork) Update(table, id string, updates map[string]any) contract.UnitOfWork {
+	u.ops = append(u.ops, uowOp{action: contract.UnitOfWorkUpdate, table: table, id: id, updates: updates})
+	return u
+}
+
+func (u *unitOfWork) Delete(table, id string) contract.UnitOfWork {
+	u.ops = append(u.ops, uowOp{action: contract.UnitOfWorkDelete, table: table, id: id})
+	return u
+}
+
+// Commit validates every queued operation, orders them by the resolver dependency graph, and
+// applies them one at a time. Nothing is written if validation fails. When a step fails, the
+// applied steps are compensated newest first using a context that ignores cancellation, so an
+// interrupted commit still rolls back.
+func (u *unitOfWork) Commit(ctx context.Context) (contract.UnitOfWorkReport, error) {
+	if u.committed {
+		return contract.UnitOfWorkReport{}, fmt.Errorf("unit of work already committed")
+	}
+	u.committed = true
+	if len(u.ops) == 0 {
+		return contract.UnitOfWorkReport{Committed: true}, nil
+	}
+
+	steps, err := u.prepare(ctx)
+	report := contract.UnitOfWorkReport{Steps: make([]contract.UnitOfWorkStep, len(steps))}
+	for i, s := range steps {
+		report.Steps[i] = contract.UnitOfWorkStep{Action: s.op.action, Table: s.op.table, ID: s.op.id, Status: contract.UnitOfWorkPending}
+	}
+	if err != nil {
+		return report, err
+	}
+
+	for i := range steps {
+		step := &report.Steps[i]
+		if err := u.apply(ctx, &steps[i], step); err != nil {
+			step.Status, step.Err = contract.UnitOfWorkFailed, err
+			failure := fmt.Errorf("unit of work %s %s %s: %w", step.Action, step.Table, step.ID, err)
+			return report, errors.Join(append([]error{failure}, u.compensate(context.WithoutCancel(ctx), steps[:i], report.Steps[:i])...)...)
+		}
+		step.Status = contract.UnitOfWorkApplied
+	}
+	report.Committed = true
+	return report, nil
+}
+
+// prepare resolves primary keys, validates operations, and returns them in execution order:
+// saves and updates with parents first, then deletes with children first.
+func (u *unitOfWork) prepare(ctx context.Context) ([]uowStep, error) {
+	steps := make([]uowStep, len(u.ops))
+	var tables []string
+	seen := map[string]bool{}
+	for i, op := range u.ops {
+		steps[i] = uowStep{op: op}
+		if !seen[op.table] {
+			seen[op.table] = true
+			tables = append(tables, op.table)
+		}
+	}
+
+	schema, err := u.client.Schema(ctx)
+	if err != nil {
+		return steps, err
+	}
+	pks := make(map[string]string, len(tables))
+	for _, table := range tables {
+		t, ok := schema.Table(table)
+		if !ok {
+			return steps, fmt.Errorf("table %s not found in schema", table)
+		}
+		pk := primaryField(t)
+		if pk == "" {
+			return steps, fmt.Errorf("table %s has no primary key", table)
+		}
+		u.client.tables.Store(table, t)
+		pks[table] = pk
+	}
+
+	for i := range steps {
+		s := &steps[i]
+		s.pk = pks[s.op.table]
+		switch s.op.action {
+		case contract.UnitOfWorkSave:
+			record, err := entityToMap(s.op.entity)
+			if err != nil {
+				return steps, fmt.Errorf("save on %s: %w", s.op.table, err)
+			}
+			s.record = record
+			if id, ok := record[s.pk]; ok && id != nil {
+				s.op.id = fmt.Sprint(id)
+			}
+		case contract.UnitOfWorkUpdate:
+			if strings.TrimSpace(s.op.id) == "" {
+				return steps, fmt.Errorf("update id is required")
+			}
+			if len(s.op.updates) == 0 {
+				return steps, fmt.Errorf("update updates are required")
+			}
+		case contract.UnitOfWorkDelete:
+			if strings.TrimSpace(s.op.id) == "" {
+				return steps, fmt.Errorf("delete id is required")
+			}
+		}
+	}
+
+	rank := map[string]int{}
+	for i, t := range orderTables(schema, tables) {
+		rank[t] = i
+	}
+	sort.SliceStable(steps, func(i, j int) bool {
+		a, b := steps[i], steps[j]
+		aDelete, bDelete := a.op.action == contract.UnitOfWorkDelete, b.op.action == contract.UnitOfWorkDelete
+		switch {
+		case aDelete != bDelete:
+			return bDelete
+		case aDelete:
+			return rank[a.op.table] > rank[b.op.table]
+		default:
+			return rank[a.op.table] < rank[b.op.table]
+		}
+	})
+	return steps, nil
+}
+
+// apply runs one step, capturing a snapshot first so it can be compensated later.
+func (u *unitOfWork) apply(ctx context.Context, s *uowStep, step *contract.UnitOfWorkStep) error {
+	c := u.client
+	switch s.op.action {
+	case contract.UnitOfWorkSave:
+		if s.op.id != "" {
+			snapshot, exists, err := c.fetchRecord(ctx, s.op.table, s.pk, s.record[s.pk])
+			if err != nil {
+				return err
+			}
+			if exists {
+				s.snapshot = snapshot
+			}
+		}
+		if len(s.op.relationships) > 0 {
+			children, err := c.cascadeRows(ctx, s.op.table, merged(s.snapshot, s.record), s.op.relationships)
+			if err != nil {
+				return err
+			}
+			s.children = children
+		}
+		saved, err := c.Save(ctx, s.op.table, s.op.entity, s.op.relationships)
+		if err != nil {
+			return err
+		}
+		s.saved = merged(s.record, saved)
+		if s.snapshot != nil {
+			step.Compensation = contract.CompensateRestore
+			return nil
+		}
+		s.undoID = s.record[s.pk]
+		if id, ok := saved[s.pk]; ok && id != nil {
+			s.undoID = id
+		}
+		if s.undoID != nil {
+			step.ID = fmt.Sprint(s.undoID)
+		}
+		step.Compensation = contract.CompensateDelete
+		return nil
+
+	case contract.UnitOfWorkUpdate:
+		snapshot, exists, err := c.fetchRecord(ctx, s.op.table, s.pk, s.op.id)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("record %s not found", s.op.id)
+		}
+		s.snapshot = snapshot
+		updated, err := c.updateByKey(ctx, s.op.table, s.pk, s.op.id, s.op.updates)
+		if err != nil {
+			return err
+		}
+		if updated == 0 {
+			return fmt.Errorf("record %s not found", s.op.id)
+		}
+		step.Compensation = contract.CompensateRestore
+		return nil
+
+	default:
+		snapshot, exists, err := c.fetchRecord(ctx, s.op.table, s.pk, s.op.id)
+		if err != nil {
+			return err
+		}
+		if err := c.Delete(ctx, s.op.table, s.op.id); err != nil {
+			return err
+		}
+		if exists {
+			s.snapshot = snapshot
+			step.Compensation = contract.CompensateRestore
+		}
+		return nil
+	}
+}
+
+// compensate undoes applied steps newest first, returning any compensation failures. The cascade
+// children of a save are undone before the row that owns them.
+func (u *unitOfWork) compensate(ctx context.Context, steps []uowStep, report []contract.UnitOfWorkStep) []error {
+	var errs []error
+	for i := len(steps) - 1; i >= 0; i-- {
+		s, step := steps[i], &report[i]
+		var err error
+		if s.saved != nil && len(s.op.relationships) > 0 {
+			err = u.undoCascade(ctx, s)
+		}
+		if err == nil {
+			switch step.Compensation {
+			case contract.CompensateRestore:
+				err = u.restore(ctx, s.op.table, s.snapshot)
+			case contract.CompensateDelete:
+				if s.undoID == nil {
+					err = fmt.Errorf("no %s returned for inserted row", s.pk)
+				} else {
+					err = u.client.Delete(ctx, s.op.table, fmt.Sprint(s.undoID))
+				}
+			}
+		}
+		if err != nil {
+			step.Status, step.RollbackErr = contract.UnitOfWorkRollbackFailed, err
+			errs = append(errs, fmt.Errorf("rollback %s %s %s: %w", step.Action, step.Table, step.ID, err))
+			continue
+		}
+		step.Status = contract.UnitOfWorkRolledBack
+	}
+	return errs
+}
+
+// undoCascade deletes the cascade rows a save created and restores the ones it may have changed
+// from the snapshots taken before the save.
+func (u *unitOfWork) undoCascade(ctx context.Context, s uowStep) error {
+	current, err := u.client.cascadeRows(ctx, s.op.table, s.saved, s.op.relationships)
+	if err != nil {
+		return err
+	}
+	existed := make(map[string]bool, len(s.children))
+	for _, row := range s.children {
+		existed[row.Table+"\x00"+row.ID] = true
+	}
+	var errs []error
+	for _, row := range current {
+		if existed[row.Table+"\x00"+row.ID] {
+			continue
+		}
+		if err := u.client.Delete(ctx, row.Table, row.ID); err != nil {
+			errs = append(errs, fmt.Errorf("delete %s %s: %w", row.Table, row.ID, err))
+		}
+	}
+	for _, row := range s.children {
+		if err := u.restore(ctx, row.Table, row.Record); err != nil {
+			errs = append(errs, fmt.Errorf("restore %s %s: %w", row.Table, row.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// restore writes a snapshot back. On a versioned table the version is advanced past both the
+// snapshot's and the stored one, so a writer still holding the snapshot's version conflicts.
+func (u *unitOfWork) restore(ctx context.Context, table string, snapshot map[string]any) error {
+	c := u.client
+	record := snapshot
+	field, versioned, err := c.versionField(ctx, table)
+	if err != nil {
+		return err
+	}
+	if versioned {
+		pk, err := c.primaryKey(ctx, table)
+		if err != nil {
+			return err
+		}
+		version, err := versionNumber(snapshot[field])
+		if err != nil {
+			return fmt.Errorf("field %s on %s: %w", field, table, err)
+		}
+		current, exists, err := c.currentVersion(ctx, table, pk, field, snapshot[pk])
+		if err != nil {
+			return err
+		}
+		if stored, err := versionNumber(current); exists && err == nil && stored > version {
+			version = stored
+		}
+		record = merged(snapshot, map[string]any{field: version + 1})
+	}
+	_, err = c.putEntity(ctx, table, record, nil)
+	return err
+}
+
+// merged returns a copy of base with the entries of over applied on top.
+func merged(base, over map[string]any) map[string]any {
+	out := make(map[string]any, len(base)+len(over))
+	for k, v := range base {
+		out[k] = v
+	}
+	for k, v := range over {
+		out[k] = v
+	}
+	return out
+}
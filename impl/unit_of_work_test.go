@@ -0,0 +1,314 @@
+package impl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+const uowSchemaResponse = `{"tables":[
+{"name":"User","fields":[{"name":"id","type":"String","primaryKey":true},{"name":"email","type":"String"}],
+ "resolvers":[{"name":"userRoles","resolver":"db.from(\"UserRole\")\n .where(eq(\"userId\", this.id))\n .list()"}]},
+{"name":"Role","fields":[{"name":"id","type":"String","primaryKey":true}]},
+{"name":"UserRole","fields":[{"name":"id","type":"String","primaryKey":true},{"name":"userId","type":"String"},{"name":"roleId","type":"String"}],
+ "resolvers":[{"name":"role","resolver":"db.from(\"Role\")\n .where(eq(\"id\", this.roleId))\n .firstOrNull()"}]}
+]}`
+
+// uowServer is an in-memory store keyed by table and "id" that logs every write.
+type uowServer struct {
+	t      *testing.T
+	schema string
+	rows   map[string]map[string]map[string]any
+	writes []string
+	failOn string
+}
+
+func newUOWServer(t *testing.T) *uowServer {
+	return &uowServer{t: t, schema: uowSchemaResponse, rows: map[string]map[string]map[string]any{"User": {}, "Role": {}, "UserRole": {}}}
+}
+
+func (s *uowServer) handle(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/database/db_test/schema":
+		_, _ = w.Write([]byte(s.schema))
+		return
+	case len(parts) >= 4 && parts[2] == "query":
+		var payload map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		table := parts[len(parts)-1]
+		cond := payload["conditions"].(map[string]any)
+		matches := []map[string]any{}
+		for _, id := range sortedKeys(s.rows[table]) {
+			if row := s.rows[table][id]; matchesPayload(cond, row) {
+				matches = append(matches, row)
+			}
+		}
+		if parts[3] != "update" {
+			_ = json.NewEncoder(w).Encode(matches)
+			return
+		}
+		if !s.write(w, "update "+table+" "+firstCriteriaValue(cond)) {
+			return
+		}
+		for _, row := range matches {
+			for k, v := range payload["updates"].(map[string]any) {
+				row[k] = v
+			}
+		}
+		_ = json.NewEncoder(w).Encode(len(matches))
+		return
+	}
+
+	table := parts[2]
+	switch r.Method {
+	case http.MethodPut:
+		var row map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&row)
+		id, _ := row["id"].(string)
+		if id == "" {
+			id = "gen1"
+			row["id"] = id
+		}
+		if !s.write(w, "put "+table+" "+id) {
+			return
+		}
+		if r.URL.Query().Get("relationships") != "" {
+			// Stand in for the server's cascade: embedded userRoles are written as UserRole rows.
+			roles, _ := row["userRoles"].([]any)
+			delete(row, "userRoles")
+			for _, item := range roles {
+				role := item.(map[string]any)
+				role["userId"] = id
+				s.writes = append(s.writes, "put UserRole "+role["id"].(string))
+				s.rows["UserRole"][role["id"].(string)] = role
+			}
+		}
+		s.rows[table][id] = row
+		_ = json.NewEncoder(w).Encode(row)
+	case http.MethodDelete:
+		if !s.write(w, "delete "+table+" "+parts[3]) {
+			return
+		}
+		delete(s.rows[table], parts[3])
+	default:
+		s.t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+	}
+}
+
+// matchesPayload evaluates the equality conditions the client sends: one criteria or an AND of them.
+func matchesPayload(cond, row map[string]any) bool {
+	if crit, ok := cond["criteria"].(map[string]any); ok {
+		return fmt.Sprint(row[crit["field"].(string)]) == fmt.Sprint(crit["value"])
+	}
+	for _, c := range cond["conditions"].([]any) {
+		if !matchesPayload(c.(map[string]any), row) {
+			return false
+		}
+	}
+	return true
+}
+
+func firstCriteriaValue(cond map[string]any) string {
+	if crit, ok := cond["criteria"].(map[string]any); ok {
+		return fmt.Sprint(crit["value"])
+	}
+	return firstCriteriaValue(cond["conditions"].([]any)[0].(map[string]any))
+}
+
+func sortedKeys(m map[string]map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (s *uowServer) write(w http.ResponseWriter, entry string) bool {
+	s.writes = append(s.writes, entry)
+	if entry == s.failOn {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"code":"server","message":"boom"}`))
+		return false
+	}
+	return true
+}
+
+func TestUnitOfWorkOrdersByResolverGraph(t *testing.T) {
+	srv := newUOWServer(t)
+	srv.rows["UserRole"]["ur0"] = map[string]any{"id": "ur0", "userId": "u0", "roleId": "r0"}
+	srv.rows["User"]["u0"] = map[string]any{"id": "u0"}
+	c := newTestClient(t, srv.handle)
+
+	report, err := c.UnitOfWork().
+		Delete("User", "u0").
+		Save("UserRole", map[string]any{"id": "ur1", "userId": "u1", "roleId": "r1"}).
+		Delete("UserRole", "ur0").
+		Save("User", map[string]any{"id": "u1", "email": "a@example.com"}).
+		Save("Role", map[string]any{"id": "r1"}).
+		Commit(context.Background())
+	if err != nil {
+		t.Fatalf("commit err: %v", err)
+	}
+	want := []string{"put User u1", "put Role r1", "put UserRole ur1", "delete UserRole ur0", "delete User u0"}
+	if !reflect.DeepEqual(srv.writes, want) {
+		t.Fatalf("unexpected write order:\n got %v\nwant %v", srv.writes, want)
+	}
+	if !report.Committed || len(report.WithStatus(contract.UnitOfWorkApplied)) != 5 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	if _, err := c.UnitOfWork().Commit(context.Background()); err != nil {
+		t.Fatalf("empty commit err: %v", err)
+	}
+}
+
+func TestUnitOfWorkCompensatesOnFailure(t *testing.T) {
+	srv := newUOWServer(t)
+	srv.rows["User"]["u1"] = map[string]any{"id": "u1", "email": "old@example.com"}
+	srv.rows["Role"]["r1"] = map[string]any{"id": "r1"}
+	srv.failOn = "delete Role r1"
+	c := newTestClient(t, srv.handle)
+
+	uow := c.UnitOfWork().
+		Update("User", "u1", map[string]any{"email": "new@example.com"}).
+		Save("UserRole", map[string]any{"userId": "u1", "roleId": "r1"}).
+		Delete("Role", "r1")
+	report, err := uow.Commit(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "delete Role r1") {
+		t.Fatalf("expected failure on role delete, got %v", err)
+	}
+
+	if srv.rows["User"]["u1"]["email"] != "old@example.com" {
+		t.Fatalf("expected user snapshot restored, got %+v", srv.rows["User"]["u1"])
+	}
+	if _, ok := srv.rows["UserRole"]["gen1"]; ok {
+		t.Fatalf("expected inserted user role to be deleted")
+	}
+	if report.Committed {
+		t.Fatalf("failed commit must not be marked committed")
+	}
+
+	failed, ok := report.Failed()
+	if !ok || failed.Table != "Role" || failed.Err == nil {
+		t.Fatalf("unexpected failed step: %+v", failed)
+	}
+	rolledBack := report.WithStatus(contract.UnitOfWorkRolledBack)
+	if len(rolledBack) != 2 || rolledBack[0].Compensation != contract.CompensateRestore || rolledBack[1].Compensation != contract.CompensateDelete || rolledBack[1].ID != "gen1" {
+		t.Fatalf("unexpected rolled back steps: %+v", rolledBack)
+	}
+
+	if _, err := uow.Commit(context.Background()); err == nil {
+		t.Fatalf("expected second commit to be rejected")
+	}
+}
+
+func TestUnitOfWorkCompensatesCascadeChildren(t *testing.T) {
+	srv := newUOWServer(t)
+	srv.rows["User"]["u1"] = map[string]any{"id": "u1", "email": "old@example.com"}
+	srv.rows["UserRole"]["ur0"] = map[string]any{"id": "ur0", "userId": "u1", "roleId": "r0"}
+	srv.rows["Role"]["r1"] = map[string]any{"id": "r1"}
+	srv.failOn = "delete Role r1"
+	c := newTestClient(t, srv.handle)
+
+	user := map[string]any{"id": "u1", "email": "new@example.com", "userRoles": []any{
+		map[string]any{"id": "ur0", "roleId": "r9"},
+		map[string]any{"id": "ur1", "roleId": "r1"},
+	}}
+	report, err := c.UnitOfWork().
+		Save("User", user, "userRoles:UserRole(userId,id)").
+		Delete("Role", "r1").
+		Commit(context.Background())
+	if err == nil {
+		t.Fatalf("expected failure on role delete")
+	}
+
+	if _, ok := srv.rows["UserRole"]["ur1"]; ok {
+		t.Fatalf("expected cascade-inserted user role to be deleted")
+	}
+	if srv.rows["UserRole"]["ur0"]["roleId"] != "r0" {
+		t.Fatalf("expected changed user role to be restored, got %+v", srv.rows["UserRole"]["ur0"])
+	}
+	if srv.rows["User"]["u1"]["email"] != "old@example.com" {
+		t.Fatalf("expected user restored, got %+v", srv.rows["User"]["u1"])
+	}
+	if rolledBack := report.WithStatus(contract.UnitOfWorkRolledBack); len(rolledBack) != 1 || rolledBack[0].Table != "User" {
+		t.Fatalf("unexpected rolled back steps: %+v", report.Steps)
+	}
+}
+
+func TestUnitOfWorkRestoreAdvancesVersion(t *testing.T) {
+	srv := newUOWServer(t)
+	srv.rows["User"]["u1"] = map[string]any{"id": "u1", "email": "old@example.com", "version": 3}
+	srv.rows["Role"]["r1"] = map[string]any{"id": "r1"}
+	srv.failOn = "delete Role r1"
+	c := newTestClient(t, srv.handle)
+	c.versionFields = map[string]string{"User": "version"}
+	ctx := context.Background()
+
+	if _, err := c.UnitOfWork().
+		Update("User", "u1", map[string]any{"email": "new@example.com"}).
+		Delete("Role", "r1").
+		Commit(ctx); err == nil {
+		t.Fatalf("expected failure on role delete")
+	}
+
+	restored := srv.rows["User"]["u1"]
+	if restored["email"] != "old@example.com" || fmt.Sprint(restored["version"]) != "5" {
+		t.Fatalf("expected snapshot restored past the updated version, got %+v", restored)
+	}
+	// A writer that read the row before the unit of work must not overwrite the rollback.
+	if _, err := c.Save(ctx, "User", map[string]any{"id": "u1", "email": "stale@example.com", "version": 3}, nil); !contract.IsConflict(err) {
+		t.Fatalf("expected stale save to conflict, got %v", err)
+	}
+}
+
+func TestUnitOfWorkValidatesBeforeWriting(t *testing.T) {
+	srv := newUOWServer(t)
+	c := newTestClient(t, srv.handle)
+
+	cases := map[string]contract.UnitOfWork{
+		"not found":   c.UnitOfWork().Save("User", map[string]any{"id": "u1"}).Save("Ghost", map[string]any{}),
+		"id is":       c.UnitOfWork().Save("User", map[string]any{"id": "u1"}).Delete("User", ""),
+		"updates are": c.UnitOfWork().Update("User", "u1", nil),
+	}
+	for want, uow := range cases {
+		report, err := uow.Commit(context.Background())
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q error, got %v", want, err)
+		}
+		if len(report.WithStatus(contract.UnitOfWorkPending)) != len(report.Steps) {
+			t.Fatalf("expected all steps pending, got %+v", report.Steps)
+		}
+	}
+	if len(srv.writes) != 0 {
+		t.Fatalf("validation failures must not write: %v", srv.writes)
+	}
+}
+
+func TestOrderTablesFollowsTransitiveDependencies(t *testing.T) {
+	schema := contract.Schema{Tables: []contract.Table{
+		{Name: "Role", Fields: []contract.Field{{Name: "id", Primary: true}}, Resolvers: []contract.Resolver{
+			{Name: "permissions", Resolver: `db.from("Permission").where(inOp("id", db.from("RolePermission").where(eq("roleId", this.id)).list().values('permissionId'))).list()`},
+		}},
+		{Name: "RolePermission", Fields: []contract.Field{{Name: "id", Primary: true}}, Resolvers: []contract.Resolver{
+			{Name: "permission", Resolver: `db.from("Permission").where(eq("id", this.permissionId)).firstOrNull()`},
+		}},
+		{Name: "Permission", Fields: []contract.Field{{Name: "id", Primary: true}}},
+		{Name: "A", Resolvers: []contract.Resolver{{Name: "b", Resolver: `db.from("B").where(eq("id", this.bId))`}}},
+		{Name: "B", Resolvers: []contract.Resolver{{Name: "a", Resolver: `db.from("A").where(eq("id", this.aId))`}}},
+	}}
+
+	got := orderTables(schema, []string{"RolePermission", "A", "Permission", "B", "Role"})
+	want := []string{"A", "Permission", "B", "Role", "RolePermission"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected order: got %v want %v", got, want)
+	}
+}
@@ -0,0 +1,269 @@
+package impl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+// versionFieldMetaKey is the Table.Meta key that declares a table's version field.
+const versionFieldMetaKey = "versionField"
+
+// versionField reports the optimistic-locking field for a table, if locking is enabled for it.
+// Explicit Config.VersionFields entries win; schema Meta is only consulted when
+// Config.OptimisticLocking is set, and the lookup is cached per table.
+func (c *client) versionField(ctx context.Context, table string) (string, bool, error) {
+	if field, ok := c.versionFields[table]; ok && field != "" {
+		return field, true, nil
+	}
+	if !c.schemaVersioning {
+		return "", false, nil
+	}
+	if cached, ok := c.versionFieldCache.Load(table); ok {
+		field := cached.(string)
+		return field, field != "", nil
+	}
+
+	schema, err := c.GetSchema(ctx, []string{table})
+	if err != nil {
+		return "", false, err
+	}
+	var field string
+	if t, ok := schema.Table(table); ok {
+		field, _ = t.Meta[versionFieldMetaKey].(string)
+	}
+	c.versionFieldCache.Store(table, field)
+	return field, field != "", nil
+}
+
+// saveVersioned performs a compare-and-swap save keyed on the primary key and expected version.
+//
+// An entity without a version (or version 0) is treated as a create, and creates are best-effort:
+// the API has no conditional create, so the existence check, the write and a read-back are separate
+// requests. The create is rejected with a conflict if a record with the same primary key already
+// exists or if the read-back shows another writer's record, otherwise it is written with version 1.
+// A second creator whose write lands after the read-back still overwrites the record unnoticed.
+//
+// Any other version issues an UpdateQuery conditioned on both the primary key and the expected
+// version, writing the table's schema fields and the incremented version. Relationships cannot be
+// cascaded in that write, so they are rejected rather than saved by a second, unconditional request.
+func (c *client) saveVersioned(ctx context.Context, table, versionField string, entity any, relationships []string) (map[string]any, error) {
+	t, err := c.tableDef(ctx, table)
+	if err != nil {
+		return nil, err
+	}
+	pk, err := c.primaryKey(ctx, table)
+	if err != nil {
+		return nil, err
+	}
+	record, err := entityToMap(entity)
+	if err != nil {
+		return nil, err
+	}
+	id, ok := record[pk]
+	if !ok || id == nil || fmt.Sprint(id) == "" {
+		return nil, fmt.Errorf("optimistic save on %s requires a %s value", table, pk)
+	}
+
+	expected, err := versionNumber(record[versionField])
+	if err != nil {
+		return nil, fmt.Errorf("field %s on %s: %w", versionField, table, err)
+	}
+
+	if expected == 0 {
+		current, exists, err := c.currentVersion(ctx, table, pk, versionField, id)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, conflictError(table, id, expected, current)
+		}
+		record[versionField] = int64(1)
+		saved, err := c.putEntity(ctx, table, record, relationships)
+		if err != nil {
+			return nil, err
+		}
+		stored, exists, err := c.fetchRecord(ctx, table, pk, id)
+		if err != nil {
+			return nil, err
+		}
+		if !exists || !sameWrite(record, saved, stored) {
+			return nil, conflictError(table, id, expected, stored[versionField])
+		}
+		return saved, nil
+	}
+
+	if len(relationships) > 0 {
+		return nil, fmt.Errorf("optimistic save on %s cannot cascade relationships into an existing record; save the related records separately", table)
+	}
+	updates := make(map[string]any, len(t.Fields))
+	for _, f := range t.Fields {
+		if v, ok := record[f.Name]; ok && f.Name != pk {
+			updates[f.Name] = v
+		}
+	}
+	if err := c.compareAndSwap(ctx, table, pk, versionField, id, expected, updates); err != nil {
+		return nil, err
+	}
+	record[versionField] = expected + 1
+	return record, nil
+}
+
+// updateByKey updates the record whose primary key is id. On a table with optimistic locking the
+// update goes through updateVersioned, so partial updates advance the version like Save does.
+func (c *client) updateByKey(ctx context.Context, table, pk string, id any, updates map[string]any) (int, error) {
+	field, versioned, err := c.versionField(ctx, table)
+	if err != nil {
+		return 0, err
+	}
+	if versioned {
+		return c.updateVersioned(ctx, table, pk, field, id, updates)
+	}
+	return c.From(table).Where(contract.Eq(pk, id)).SetUpdates(updates).Update(ctx)
+}
+
+// updateVersioned reads a record's stored version and applies updates together with the next
+// version as a compare-and-swap on it. Zero rows means the record does not exist; a writer that
+// changed the record between the read and the write causes a conflict. A version in updates is
+// replaced by the next version.
+func (c *client) updateVersioned(ctx context.Context, table, pk, versionField string, id any, updates map[string]any) (int, error) {
+	current, exists, err := c.currentVersion(ctx, table, pk, versionField, id)
+	if err != nil || !exists {
+		return 0, err
+	}
+	expected, err := versionNumber(current)
+	if err != nil {
+		return 0, fmt.Errorf("field %s on %s: %w", versionField, table, err)
+	}
+	next := make(map[string]any, len(updates)+1)
+	for k, v := range updates {
+		next[k] = v
+	}
+	if err := c.compareAndSwap(ctx, table, pk, versionField, id, expected, next); err != nil {
+		return 0, err
+	}
+	return 1, nil
+}
+
+// compareAndSwap writes updates plus the incremented version to the record whose primary key is
+// id, conditioned on the stored version still being expected. When the update matches no rows the
+// current server version is fetched and returned in a conflict error.
+func (c *client) compareAndSwap(ctx context.Context, table, pk, versionField string, id any, expected int64, updates map[string]any) error {
+	updates[versionField] = expected + 1
+	updated, err := c.From(table).
+		Where(contract.Eq(pk, id)).
+		And(contract.Eq(versionField, expected)).
+		SetUpdates(updates).
+		Update(ctx)
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		current, _, err := c.currentVersion(ctx, table, pk, versionField, id)
+		if err != nil {
+			return err
+		}
+		return conflictError(table, id, expected, current)
+	}
+	return nil
+}
+
+// currentVersion fetches the stored version for a record, reporting whether the record exists.
+func (c *client) currentVersion(ctx context.Context, table, pk, versionField string, id any) (any, bool, error) {
+	record, exists, err := c.fetchRecord(ctx, table, pk, id)
+	if err != nil || !exists {
+		return nil, exists, err
+	}
+	return record[versionField], true, nil
+}
+
+// fetchRecord loads a single record by primary key, reporting whether it exists.
+func (c *client) fetchRecord(ctx context.Context, table, pk string, id any) (map[string]any, bool, error) {
+	rows, err := c.freshQuery(table).Where(contract.Eq(pk, id)).Limit(1).List(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(rows) == 0 {
+		return nil, false, nil
+	}
+	return rows[0], true, nil
+}
+
+// sameWrite reports whether stored still holds the fields of record as the server saved them,
+// i.e. no other writer replaced the record after it was written.
+func sameWrite(record, saved, stored map[string]any) bool {
+	for k := range record {
+		want, ok := saved[k]
+		if !ok {
+			continue
+		}
+		a, errA := json.Marshal(want)
+		b, errB := json.Marshal(stored[k])
+		if errA != nil || errB != nil || !bytes.Equal(a, b) {
+			return false
+		}
+	}
+	return true
+}
+
+func conflictError(table string, id any, expected int64, current any) *contract.Error {
+	return contract.NewError(contract.ErrCodeConflict,
+		fmt.Sprintf("version conflict saving %s %v", table, id),
+		map[string]any{
+			"status":          http.StatusConflict,
+			"table":           table,
+			"id":              id,
+			"expectedVersion": expected,
+			"currentVersion":  current,
+		})
+}
+
+func entityToMap(entity any) (map[string]any, error) {
+	if m, ok := entity.(map[string]any); ok {
+		out := make(map[string]any, len(m))
+		for k, v := range m {
+			out[k] = v
+		}
+		return out, nil
+	}
+	raw, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var out map[string]any
+	if err := dec.Decode(&out); err != nil {
+		return nil, fmt.Errorf("entity must encode to a JSON object: %w", err)
+	}
+	return out, nil
+}
+
+func versionNumber(v any) (int64, error) {
+	switch n := v.(type) {
+	case nil:
+		return 0, nil
+	case int:
+		return int64(n), nil
+	case int32:
+		return int64(n), nil
+	case int64:
+		return n, nil
+	case float64:
+		return int64(n), nil
+	case json.Number:
+		return n.Int64()
+	case string:
+		if n == "" {
+			return 0, nil
+		}
+		return strconv.ParseInt(n, 10, 64)
+	default:
+		return 0, fmt.Errorf("unsupported version type %T", v)
+	}
+}
@@ -0,0 +1,300 @@
+package impl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+const versionedSchemaResponse = `{"tables":[{"name":"User","fields":[{"name":"id","type":"String","primaryKey":true},{"name":"email","type":"String"},{"name":"version","type":"Long"}],"meta":{"versionField":"version"}}]}`
+
+type versionServer struct {
+	t        *testing.T
+	version  float64
+	exists   bool
+	updates  []map[string]any
+	puts     []map[string]any
+	putPaths []string
+	// row is the stored record once one was written; afterPut lets a test simulate another
+	// writer landing right after a save.
+	row       map[string]any
+	afterPut  func(v *versionServer)
+	afterRead func(v *versionServer)
+}
+
+func (v *versionServer) handle(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/database/db_test/schema":
+		_, _ = w.Write([]byte(versionedSchemaResponse))
+	case r.URL.Path == "/data/db_test/query/update/User":
+		var payload map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		v.updates = append(v.updates, payload)
+		cond := payload["conditions"].(map[string]any)
+		if nested, ok := cond["conditions"].([]any); ok {
+			expected := nested[1].(map[string]any)["criteria"].(map[string]any)["value"].(float64)
+			if expected != v.version {
+				_, _ = w.Write([]byte(`0`))
+				return
+			}
+		}
+		if !v.exists {
+			_, _ = w.Write([]byte(`0`))
+			return
+		}
+		if next, ok := payload["updates"].(map[string]any)["version"].(float64); ok {
+			v.version = next
+		}
+		if v.row != nil {
+			for k, val := range payload["updates"].(map[string]any) {
+				v.row[k] = val
+			}
+		}
+		_, _ = w.Write([]byte(`1`))
+	case r.URL.Path == "/data/db_test/query/User":
+		if v.afterRead != nil {
+			defer v.afterRead(v)
+		}
+		if !v.exists {
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+		if v.row != nil {
+			_ = json.NewEncoder(w).Encode([]map[string]any{v.row})
+			return
+		}
+		_ = json.NewEncoder(w).Encode([]map[string]any{{"id": "u1", "version": v.version}})
+	case r.Method == http.MethodPut && r.URL.Path == "/data/db_test/User":
+		var payload map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		v.puts = append(v.puts, payload)
+		v.putPaths = append(v.putPaths, r.URL.RawQuery)
+		v.exists = true
+		v.version = payload["version"].(float64)
+		v.row = payload
+		_ = json.NewEncoder(w).Encode(payload)
+		if v.afterPut != nil {
+			v.afterPut(v)
+		}
+	default:
+		v.t.Fatalf("unexpected request %s %s", r.Method, r.URL.String())
+	}
+}
+
+func newVersionedClient(t *testing.T, srv *versionServer) *client {
+	c := newTestClient(t, srv.handle)
+	c.schemaVersioning = true
+	return c
+}
+
+type versionedUser struct {
+	ID      string `json:"id"`
+	Email   string `json:"email"`
+	Version int64  `json:"version"`
+}
+
+func TestVersionedSaveCreatesAndIncrements(t *testing.T) {
+	srv := &versionServer{t: t}
+	c := newVersionedClient(t, srv)
+	ctx := context.Background()
+
+	created, err := c.Save(ctx, "User", versionedUser{ID: "u1", Email: "a@example.com"}, nil)
+	if err != nil {
+		t.Fatalf("create err: %v", err)
+	}
+	if created["version"] != float64(1) || len(srv.puts) != 1 {
+		t.Fatalf("expected create with version 1, got %+v (puts=%d)", created, len(srv.puts))
+	}
+
+	saved, err := c.Save(ctx, "User", versionedUser{ID: "u1", Email: "b@example.com", Version: 1}, nil)
+	if err != nil {
+		t.Fatalf("update err: %v", err)
+	}
+	if saved["version"] != int64(2) || srv.version != 2 {
+		t.Fatalf("expected version 2, got %+v (server=%v)", saved["version"], srv.version)
+	}
+
+	payload := srv.updates[0]
+	if payload["type"] != "UpdateQuery" {
+		t.Fatalf("expected conditional UpdateQuery, got %+v", payload)
+	}
+	cond := payload["conditions"].(map[string]any)
+	if cond["operator"] != "AND" {
+		t.Fatalf("expected AND of pk and version, got %+v", cond)
+	}
+	pkCrit := cond["conditions"].([]any)[0].(map[string]any)["criteria"].(map[string]any)
+	if pkCrit["field"] != "id" || pkCrit["value"] != "u1" {
+		t.Fatalf("unexpected pk criteria: %+v", pkCrit)
+	}
+	if _, ok := payload["updates"].(map[string]any)["id"]; ok {
+		t.Fatalf("primary key should not be part of updates: %+v", payload["updates"])
+	}
+}
+
+func TestVersionedSaveConflictCarriesCurrentVersion(t *testing.T) {
+	srv := &versionServer{t: t, exists: true, version: 5}
+	c := newVersionedClient(t, srv)
+
+	_, err := c.Save(context.Background(), "User", map[string]any{"id": "u1", "email": "x", "version": 3}, nil)
+	if !contract.IsConflict(err) {
+		t.Fatalf("expected conflict, got %v", err)
+	}
+	var cerr *contract.Error
+	if !errors.As(err, &cerr) {
+		t.Fatalf("expected contract error, got %T", err)
+	}
+	if cerr.Meta["currentVersion"] != float64(5) || cerr.Meta["expectedVersion"] != int64(3) || cerr.Meta["status"] != http.StatusConflict {
+		t.Fatalf("unexpected conflict meta: %+v", cerr.Meta)
+	}
+
+	// Creating a record that already exists is also a conflict.
+	if _, err := c.Save(context.Background(), "User", map[string]any{"id": "u1"}, nil); !contract.IsConflict(err) {
+		t.Fatalf("expected create conflict, got %v", err)
+	}
+	if len(srv.puts) != 0 {
+		t.Fatalf("conflicting create must not write")
+	}
+}
+
+func TestVersionedCreateDetectsARacingCreator(t *testing.T) {
+	srv := &versionServer{t: t}
+	srv.afterPut = func(v *versionServer) {
+		// Another creator passed its existence check too and overwrote this write.
+		v.afterPut = nil
+		v.row = map[string]any{"id": "u1", "email": "other@example.com", "version": float64(1)}
+	}
+	c := newVersionedClient(t, srv)
+
+	_, err := c.Save(context.Background(), "User", versionedUser{ID: "u1", Email: "a@example.com"}, nil)
+	var cerr *contract.Error
+	if !errors.As(err, &cerr) || !contract.IsConflict(err) {
+		t.Fatalf("expected conflict, got %v", err)
+	}
+	if cerr.Meta["currentVersion"] != float64(1) || cerr.Meta["expectedVersion"] != int64(0) {
+		t.Fatalf("unexpected conflict meta: %+v", cerr.Meta)
+	}
+}
+
+func TestVersionedUpdateRejectsRelationships(t *testing.T) {
+	srv := &versionServer{t: t, exists: true, version: 1}
+	c := newVersionedClient(t, srv)
+
+	_, err := c.Save(context.Background(), "User", map[string]any{"id": "u1", "version": 1}, []string{"userRoles:UserRole(userId,id)"})
+	if err == nil || !strings.Contains(err.Error(), "cannot cascade") {
+		t.Fatalf("expected relationships to be rejected, got %v", err)
+	}
+	if len(srv.updates) != 0 || len(srv.puts) != 0 {
+		t.Fatalf("rejected save must not write, got updates=%d puts=%d", len(srv.updates), len(srv.puts))
+	}
+}
+
+func TestVersionedUpdateWritesOnlySchemaFields(t *testing.T) {
+	srv := &versionServer{t: t, exists: true, version: 1}
+	c := newVersionedClient(t, srv)
+
+	entity := map[string]any{"id": "u1", "email": "b@example.com", "version": 1, "profile": map[string]any{"bio": "x"}}
+	if _, err := c.Save(context.Background(), "User", entity, nil); err != nil {
+		t.Fatalf("save err: %v", err)
+	}
+	updates := srv.updates[0]["updates"].(map[string]any)
+	if len(updates) != 2 || updates["email"] != "b@example.com" || updates["version"] != float64(2) {
+		t.Fatalf("expected only email and version to be written, got %+v", updates)
+	}
+}
+
+func TestPatchAdvancesVersion(t *testing.T) {
+	srv := &versionServer{t: t, exists: true, version: 1}
+	c := newVersionedClient(t, srv)
+	ctx := context.Background()
+
+	res, err := c.Patch(ctx, "User", "u1", map[string]any{"email": "p@example.com"})
+	if err != nil || res.Status != contract.PatchUpdated {
+		t.Fatalf("patch: %+v %v", res, err)
+	}
+	if srv.version != 2 {
+		t.Fatalf("expected patch to advance the version, server has %v", srv.version)
+	}
+
+	// A writer still holding the version read before the patch must conflict.
+	if _, err := c.Save(ctx, "User", versionedUser{ID: "u1", Email: "stale@example.com", Version: 1}, nil); !contract.IsConflict(err) {
+		t.Fatalf("expected stale save to conflict, got %v", err)
+	}
+
+	// A writer landing between the version read and the write fails the patch with a conflict.
+	srv.afterRead = func(v *versionServer) {
+		v.afterRead = nil
+		v.version = 7
+	}
+	res, _ = c.Patch(ctx, "User", "u1", map[string]any{"email": "x"})
+	if res.Status != contract.PatchFailed || !contract.IsConflict(res.Err) {
+		t.Fatalf("expected conflicting patch, got %+v", res)
+	}
+}
+
+// TestQueryUpdateBypassesVersioning pins down that bulk UpdateQuery writes do not take part in
+// optimistic locking: they leave the version untouched, so a later Save holding it still wins.
+func TestQueryUpdateBypassesVersioning(t *testing.T) {
+	srv := &versionServer{t: t, exists: true, version: 1}
+	c := newVersionedClient(t, srv)
+	ctx := context.Background()
+
+	if _, err := c.From("User").Where(contract.Eq("id", "u1")).SetUpdates(map[string]any{"email": "q@example.com"}).Update(ctx); err != nil {
+		t.Fatalf("update err: %v", err)
+	}
+	if srv.version != 1 {
+		t.Fatalf("query update should not advance the version, server has %v", srv.version)
+	}
+	if _, err := c.Save(ctx, "User", versionedUser{ID: "u1", Email: "later@example.com", Version: 1}, nil); err != nil {
+		t.Fatalf("expected save over a query update to succeed, got %v", err)
+	}
+}
+
+func TestSaveWithoutVersioningSkipsSchema(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+	if _, err := c.Save(context.Background(), "User", map[string]any{"id": "u1"}, nil); err != nil {
+		t.Fatalf("save err: %v", err)
+	}
+}
+
+func TestVersionFieldsOptionOverridesSchema(t *testing.T) {
+	schemaCalls := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			schemaCalls++
+			_, _ = w.Write([]byte(versionedSchemaResponse))
+			return
+		}
+		_, _ = w.Write([]byte(`1`))
+	})
+	c.versionFields = map[string]string{"User": "rev"}
+
+	field, ok, err := c.versionField(context.Background(), "User")
+	if err != nil || !ok || field != "rev" || schemaCalls != 0 {
+		t.Fatalf("expected explicit field without schema lookup, got %q %v %v (calls=%d)", field, ok, err, schemaCalls)
+	}
+
+	c.versionFields = nil
+	c.schemaVersioning = true
+	for i := 0; i < 2; i++ {
+		field, ok, err = c.versionField(context.Background(), "User")
+		if err != nil || !ok || field != "version" {
+			t.Fatalf("expected schema version field, got %q %v %v", field, ok, err)
+		}
+	}
+	if schemaCalls != 1 {
+		t.Fatalf("expected cached schema lookup, got %d calls", schemaCalls)
+	}
+
+	if _, err := versionNumber(struct{}{}); err == nil {
+		t.Fatalf("expected unsupported version type error")
+	}
+}
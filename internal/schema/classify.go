@@ -0,0 +1,237 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+// Severity grades how a schema change affects existing clients and data.
+type Severity string
+
+const (
+	// SeveritySafe changes are additive; existing clients and rows keep working.
+	SeveritySafe Severity = "safe"
+	// SeverityRisky changes keep existing rows valid but may change what clients observe.
+	SeverityRisky Severity = "risky"
+	// SeverityBreaking changes drop data or reject reads and writes that worked before.
+	SeverityBreaking Severity = "breaking"
+)
+
+func (s Severity) rank() int {
+	switch s {
+	case SeverityBreaking:
+		return 2
+	case SeverityRisky:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// AtLeast reports whether s is as severe as other.
+func (s Severity) AtLeast(other Severity) bool { return s.rank() >= other.rank() }
+
+// ParseSeverity validates a severity name.
+func ParseSeverity(name string) (Severity, error) {
+	switch s := Severity(strings.ToLower(name)); s {
+	case SeveritySafe, SeverityRisky, SeverityBreaking:
+		return s, nil
+	}
+	return "", fmt.Errorf("unknown severity %q (want safe, risky or breaking)", name)
+}
+
+// Change kinds, one per kind of SchemaDiff entry.
+const (
+	ChangeTableAdded       = "table_added"
+	ChangeTableRemoved     = "table_removed"
+	ChangeFieldAdded       = "field_added"
+	ChangeFieldRemoved     = "field_removed"
+	ChangeFieldModified    = "field_modified"
+	ChangeResolverAdded    = "resolver_added"
+	ChangeResolverRemoved  = "resolver_removed"
+	ChangeResolverModified = "resolver_modified"
+	ChangeIndexAdded       = "index_added"
+	ChangeIndexRemoved     = "index_removed"
+	ChangeIndexModified    = "index_modified"
+	ChangeTriggerAdded     = "trigger_added"
+	ChangeTriggerRemoved   = "trigger_removed"
+	ChangeTriggerModified  = "trigger_modified"
+	ChangePartition        = "partition_changed"
+	ChangeMeta             = "meta_changed"
+)
+
+// Change classifies a single SchemaDiff entry.
+type Change struct {
+	Kind     string   `json:"kind"`
+	Table    string   `json:"table"`
+	Name     string   `json:"name,omitempty"`
+	Severity Severity `json:"severity"`
+	Reason   string   `json:"reason"`
+}
+
+// Severity returns the most severe classification in the diff, or safe when it is empty.
+func (d SchemaDiff) Severity() Severity {
+	max := SeveritySafe
+	for _, c := range d.Changes {
+		if c.Severity.rank() > max.rank() {
+			max = c.Severity
+		}
+	}
+	return max
+}
+
+// classifyDiff grades every entry in the diff. A removed table whose fields match an added
+// table is reported as a rename on both sides.
+func classifyDiff(diff SchemaDiff) []Change {
+	var changes []Change
+
+	renamedTo := map[string]string{}
+	renamedFrom := map[string]string{}
+	for _, removed := range diff.RemovedTables {
+		for _, added := range diff.AddedTables {
+			if _, taken := renamedFrom[added.Name]; taken || len(removed.Fields) == 0 || tableShape(removed) != tableShape(added) {
+				continue
+			}
+			renamedTo[removed.Name] = added.Name
+			renamedFrom[added.Name] = removed.Name
+			break
+		}
+	}
+
+	for _, t := range diff.AddedTables {
+		c := Change{Kind: ChangeTableAdded, Table: t.Name, Severity: SeveritySafe, Reason: "new table"}
+		if from, ok := renamedFrom[t.Name]; ok {
+			c.Severity, c.Reason = SeverityBreaking, fmt.Sprintf("appears to rename %s; clients using the old name break", from)
+		}
+		changes = append(changes, c)
+	}
+	for _, t := range diff.RemovedTables {
+		c := Change{Kind: ChangeTableRemoved, Table: t.Name, Severity: SeverityBreaking, Reason: "table and its data are dropped"}
+		if to, ok := renamedTo[t.Name]; ok {
+			c.Reason = fmt.Sprintf("appears to be renamed to %s; clients using the old name break", to)
+		}
+		changes = append(changes, c)
+	}
+
+	for _, td := range diff.TableDiffs {
+		for _, f := range td.AddedFields {
+			changes = append(changes, classifyAddedField(td.Name, f))
+		}
+		for _, f := range td.RemovedFields {
+			changes = append(changes, Change{Kind: ChangeFieldRemoved, Table: td.Name, Name: f.Name, Severity: SeverityBreaking,
+				Reason: "field and its values are dropped; clients reading or writing it fail"})
+		}
+		for _, fd := range td.ModifiedFields {
+			changes = append(changes, classifyModifiedField(td.Name, fd))
+		}
+		for _, r := range td.AddedResolvers {
+			changes = append(changes, Change{Kind: ChangeResolverAdded, Table: td.Name, Name: r, Severity: SeveritySafe, Reason: "new resolver"})
+		}
+		for _, r := range td.RemovedResolvers {
+			changes = append(changes, Change{Kind: ChangeResolverRemoved, Table: td.Name, Name: r, Severity: SeverityBreaking,
+				Reason: "queries resolving it fail"})
+		}
+		for _, r := range td.ModifiedResolvers {
+			changes = append(changes, Change{Kind: ChangeResolverModified, Table: td.Name, Name: r.Name, Severity: SeverityRisky,
+				Reason: "resolver definition changed; resolved values may differ"})
+		}
+		for _, idx := range td.AddedIndexes {
+			changes = append(changes, Change{Kind: ChangeIndexAdded, Table: td.Name, Name: idx.Name, Severity: SeveritySafe, Reason: "new index"})
+		}
+		for _, idx := range td.RemovedIndexes {
+			changes = append(changes, Change{Kind: ChangeIndexRemoved, Table: td.Name, Name: idx.Name, Severity: SeverityRisky,
+				Reason: "queries and searches relying on it slow down or stop matching"})
+		}
+		for _, idx := range td.ModifiedIndexes {
+			changes = append(changes, Change{Kind: ChangeIndexModified, Table: td.Name, Name: idx.Name, Severity: SeverityRisky,
+				Reason: "index is rebuilt; search results may differ"})
+		}
+		for _, trg := range td.AddedTriggers {
+			changes = append(changes, Change{Kind: ChangeTriggerAdded, Table: td.Name, Name: trg.Name, Severity: SeverityRisky,
+				Reason: "new trigger runs on existing write paths"})
+		}
+		for _, trg := range td.RemovedTriggers {
+			changes = append(changes, Change{Kind: ChangeTriggerRemoved, Table: td.Name, Name: trg.Name, Severity: SeverityRisky,
+				Reason: "writes no longer run the trigger"})
+		}
+		for _, trg := range td.ModifiedTriggers {
+			changes = append(changes, Change{Kind: ChangeTriggerModified, Table: td.Name, Name: trg.Name, Severity: SeverityRisky,
+				Reason: "trigger behavior changed"})
+		}
+		if td.Partition != nil {
+			changes = append(changes, Change{Kind: ChangePartition, Table: td.Name, Severity: SeverityBreaking,
+				Reason: fmt.Sprintf("partition changes from %q to %q; rows are redistributed and partition-scoped queries change", td.Partition.From, td.Partition.To)})
+		}
+		if td.Meta != nil {
+			changes = append(changes, Change{Kind: ChangeMeta, Table: td.Name, Severity: SeveritySafe, Reason: "table metadata changed"})
+		}
+	}
+
+	return changes
+}
+
+func classifyAddedField(table string, f contract.Field) Change {
+	c := Change{Kind: ChangeFieldAdded, Table: table, Name: f.Name}
+	switch {
+	case f.Nullable:
+		c.Severity, c.Reason = SeveritySafe, "new nullable field"
+	case f.Default != nil:
+		c.Severity, c.Reason = SeverityRisky, "new required field; existing rows need a backfill to the default"
+	default:
+		c.Severity, c.Reason = SeverityBreaking, "new required field without a default; existing rows and older writers have no value"
+	}
+	return c
+}
+
+func classifyModifiedField(table string, fd FieldDiff) Change {
+	c := Change{Kind: ChangeFieldModified, Table: table, Name: fd.Name, Severity: SeveritySafe, Reason: "field attributes changed"}
+	var reasons []string
+	note := func(sev Severity, reason string) {
+		if sev.rank() > c.Severity.rank() {
+			c.Severity = sev
+		}
+		reasons = append(reasons, reason)
+	}
+
+	from, to := fd.From, fd.To
+	if from.Type != to.Type {
+		note(SeverityBreaking, fmt.Sprintf("type changes from %s to %s; stored values and client types no longer match", from.Type, to.Type))
+	}
+	if from.Primary != to.Primary {
+		note(SeverityBreaking, "primary key changes; record identity and lookups by id change")
+	}
+	switch {
+	case from.Nullable && !to.Nullable:
+		note(SeverityBreaking, "becomes non-null; existing nulls and writes that omit it are rejected")
+	case !from.Nullable && to.Nullable:
+		note(SeverityRisky, "becomes nullable; readers may now see nulls")
+	}
+	switch {
+	case !from.Unique && to.Unique:
+		note(SeverityBreaking, "becomes unique; existing duplicates and duplicate writes are rejected")
+	case from.Unique && !to.Unique:
+		note(SeverityRisky, "no longer unique; readers may now see duplicates")
+	}
+	if to.MaxSize > 0 && (from.MaxSize == 0 || to.MaxSize < from.MaxSize) {
+		note(SeverityBreaking, fmt.Sprintf("maxSize shrinks to %d; longer values are rejected", to.MaxSize))
+	}
+	if from.Generator != to.Generator {
+		note(SeverityRisky, fmt.Sprintf("generator changes from %q to %q; new ids take a different form", from.Generator, to.Generator))
+	}
+
+	if len(reasons) > 0 {
+		c.Reason = strings.Join(reasons, "; ")
+	}
+	return c
+}
+
+// tableShape identifies a table by its fields, ignoring its name, for rename detection.
+func tableShape(t contract.Table) string {
+	parts := make([]string, 0, len(t.Fields))
+	for _, f := range t.Fields {
+		parts = append(parts, fmt.Sprintf("%s:%s:%t", f.Name, f.Type, f.Nullable))
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,83 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+func TestDiffSchemasClassifiesChanges(t *testing.T) {
+	base := contract.Schema{Tables: []contract.Table{
+		{Name: "User", Fields: []contract.Field{
+			{Name: "id", Type: "String"},
+			{Name: "age", Type: "Int"},
+			{Name: "bio", Type: "String", Nullable: true},
+			{Name: "nick", Type: "String"},
+			{Name: "old", Type: "String"},
+		}, Resolvers: []contract.Resolver{{Name: "roles", Resolver: "a"}, {Name: "legacy", Resolver: "b"}}},
+		{Name: "Account", Fields: []contract.Field{{Name: "id", Type: "String"}, {Name: "owner", Type: "String"}}},
+	}}
+	target := contract.Schema{Tables: []contract.Table{
+		{Name: "User", Fields: []contract.Field{
+			{Name: "id", Type: "String"},
+			{Name: "age", Type: "Float"},
+			{Name: "bio", Type: "String"},
+			{Name: "nick", Type: "String", Nullable: true},
+			{Name: "note", Type: "String", Nullable: true},
+			{Name: "plan", Type: "String", Default: "free"},
+			{Name: "tier", Type: "Int"},
+		}, Resolvers: []contract.Resolver{{Name: "roles", Resolver: "a2"}, {Name: "profile", Resolver: "c"}}},
+		{Name: "Workspace", Fields: []contract.Field{{Name: "id", Type: "String"}, {Name: "owner", Type: "String"}}},
+		{Name: "Audit", Fields: []contract.Field{{Name: "id", Type: "String"}}},
+	}}
+
+	diff := DiffSchemas(base, target)
+	got := map[string]Severity{}
+	for _, c := range diff.Changes {
+		if c.Reason == "" {
+			t.Fatalf("expected a reason for %+v", c)
+		}
+		got[c.Kind+" "+c.Table+"."+c.Name] = c.Severity
+	}
+	want := map[string]Severity{
+		"table_added Audit.":           SeveritySafe,
+		"table_added Workspace.":       SeverityBreaking,
+		"table_removed Account.":       SeverityBreaking,
+		"field_added User.note":        SeveritySafe,
+		"field_added User.plan":        SeverityRisky,
+		"field_added User.tier":        SeverityBreaking,
+		"field_removed User.old":       SeverityBreaking,
+		"field_modified User.age":      SeverityBreaking,
+		"field_modified User.bio":      SeverityBreaking,
+		"field_modified User.nick":     SeverityRisky,
+		"resolver_added User.profile":  SeveritySafe,
+		"resolver_removed User.legacy": SeverityBreaking,
+		"resolver_modified User.roles": SeverityRisky,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d changes, got %v", len(want), got)
+	}
+	for key, sev := range want {
+		if got[key] != sev {
+			t.Fatalf("%s: expected %s, got %q (all: %v)", key, sev, got[key], got)
+		}
+	}
+	if diff.Severity() != SeverityBreaking {
+		t.Fatalf("expected breaking overall, got %s", diff.Severity())
+	}
+	if DiffSchemas(base, base).Severity() != SeveritySafe {
+		t.Fatalf("expected identical schemas to be safe")
+	}
+}
+
+func TestParseSeverity(t *testing.T) {
+	if s, err := ParseSeverity("Risky"); err != nil || s != SeverityRisky {
+		t.Fatalf("unexpected parse: %v %v", s, err)
+	}
+	if _, err := ParseSeverity("fatal"); err == nil {
+		t.Fatalf("expected error for unknown severity")
+	}
+	if !SeverityBreaking.AtLeast(SeverityRisky) || SeveritySafe.AtLeast(SeverityRisky) {
+		t.Fatalf("unexpected severity ordering")
+	}
+}
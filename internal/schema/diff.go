@@ -1,6 +1,7 @@
 package schema
 
 import (
+	"reflect"
 	"sort"
 
 	"github.com/OnyxDevTools/onyx-database-go/contract"
@@ -13,29 +14,66 @@ type FieldDiff struct {
 	To   contract.Field `json:"to"`
 }
 
-// TableDiff captures changes within a table.
+// TableDiff captures changes within a table. Added entries exist only in the updated schema and
+// removed entries only in the base schema, for every kind of entry.
 type TableDiff struct {
-	Name             string           `json:"name"`
-	AddedFields      []contract.Field `json:"addedFields,omitempty"`
-	RemovedFields    []contract.Field `json:"removedFields,omitempty"`
-	ModifiedFields   []FieldDiff      `json:"modifiedFields,omitempty"`
-	AddedResolvers   []string         `json:"addedResolvers,omitempty"`
-	RemovedResolvers []string         `json:"removedResolvers,omitempty"`
-	ModifiedResolvers []ResolverDiff  `json:"modifiedResolvers,omitempty"`
+	Name              string             `json:"name"`
+	AddedFields       []contract.Field   `json:"addedFields,omitempty"`
+	RemovedFields     []contract.Field   `json:"removedFields,omitempty"`
+	ModifiedFields    []FieldDiff        `json:"modifiedFields,omitempty"`
+	AddedResolvers    []string           `json:"addedResolvers,omitempty"`
+	RemovedResolvers  []string           `json:"removedResolvers,omitempty"`
+	ModifiedResolvers []ResolverDiff     `json:"modifiedResolvers,omitempty"`
+	AddedIndexes      []contract.Index   `json:"addedIndexes,omitempty"`
+	RemovedIndexes    []contract.Index   `json:"removedIndexes,omitempty"`
+	ModifiedIndexes   []IndexDiff        `json:"modifiedIndexes,omitempty"`
+	AddedTriggers     []contract.Trigger `json:"addedTriggers,omitempty"`
+	RemovedTriggers   []contract.Trigger `json:"removedTriggers,omitempty"`
+	ModifiedTriggers  []TriggerDiff      `json:"modifiedTriggers,omitempty"`
+	Partition         *PartitionDiff     `json:"partition,omitempty"`
+	Meta              *MetaDiff          `json:"meta,omitempty"`
 }
 
-// SchemaDiff reports differences between schemas.
+// IndexDiff captures an index change.
+type IndexDiff struct {
+	Name string         `json:"name"`
+	From contract.Index `json:"from"`
+	To   contract.Index `json:"to"`
+}
+
+// TriggerDiff captures a trigger change.
+type TriggerDiff struct {
+	Name string           `json:"name"`
+	From contract.Trigger `json:"from"`
+	To   contract.Trigger `json:"to"`
+}
+
+// PartitionDiff captures a change to the table's partition field.
+type PartitionDiff struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// MetaDiff captures a change to the table's metadata.
+type MetaDiff struct {
+	From map[string]any `json:"from,omitempty"`
+	To   map[string]any `json:"to,omitempty"`
+}
+
+// SchemaDiff reports differences between schemas. Changes classifies every entry as safe,
+// risky or breaking.
 type SchemaDiff struct {
 	AddedTables   []contract.Table `json:"addedTables,omitempty"`
 	RemovedTables []contract.Table `json:"removedTables,omitempty"`
 	TableDiffs    []TableDiff      `json:"tableDiffs,omitempty"`
+	Changes       []Change         `json:"changes,omitempty"`
 }
 
 // ResolverDiff captures a resolver change.
 type ResolverDiff struct {
-	Name string             `json:"name"`
-	From contract.Resolver  `json:"from"`
-	To   contract.Resolver  `json:"to"`
+	Name string            `json:"name"`
+	From contract.Resolver `json:"from"`
+	To   contract.Resolver `json:"to"`
 }
 
 // DiffSchemas compares two schemas and reports structural differences.
@@ -77,6 +115,7 @@ func DiffSchemas(a, b contract.Schema) SchemaDiff {
 	sort.Slice(diff.RemovedTables, func(i, j int) bool { return diff.RemovedTables[i].Name < diff.RemovedTables[j].Name })
 	sort.Slice(diff.TableDiffs, func(i, j int) bool { return diff.TableDiffs[i].Name < diff.TableDiffs[j].Name })
 
+	diff.Changes = classifyDiff(diff)
 	return diff
 }
 
@@ -121,7 +160,7 @@ func diffTable(a, b contract.Table) *TableDiff {
 	}
 	for r := range resolverMapA {
 		if _, ok := resolverMapB[r]; !ok {
-			td.AddedResolvers = append(td.AddedResolvers, r)
+			td.RemovedResolvers = append(td.RemovedResolvers, r)
 			continue
 		}
 		if resolverChanged(resolverMapA[r], resolverMapB[r]) {
@@ -134,7 +173,7 @@ func diffTable(a, b contract.Table) *TableDiff {
 	}
 	for r := range resolverMapB {
 		if _, ok := resolverMapA[r]; !ok {
-			td.RemovedResolvers = append(td.RemovedResolvers, r)
+			td.AddedResolvers = append(td.AddedResolvers, r)
 		}
 	}
 
@@ -145,33 +184,94 @@ func diffTable(a, b contract.Table) *TableDiff {
 	sort.Strings(td.AddedResolvers)
 	sort.Strings(td.RemovedResolvers)
 
+	diffIndexes(&td, a.Indexes, b.Indexes)
+	diffTriggers(&td, a.Triggers, b.Triggers)
+
+	if a.Partition != b.Partition {
+		td.Partition = &PartitionDiff{From: a.Partition, To: b.Partition}
+	}
+	if !metaEqual(a.Meta, b.Meta) {
+		td.Meta = &MetaDiff{From: a.Meta, To: b.Meta}
+	}
+
 	if len(td.AddedFields) == 0 &&
 		len(td.RemovedFields) == 0 &&
 		len(td.ModifiedFields) == 0 &&
 		len(td.AddedResolvers) == 0 &&
 		len(td.RemovedResolvers) == 0 &&
-		len(td.ModifiedResolvers) == 0 {
+		len(td.ModifiedResolvers) == 0 &&
+		len(td.AddedIndexes) == 0 &&
+		len(td.RemovedIndexes) == 0 &&
+		len(td.ModifiedIndexes) == 0 &&
+		len(td.AddedTriggers) == 0 &&
+		len(td.RemovedTriggers) == 0 &&
+		len(td.ModifiedTriggers) == 0 &&
+		td.Partition == nil &&
+		td.Meta == nil {
 		return nil
 	}
 
 	return &td
 }
 
-func fieldChanged(a, b contract.Field) bool {
-	return a.Type != b.Type || a.Nullable != b.Nullable
+// diffIndexes compares indexes by name. Both inputs are already sorted by NormalizeSchema.
+func diffIndexes(td *TableDiff, a, b []contract.Index) {
+	mapA := map[string]contract.Index{}
+	for _, idx := range a {
+		mapA[idx.Name] = idx
+	}
+	mapB := map[string]contract.Index{}
+	for _, idx := range b {
+		mapB[idx.Name] = idx
+		from, ok := mapA[idx.Name]
+		if !ok {
+			td.AddedIndexes = append(td.AddedIndexes, idx)
+		} else if !reflect.DeepEqual(from, idx) {
+			td.ModifiedIndexes = append(td.ModifiedIndexes, IndexDiff{Name: idx.Name, From: from, To: idx})
+		}
+	}
+	for _, idx := range a {
+		if _, ok := mapB[idx.Name]; !ok {
+			td.RemovedIndexes = append(td.RemovedIndexes, idx)
+		}
+	}
 }
 
-func resolverChanged(a, b contract.Resolver) bool {
-	if a.Resolver != b.Resolver {
-		return true
+// diffTriggers compares triggers by name. Both inputs are already sorted by NormalizeSchema.
+func diffTriggers(td *TableDiff, a, b []contract.Trigger) {
+	mapA := map[string]contract.Trigger{}
+	for _, trg := range a {
+		mapA[trg.Name] = trg
 	}
-	if len(a.Meta) != len(b.Meta) {
-		return true
+	mapB := map[string]contract.Trigger{}
+	for _, trg := range b {
+		mapB[trg.Name] = trg
+		from, ok := mapA[trg.Name]
+		if !ok {
+			td.AddedTriggers = append(td.AddedTriggers, trg)
+		} else if from != trg {
+			td.ModifiedTriggers = append(td.ModifiedTriggers, TriggerDiff{Name: trg.Name, From: from, To: trg})
+		}
 	}
-	for k, v := range a.Meta {
-		if vb, ok := b.Meta[k]; !ok || vb != v {
-			return true
+	for _, trg := range a {
+		if _, ok := mapB[trg.Name]; !ok {
+			td.RemovedTriggers = append(td.RemovedTriggers, trg)
 		}
 	}
-	return false
+}
+
+// fieldChanged reports any attribute change, including key flags, generator, size and default.
+func fieldChanged(a, b contract.Field) bool {
+	return !reflect.DeepEqual(a, b)
+}
+
+func metaEqual(a, b map[string]any) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func resolverChanged(a, b contract.Resolver) bool {
+	return a.Resolver != b.Resolver || !metaEqual(a.Meta, b.Meta)
 }
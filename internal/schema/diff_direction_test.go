@@ -0,0 +1,131 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+// In every list, "added" means present only in the updated schema (b) and "removed" means
+// present only in the base schema (a).
+func TestDiffDirectionIsConsistentAcrossEntries(t *testing.T) {
+	base := contract.Schema{Tables: []contract.Table{
+		{
+			Name:      "Doc",
+			Fields:    []contract.Field{{Name: "id", Type: "String"}, {Name: "baseField", Type: "String"}},
+			Resolvers: []contract.Resolver{{Name: "baseResolver"}},
+			Indexes:   []contract.Index{{Name: "baseIndex"}},
+			Triggers:  []contract.Trigger{{Name: "baseTrigger"}},
+		},
+		{Name: "BaseTable", Fields: []contract.Field{{Name: "id", Type: "String"}}},
+	}}
+	updated := contract.Schema{Tables: []contract.Table{
+		{
+			Name:      "Doc",
+			Fields:    []contract.Field{{Name: "id", Type: "String"}, {Name: "newField", Type: "String"}},
+			Resolvers: []contract.Resolver{{Name: "newResolver"}},
+			Indexes:   []contract.Index{{Name: "newIndex"}},
+			Triggers:  []contract.Trigger{{Name: "newTrigger"}},
+		},
+		{Name: "NewTable", Fields: []contract.Field{{Name: "key", Type: "String"}}},
+	}}
+
+	diff := DiffSchemas(base, updated)
+	if len(diff.AddedTables) != 1 || diff.AddedTables[0].Name != "NewTable" {
+		t.Fatalf("added tables: %+v", diff.AddedTables)
+	}
+	if len(diff.RemovedTables) != 1 || diff.RemovedTables[0].Name != "BaseTable" {
+		t.Fatalf("removed tables: %+v", diff.RemovedTables)
+	}
+	td := diff.TableDiffs[0]
+	checks := []struct {
+		what    string
+		added   string
+		removed string
+	}{
+		{"fields", td.AddedFields[0].Name, td.RemovedFields[0].Name},
+		{"resolvers", td.AddedResolvers[0], td.RemovedResolvers[0]},
+		{"indexes", td.AddedIndexes[0].Name, td.RemovedIndexes[0].Name},
+		{"triggers", td.AddedTriggers[0].Name, td.RemovedTriggers[0].Name},
+	}
+	for _, c := range checks {
+		if c.added[:3] != "new" || c.removed[:4] != "base" {
+			t.Fatalf("%s: expected added=new*, removed=base*, got added=%s removed=%s", c.what, c.added, c.removed)
+		}
+	}
+}
+
+func TestDiffCoversTableAttributesAndKeyFlags(t *testing.T) {
+	base := contract.Schema{Tables: []contract.Table{{
+		Name: "Doc",
+		Fields: []contract.Field{
+			{Name: "id", Type: "String", Primary: true, Generator: "UUID"},
+			{Name: "slug", Type: "String", MaxSize: 128},
+			{Name: "code", Type: "String", Unique: true},
+		},
+		Indexes:   []contract.Index{{Name: "search", Type: "LUCENE", Options: map[string]any{"minScore": 0.5}}},
+		Triggers:  []contract.Trigger{{Name: "audit", Event: "PreSave", Trigger: "a"}},
+		Partition: "region",
+		Meta:      map[string]any{"owner": "docs"},
+	}}}
+	updated := contract.Schema{Tables: []contract.Table{{
+		Name: "Doc",
+		Fields: []contract.Field{
+			{Name: "id", Type: "String", Generator: "Sequence"},
+			{Name: "slug", Type: "String", MaxSize: 64, Unique: true},
+			{Name: "code", Type: "String"},
+		},
+		Indexes:   []contract.Index{{Name: "search", Type: "LUCENE", Options: map[string]any{"minScore": 0.7}}},
+		Triggers:  []contract.Trigger{{Name: "audit", Event: "PostSave", Trigger: "a"}},
+		Partition: "tenant",
+		Meta:      map[string]any{"owner": "platform"},
+	}}}
+
+	diff := DiffSchemas(base, updated)
+	if len(diff.TableDiffs) != 1 {
+		t.Fatalf("expected one table diff, got %+v", diff)
+	}
+	td := diff.TableDiffs[0]
+	if len(td.ModifiedFields) != 3 {
+		t.Fatalf("expected key, size and unique flips as modifications, got %+v", td.ModifiedFields)
+	}
+	if len(td.ModifiedIndexes) != 1 || td.ModifiedIndexes[0].To.Options["minScore"] != 0.7 {
+		t.Fatalf("expected index option change, got %+v", td.ModifiedIndexes)
+	}
+	if len(td.ModifiedTriggers) != 1 || td.ModifiedTriggers[0].From.Event != "PreSave" {
+		t.Fatalf("expected trigger event change, got %+v", td.ModifiedTriggers)
+	}
+	if td.Partition == nil || td.Partition.From != "region" || td.Partition.To != "tenant" {
+		t.Fatalf("expected partition change, got %+v", td.Partition)
+	}
+	if td.Meta == nil || td.Meta.From["owner"] != "docs" || td.Meta.To["owner"] != "platform" {
+		t.Fatalf("expected meta change, got %+v", td.Meta)
+	}
+
+	severities := map[string]Severity{}
+	for _, c := range diff.Changes {
+		severities[c.Kind+" "+c.Name] = c.Severity
+	}
+	want := map[string]Severity{
+		"field_modified id":      SeverityBreaking,
+		"field_modified slug":    SeverityBreaking,
+		"field_modified code":    SeverityRisky,
+		"index_modified search":  SeverityRisky,
+		"trigger_modified audit": SeverityRisky,
+		"partition_changed ":     SeverityBreaking,
+		"meta_changed ":          SeveritySafe,
+	}
+	for key, sev := range want {
+		if severities[key] != sev {
+			t.Fatalf("%s: expected %s, got %q (all: %v)", key, sev, severities[key], severities)
+		}
+	}
+
+	if len(DiffSchemas(base, base).TableDiffs) != 0 {
+		t.Fatalf("identical schemas must not diff")
+	}
+	emptyMeta := contract.Schema{Tables: []contract.Table{{Name: "T", Meta: map[string]any{}}}}
+	if len(DiffSchemas(emptyMeta, contract.Schema{Tables: []contract.Table{{Name: "T"}}}).TableDiffs) != 0 {
+		t.Fatalf("empty and nil meta must compare equal")
+	}
+}
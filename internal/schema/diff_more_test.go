@@ -98,10 +98,10 @@ func TestDiffSchemasSortingAndComparatorPaths(t *testing.T) {
 	if len(baseDiff.ModifiedFields) < 2 {
 		t.Fatalf("expected multiple modified fields, got %+v", baseDiff.ModifiedFields)
 	}
-	if len(baseDiff.AddedResolvers) != 2 || baseDiff.AddedResolvers[0] != "baseOnly1" || baseDiff.AddedResolvers[1] != "baseOnly2" {
+	if len(baseDiff.AddedResolvers) != 2 || baseDiff.AddedResolvers[0] != "newOnly1" || baseDiff.AddedResolvers[1] != "newOnly2" {
 		t.Fatalf("expected sorted added resolvers, got %+v", baseDiff.AddedResolvers)
 	}
-	if len(baseDiff.RemovedResolvers) != 2 || baseDiff.RemovedResolvers[0] != "newOnly1" || baseDiff.RemovedResolvers[1] != "newOnly2" {
+	if len(baseDiff.RemovedResolvers) != 2 || baseDiff.RemovedResolvers[0] != "baseOnly1" || baseDiff.RemovedResolvers[1] != "baseOnly2" {
 		t.Fatalf("expected sorted removed resolvers, got %+v", baseDiff.RemovedResolvers)
 	}
 	if len(baseDiff.ModifiedResolvers) != 2 || baseDiff.ModifiedResolvers[0].Name != "modR1" || baseDiff.ModifiedResolvers[1].Name != "modR2" {
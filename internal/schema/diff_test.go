@@ -72,10 +72,10 @@ func TestDiffSchemasDetectsChanges(t *testing.T) {
 	if len(users.ModifiedFields) != 1 || users.ModifiedFields[0].Name != "id" {
 		t.Fatalf("expected modified id field, got %+v", users.ModifiedFields)
 	}
-	if len(users.AddedResolvers) != 1 || users.AddedResolvers[0] != "legacy" {
+	if len(users.AddedResolvers) != 1 || users.AddedResolvers[0] != "new" {
 		t.Fatalf("expected added resolver, got %+v", users.AddedResolvers)
 	}
-	if len(users.RemovedResolvers) != 1 || users.RemovedResolvers[0] != "new" {
+	if len(users.RemovedResolvers) != 1 || users.RemovedResolvers[0] != "legacy" {
 		t.Fatalf("expected removed resolver, got %+v", users.RemovedResolvers)
 	}
 	if len(users.ModifiedResolvers) != 1 || users.ModifiedResolvers[0].Name != "by_email" {
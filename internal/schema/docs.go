@@ -0,0 +1,340 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+// Relationship kinds reported by InferRelationships.
+const (
+	// RelationshipResolver edges come from a resolver script querying another table.
+	RelationshipResolver = "resolver"
+	// RelationshipReference edges come from a field such as roleId naming another table.
+	RelationshipReference = "reference"
+)
+
+// Relationship is an edge of the table graph.
+type Relationship struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	// Via is the resolver or field the edge comes from.
+	Via  string `json:"via"`
+	Kind string `json:"kind"`
+	// Many is set when the edge resolves to a list of To rows rather than at most one.
+	Many bool `json:"many"`
+}
+
+var resolverSinglePattern = regexp.MustCompile(`\.\s*(firstOrNull|first|one)\s*\(\s*\)\s*$`)
+
+// InferRelationships derives the table graph from resolvers and from fields named after another
+// table with an Id suffix (roleId references Role). A resolver points at the table its outermost
+// db.from queries. Edges are sorted by From, To and Via.
+func InferRelationships(s contract.Schema) []Relationship {
+	tables := map[string]string{}
+	for _, t := range s.Tables {
+		tables[strings.ToLower(t.Name)] = t.Name
+	}
+
+	var rels []Relationship
+	for _, t := range s.Tables {
+		for _, f := range t.Fields {
+			if f.Primary || len(f.Name) <= 2 || !strings.HasSuffix(f.Name, "Id") {
+				continue
+			}
+			if target, ok := tables[strings.ToLower(strings.TrimSuffix(f.Name, "Id"))]; ok {
+				rels = append(rels, Relationship{From: t.Name, To: target, Via: f.Name, Kind: RelationshipReference})
+			}
+		}
+		for _, r := range t.Resolvers {
+			m := resolverTablePattern.FindStringSubmatch(r.Resolver)
+			if m == nil {
+				continue
+			}
+			if target, ok := tables[strings.ToLower(m[1])]; ok {
+				many := !resolverSinglePattern.MatchString(strings.TrimSpace(r.Resolver))
+				rels = append(rels, Relationship{From: t.Name, To: target, Via: r.Name, Kind: RelationshipResolver, Many: many})
+			}
+		}
+	}
+
+	sort.Slice(rels, func(i, j int) bool {
+		a, b := rels[i], rels[j]
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		if a.To != b.To {
+			return a.To < b.To
+		}
+		return a.Via < b.Via
+	})
+	return rels
+}
+
+// DocFormats lists the formats RenderDocs accepts.
+var DocFormats = []string{"markdown", "mermaid", "dot"}
+
+// RenderDocs renders the schema as Markdown reference docs, a Mermaid ER diagram or a Graphviz
+// DOT graph. Tables, fields, indexes, resolvers and triggers follow NormalizeSchema ordering, so
+// the output only changes when the schema does.
+func RenderDocs(s contract.Schema, format string) (string, error) {
+	s = contract.NormalizeSchema(s)
+	rels := InferRelationships(s)
+	switch format {
+	case "markdown":
+		return renderMarkdown(s, rels), nil
+	case "mermaid":
+		return renderMermaid(s, rels), nil
+	case "dot":
+		return renderDot(s, rels), nil
+	}
+	return "", fmt.Errorf("unknown docs format %q: want %s", format, strings.Join(DocFormats, ", "))
+}
+
+// references maps table and field to the table the field references.
+func references(rels []Relationship) map[[2]string]string {
+	out := map[[2]string]string{}
+	for _, r := range rels {
+		if r.Kind == RelationshipReference {
+			out[[2]string{r.From, r.Via}] = r.To
+		}
+	}
+	return out
+}
+
+// indexedFields lists the fields covered by an index, keyed by field name.
+func indexedFields(t contract.Table) map[string]bool {
+	out := map[string]bool{}
+	for _, idx := range t.Indexes {
+		if len(idx.Fields) == 0 {
+			out[idx.Name] = true
+		}
+		for _, f := range idx.Fields {
+			out[f] = true
+		}
+	}
+	return out
+}
+
+func renderMarkdown(s contract.Schema, rels []Relationship) string {
+	refs := references(rels)
+	var b strings.Builder
+
+	b.WriteString("# Schema\n\n")
+	b.WriteString("## Tables\n\n")
+	for _, t := range s.Tables {
+		fmt.Fprintf(&b, "- [%s](#%s)\n", t.Name, anchor(t.Name))
+	}
+
+	if len(rels) > 0 {
+		b.WriteString("\n## Relationships\n\n```mermaid\n")
+		b.WriteString(renderMermaid(s, rels))
+		b.WriteString("```\n")
+	}
+
+	for _, t := range s.Tables {
+		fmt.Fprintf(&b, "\n## %s\n\n", t.Name)
+		if t.Partition != "" {
+			fmt.Fprintf(&b, "Partitioned by `%s`.\n\n", t.Partition)
+		}
+
+		b.WriteString("| Field | Type | Nullable | Key | Default |\n")
+		b.WriteString("| --- | --- | --- | --- | --- |\n")
+		for _, f := range t.Fields {
+			nullable := "no"
+			if f.Nullable {
+				nullable = "yes"
+			}
+			var keys []string
+			if f.Primary {
+				key := "PK"
+				if f.Generator != "" {
+					key += " (" + f.Generator + ")"
+				}
+				keys = append(keys, key)
+			}
+			if f.Unique {
+				keys = append(keys, "unique")
+			}
+			if target, ok := refs[[2]string{t.Name, f.Name}]; ok {
+				keys = append(keys, fmt.Sprintf("→ [%s](#%s)", target, anchor(target)))
+			}
+			typ := f.Type
+			if f.MaxSize > 0 {
+				typ = fmt.Sprintf("%s(%d)", typ, f.MaxSize)
+			}
+			def := ""
+			if f.Default != nil {
+				def = fmt.Sprintf("`%v`", f.Default)
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n", f.Name, typ, nullable, strings.Join(keys, ", "), def)
+		}
+
+		if len(t.Indexes) > 0 {
+			b.WriteString("\n**Indexes**\n\n| Name | Type | Fields |\n| --- | --- | --- |\n")
+			for _, idx := range t.Indexes {
+				fields := idx.Fields
+				if len(fields) == 0 {
+					fields = []string{idx.Name}
+				}
+				fmt.Fprintf(&b, "| %s | %s | %s |\n", idx.Name, orDefault(idx.Type), strings.Join(fields, ", "))
+			}
+		}
+
+		if len(t.Resolvers) > 0 {
+			b.WriteString("\n**Resolvers**\n\n| Name | Returns |\n| --- | --- |\n")
+			for _, r := range t.Resolvers {
+				fmt.Fprintf(&b, "| %s | %s |\n", r.Name, resolverTarget(t.Name, r.Name, rels))
+			}
+		}
+
+		if len(t.Triggers) > 0 {
+			b.WriteString("\n**Triggers**\n\n| Name | Event |\n| --- | --- |\n")
+			for _, trg := range t.Triggers {
+				fmt.Fprintf(&b, "| %s | %s |\n", trg.Name, trg.Event)
+			}
+		}
+	}
+	return b.String()
+}
+
+func resolverTarget(table, resolver string, rels []Relationship) string {
+	for _, r := range rels {
+		if r.Kind == RelationshipResolver && r.From == table && r.Via == resolver {
+			link := fmt.Sprintf("[%s](#%s)", r.To, anchor(r.To))
+			if r.Many {
+				return "list of " + link
+			}
+			return link
+		}
+	}
+	return ""
+}
+
+func renderMermaid(s contract.Schema, rels []Relationship) string {
+	refs := references(rels)
+	var b strings.Builder
+	b.WriteString("erDiagram\n")
+	for _, t := range s.Tables {
+		indexed := indexedFields(t)
+		fmt.Fprintf(&b, "    %s {\n", t.Name)
+		for _, f := range t.Fields {
+			var keys []string
+			if f.Primary {
+				keys = append(keys, "PK")
+			}
+			if _, ok := refs[[2]string{t.Name, f.Name}]; ok {
+				keys = append(keys, "FK")
+			}
+			if f.Unique {
+				keys = append(keys, "UK")
+			}
+			var notes []string
+			if f.Nullable {
+				notes = append(notes, "nullable")
+			}
+			if indexed[f.Name] {
+				notes = append(notes, "indexed")
+			}
+			if t.Partition == f.Name {
+				notes = append(notes, "partition")
+			}
+			line := fmt.Sprintf("        %s %s", f.Type, f.Name)
+			if len(keys) > 0 {
+				line += " " + strings.Join(keys, ", ")
+			}
+			if len(notes) > 0 {
+				line += fmt.Sprintf(" %q", strings.Join(notes, ", "))
+			}
+			b.WriteString(line + "\n")
+		}
+		b.WriteString("    }\n")
+		for _, trg := range t.Triggers {
+			fmt.Fprintf(&b, "    %%%% %s trigger %s on %s\n", t.Name, trg.Name, trg.Event)
+		}
+	}
+	for _, r := range rels {
+		switch {
+		case r.Kind == RelationshipReference:
+			fmt.Fprintf(&b, "    %s }o--|| %s : %q\n", r.From, r.To, r.Via)
+		case r.Many:
+			fmt.Fprintf(&b, "    %s ||--o{ %s : %q\n", r.From, r.To, r.Via)
+		default:
+			fmt.Fprintf(&b, "    %s ||--o| %s : %q\n", r.From, r.To, r.Via)
+		}
+	}
+	return b.String()
+}
+
+func renderDot(s contract.Schema, rels []Relationship) string {
+	refs := references(rels)
+	var b strings.Builder
+	b.WriteString("digraph schema {\n")
+	b.WriteString("    rankdir=LR;\n")
+	b.WriteString("    node [shape=record, fontname=\"Helvetica\"];\n")
+	for _, t := range s.Tables {
+		var fields []string
+		for _, f := range t.Fields {
+			line := f.Name + " : " + f.Type
+			if f.Nullable {
+				line += "?"
+			}
+			if f.Primary {
+				line += " PK"
+			}
+			if _, ok := refs[[2]string{t.Name, f.Name}]; ok {
+				line += " FK"
+			}
+			if f.Unique {
+				line += " UK"
+			}
+			fields = append(fields, dotEscape(line)+`\l`)
+		}
+		sections := []string{dotEscape(t.Name), strings.Join(fields, "")}
+		var extras []string
+		for _, idx := range t.Indexes {
+			fieldsList := idx.Fields
+			if len(fieldsList) == 0 {
+				fieldsList = []string{idx.Name}
+			}
+			extras = append(extras, dotEscape(fmt.Sprintf("index %s (%s) on %s", idx.Name, orDefault(idx.Type), strings.Join(fieldsList, ", ")))+`\l`)
+		}
+		for _, trg := range t.Triggers {
+			extras = append(extras, dotEscape(fmt.Sprintf("trigger %s on %s", trg.Name, trg.Event))+`\l`)
+		}
+		if len(extras) > 0 {
+			sections = append(sections, strings.Join(extras, ""))
+		}
+		fmt.Fprintf(&b, "    %q [label=\"{%s}\"];\n", t.Name, strings.Join(sections, "|"))
+	}
+	for _, r := range rels {
+		attrs := fmt.Sprintf("label=%q", r.Via)
+		if r.Kind == RelationshipReference {
+			attrs += ", style=dashed"
+		} else if r.Many {
+			attrs += ", arrowhead=crow"
+		}
+		fmt.Fprintf(&b, "    %q -> %q [%s];\n", r.From, r.To, attrs)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// dotEscape escapes the characters record labels treat as structure.
+func dotEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "{", `\{`, "}", `\}`, "|", `\|`, "<", `\<`, ">", `\>`).Replace(s)
+}
+
+func anchor(name string) string {
+	return strings.ToLower(name)
+}
+
+func orDefault(indexType string) string {
+	if indexType == "" {
+		return "DEFAULT"
+	}
+	return indexType
+}
@@ -0,0 +1,135 @@
+package schema
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+func docsSchema() contract.Schema {
+	return contract.Schema{Tables: []contract.Table{
+		{
+			Name: "UserRole",
+			Fields: []contract.Field{
+				{Name: "userId", Type: "String"},
+				{Name: "roleId", Type: "String"},
+				{Name: "id", Type: "String", Primary: true, Generator: "UUID"},
+			},
+			Resolvers: []contract.Resolver{{Name: "role", Resolver: `db.from("Role").where(eq("id", this.roleId)).firstOrNull()`}},
+		},
+		{
+			Name:      "User",
+			Partition: "region",
+			Fields: []contract.Field{
+				{Name: "id", Type: "String", Primary: true},
+				{Name: "email", Type: "String", Unique: true, MaxSize: 255},
+				{Name: "region", Type: "String", Nullable: true, Default: "eu"},
+				{Name: "tenantId", Type: "String"},
+			},
+			Indexes:   []contract.Index{{Name: "email"}},
+			Triggers:  []contract.Trigger{{Name: "audit", Event: "PostSave"}},
+			Resolvers: []contract.Resolver{{Name: "roles", Resolver: "db.from(\"Role\")\n .where(inOp(\"id\", db.from(\"UserRole\").where(eq(\"userId\", this.id)).list().values('roleId')))\n .list()"}},
+		},
+		{Name: "Role", Fields: []contract.Field{{Name: "id", Type: "String", Primary: true}}},
+	}}
+}
+
+func TestInferRelationships(t *testing.T) {
+	got := InferRelationships(contract.NormalizeSchema(docsSchema()))
+	want := []Relationship{
+		{From: "User", To: "Role", Via: "roles", Kind: RelationshipResolver, Many: true},
+		{From: "UserRole", To: "Role", Via: "role", Kind: RelationshipResolver},
+		{From: "UserRole", To: "Role", Via: "roleId", Kind: RelationshipReference},
+		{From: "UserRole", To: "User", Via: "userId", Kind: RelationshipReference},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestRenderDocsMarkdown(t *testing.T) {
+	out, err := RenderDocs(docsSchema(), "markdown")
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	for _, want := range []string{
+		"- [Role](#role)\n- [User](#user)\n- [UserRole](#userrole)\n",
+		"```mermaid\nerDiagram\n",
+		"Partitioned by `region`.",
+		"| email | String(255) | no | unique |  |",
+		"| region | String | yes |  | `eu` |",
+		"| id | String | no | PK (UUID) |  |",
+		"| roleId | String | no | → [Role](#role) |  |",
+		"| email | DEFAULT | email |",
+		"| roles | list of [Role](#role) |",
+		"| role | [Role](#role) |",
+		"| audit | PostSave |",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in markdown:\n%s", want, out)
+		}
+	}
+	if strings.Index(out, "## Role\n") > strings.Index(out, "## UserRole\n") {
+		t.Fatalf("expected tables in name order:\n%s", out)
+	}
+}
+
+func TestRenderDocsMermaid(t *testing.T) {
+	out, err := RenderDocs(docsSchema(), "mermaid")
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	for _, want := range []string{
+		"        String email UK \"indexed\"\n",
+		"        String region \"nullable, partition\"\n",
+		"        String roleId FK\n",
+		"    %% User trigger audit on PostSave\n",
+		"    User ||--o{ Role : \"roles\"\n",
+		"    UserRole ||--o| Role : \"role\"\n",
+		"    UserRole }o--|| User : \"userId\"\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in mermaid:\n%s", want, out)
+		}
+	}
+}
+
+func TestRenderDocsDot(t *testing.T) {
+	out, err := RenderDocs(docsSchema(), "dot")
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	for _, want := range []string{
+		`"User" [label="{User|email : String UK\lid : String PK\lregion : String?\ltenantId : String\l|index email (DEFAULT) on email\ltrigger audit on PostSave\l}"];`,
+		`"UserRole" -> "User" [label="userId", style=dashed];`,
+		`"User" -> "Role" [label="roles", arrowhead=crow];`,
+		`roleId : String FK\l`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in dot:\n%s", want, out)
+		}
+	}
+	if got := dotEscape(`a{b}|<c>"`); got != `a\{b\}\|\<c\>\"` {
+		t.Fatalf("unexpected escape %q", got)
+	}
+}
+
+func TestRenderDocsDeterministic(t *testing.T) {
+	s := docsSchema()
+	reversed := contract.Schema{}
+	for i := len(s.Tables) - 1; i >= 0; i-- {
+		reversed.Tables = append(reversed.Tables, s.Tables[i])
+	}
+	for _, format := range DocFormats {
+		a, _ := RenderDocs(s, format)
+		b, _ := RenderDocs(reversed, format)
+		if a != b {
+			t.Fatalf("%s output depends on input order", format)
+		}
+	}
+	if _, err := RenderDocs(s, "html"); err == nil {
+		t.Fatalf("expected unknown format error")
+	}
+}
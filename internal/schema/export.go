@@ -0,0 +1,173 @@
+package schema
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+// JSONSchemaDialect is the $schema of documents from ExportJSONSchema.
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// ExportFormats lists the formats Export accepts.
+var ExportFormats = []string{"jsonschema", "openapi"}
+
+// ExportOptions describe the exported document.
+type ExportOptions struct {
+	// Title and Version fill the OpenAPI info object and the JSON Schema title.
+	// Title defaults to "Onyx schema" and Version to "1.0.0".
+	Title   string
+	Version string
+}
+
+// Export renders the schema as a JSON Schema (draft 2020-12) document with one $defs entry per
+// table, or as an OpenAPI 3.1 document with one components/schemas entry per table. The result
+// is a plain JSON value; encoding/json writes its keys in sorted order.
+func Export(s contract.Schema, format string, opts ExportOptions) (map[string]any, error) {
+	switch format {
+	case "jsonschema":
+		return ExportJSONSchema(s, opts), nil
+	case "openapi":
+		return ExportOpenAPI(s, opts), nil
+	}
+	return nil, fmt.Errorf("unknown export format %q: want %s", format, strings.Join(ExportFormats, ", "))
+}
+
+// ExportJSONSchema renders the tables as JSON Schema definitions under $defs.
+func ExportJSONSchema(s contract.Schema, opts ExportOptions) map[string]any {
+	opts = opts.withDefaults()
+	return map[string]any{
+		"$schema": JSONSchemaDialect,
+		"title":   opts.Title,
+		"$defs":   tableSchemas(s, "#/$defs/"),
+	}
+}
+
+// ExportOpenAPI renders the tables as an OpenAPI 3.1 components/schemas document.
+func ExportOpenAPI(s contract.Schema, opts ExportOptions) map[string]any {
+	opts = opts.withDefaults()
+	return map[string]any{
+		"openapi":           "3.1.0",
+		"jsonSchemaDialect": JSONSchemaDialect,
+		"info":              map[string]any{"title": opts.Title, "version": opts.Version},
+		"paths":             map[string]any{},
+		"components":        map[string]any{"schemas": tableSchemas(s, "#/components/schemas/")},
+	}
+}
+
+func (o ExportOptions) withDefaults() ExportOptions {
+	if o.Title == "" {
+		o.Title = "Onyx schema"
+	}
+	if o.Version == "" {
+		o.Version = "1.0.0"
+	}
+	return o
+}
+
+// tableSchemas builds one object schema per table. Non-nullable attributes are required;
+// resolvers are optional properties referencing the table they return, when it is known.
+func tableSchemas(s contract.Schema, refPrefix string) map[string]any {
+	s = contract.NormalizeSchema(s)
+	targets := map[[2]string]Relationship{}
+	for _, r := range InferRelationships(s) {
+		if r.Kind == RelationshipResolver {
+			targets[[2]string{r.From, r.Via}] = r
+		}
+	}
+
+	defs := make(map[string]any, len(s.Tables))
+	for _, t := range s.Tables {
+		props := map[string]any{}
+		required := []string{}
+		for _, f := range t.Fields {
+			props[f.Name] = fieldSchema(f)
+			if !f.Nullable {
+				required = append(required, f.Name)
+			}
+		}
+		for _, r := range t.Resolvers {
+			prop := map[string]any{"description": "Resolver " + r.Name + "; present when requested."}
+			if rel, ok := targets[[2]string{t.Name, r.Name}]; ok {
+				ref := map[string]any{"$ref": refPrefix + rel.To}
+				if rel.Many {
+					prop["type"] = "array"
+					prop["items"] = ref
+				} else {
+					prop["anyOf"] = []any{ref, map[string]any{"type": "null"}}
+				}
+			}
+			props[r.Name] = prop
+		}
+
+		def := map[string]any{
+			"type":       "object",
+			"title":      t.Name,
+			"properties": props,
+			"required":   required,
+		}
+		defs[t.Name] = def
+	}
+	return defs
+}
+
+// fieldSchema maps an attribute to its JSON Schema. Nullable attributes accept null through a
+// type array, as JSON Schema 2020-12 and OpenAPI 3.1 both expect.
+func fieldSchema(f contract.Field) map[string]any {
+	out := map[string]any{}
+	var typ string
+	switch f.Type {
+	case "String":
+		typ = "string"
+		if f.MaxSize > 0 {
+			out["maxLength"] = f.MaxSize
+		}
+	case "Char":
+		typ = "string"
+		out["minLength"], out["maxLength"] = 1, 1
+	case "Boolean":
+		typ = "boolean"
+	case "Byte":
+		typ = "integer"
+		out["minimum"], out["maximum"] = math.MinInt8, math.MaxInt8
+	case "Short":
+		typ = "integer"
+		out["minimum"], out["maximum"] = math.MinInt16, math.MaxInt16
+	case "Int":
+		typ = "integer"
+		out["format"] = "int32"
+	case "Long":
+		typ = "integer"
+		out["format"] = "int64"
+	case "Float":
+		typ = "number"
+		out["format"] = "float"
+	case "Double":
+		typ = "number"
+		out["format"] = "double"
+	case "Date", "Timestamp":
+		typ = "string"
+		out["format"] = "date-time"
+	case "EmbeddedObject":
+		typ = "object"
+	case "EmbeddedList":
+		typ = "array"
+	}
+
+	if typ != "" {
+		if f.Nullable {
+			out["type"] = []any{typ, "null"}
+		} else {
+			out["type"] = typ
+		}
+	}
+	if f.Default != nil {
+		out["default"] = f.Default
+	}
+	if f.Primary && f.Generator != "" && f.Generator != "None" {
+		out["readOnly"] = true
+	}
+	return out
+}
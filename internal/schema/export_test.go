@@ -0,0 +1,132 @@
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+func exportSchema() contract.Schema {
+	return contract.Schema{Tables: []contract.Table{
+		{
+			Name: "User",
+			Fields: []contract.Field{
+				{Name: "id", Type: "String", Primary: true, Generator: "UUID"},
+				{Name: "email", Type: "String", MaxSize: 120},
+				{Name: "age", Type: "Int", Nullable: true},
+				{Name: "createdAt", Type: "Timestamp"},
+				{Name: "active", Type: "Boolean", Default: true},
+			},
+			Resolvers: []contract.Resolver{
+				{Name: "profile", Resolver: `db.from("Profile").where(eq("userId", this.id)).firstOrNull()`},
+				{Name: "roles", Resolver: `db.from("Role").list()`},
+				{Name: "custom", Resolver: `compute()`},
+			},
+		},
+		{Name: "Profile", Fields: []contract.Field{{Name: "id", Type: "Long", Primary: true, Generator: "None"}, {Name: "tags", Type: "EmbeddedList", Nullable: true}}},
+		{Name: "Role", Fields: []contract.Field{{Name: "id", Type: "String", Primary: true}}},
+	}}
+}
+
+// roundTrip encodes v the way the CLI does so assertions compare plain JSON values.
+func roundTrip(t *testing.T, v any) map[string]any {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var out map[string]any
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	return out
+}
+
+func TestExportJSONSchema(t *testing.T) {
+	doc := roundTrip(t, ExportJSONSchema(exportSchema(), ExportOptions{}))
+	if doc["$schema"] != JSONSchemaDialect || doc["title"] != "Onyx schema" {
+		t.Fatalf("unexpected header: %v", doc)
+	}
+
+	user := doc["$defs"].(map[string]any)["User"].(map[string]any)
+	want := roundTrip(t, map[string]any{
+		"type":     "object",
+		"title":    "User",
+		"required": []string{"active", "createdAt", "email", "id"},
+		"properties": map[string]any{
+			"id":        map[string]any{"type": "string", "readOnly": true},
+			"email":     map[string]any{"type": "string", "maxLength": 120},
+			"age":       map[string]any{"type": []string{"integer", "null"}, "format": "int32"},
+			"createdAt": map[string]any{"type": "string", "format": "date-time"},
+			"active":    map[string]any{"type": "boolean", "default": true},
+			"profile": map[string]any{
+				"description": "Resolver profile; present when requested.",
+				"anyOf":       []any{map[string]any{"$ref": "#/$defs/Profile"}, map[string]any{"type": "null"}},
+			},
+			"roles": map[string]any{
+				"description": "Resolver roles; present when requested.",
+				"type":        "array",
+				"items":       map[string]any{"$ref": "#/$defs/Role"},
+			},
+			"custom": map[string]any{"description": "Resolver custom; present when requested."},
+		},
+	})
+	if !reflect.DeepEqual(user, want) {
+		t.Fatalf("unexpected User definition:\n got %v\nwant %v", user, want)
+	}
+
+	profile := doc["$defs"].(map[string]any)["Profile"].(map[string]any)["properties"].(map[string]any)
+	if !reflect.DeepEqual(profile["id"], map[string]any{"type": "integer", "format": "int64"}) {
+		t.Fatalf("unexpected Profile.id: %v", profile["id"])
+	}
+	if !reflect.DeepEqual(profile["tags"], map[string]any{"type": []any{"array", "null"}}) {
+		t.Fatalf("unexpected Profile.tags: %v", profile["tags"])
+	}
+}
+
+func TestExportOpenAPI(t *testing.T) {
+	doc := roundTrip(t, ExportOpenAPI(exportSchema(), ExportOptions{Title: "Users API", Version: "2.1.0"}))
+	if doc["openapi"] != "3.1.0" || doc["jsonSchemaDialect"] != JSONSchemaDialect {
+		t.Fatalf("unexpected header: %v", doc)
+	}
+	if !reflect.DeepEqual(doc["info"], map[string]any{"title": "Users API", "version": "2.1.0"}) {
+		t.Fatalf("unexpected info: %v", doc["info"])
+	}
+	schemas := doc["components"].(map[string]any)["schemas"].(map[string]any)
+	if len(schemas) != 3 {
+		t.Fatalf("expected one schema per table, got %v", schemas)
+	}
+	roles := schemas["User"].(map[string]any)["properties"].(map[string]any)["roles"].(map[string]any)
+	if roles["items"].(map[string]any)["$ref"] != "#/components/schemas/Role" {
+		t.Fatalf("expected components ref, got %v", roles)
+	}
+}
+
+func TestExportFieldTypes(t *testing.T) {
+	for typ, want := range map[string]map[string]any{
+		"Char":           {"type": "string", "minLength": 1, "maxLength": 1},
+		"Byte":           {"type": "integer", "minimum": -128, "maximum": 127},
+		"Short":          {"type": "integer", "minimum": -32768, "maximum": 32767},
+		"Float":          {"type": "number", "format": "float"},
+		"Double":         {"type": "number", "format": "double"},
+		"Date":           {"type": "string", "format": "date-time"},
+		"EmbeddedObject": {"type": "object"},
+		"Mystery":        {},
+	} {
+		if got := fieldSchema(contract.Field{Name: "f", Type: typ}); !reflect.DeepEqual(got, want) {
+			t.Fatalf("%s: expected %v, got %v", typ, want, got)
+		}
+	}
+}
+
+func TestExportUnknownFormat(t *testing.T) {
+	if _, err := Export(exportSchema(), "protobuf", ExportOptions{}); err == nil {
+		t.Fatalf("expected unknown format error")
+	}
+	doc, err := Export(exportSchema(), "jsonschema", ExportOptions{})
+	if err != nil || doc["$defs"] == nil {
+		t.Fatalf("unexpected export %v, err %v", doc, err)
+	}
+}
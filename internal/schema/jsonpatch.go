@@ -0,0 +1,409 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+// PatchOperation is a single RFC 6902 JSON Patch operation.
+type PatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	From  string `json:"from,omitempty"`
+	Value any    `json:"value,omitempty"`
+}
+
+// MarshalJSON always writes "value" for operations that carry one, even when it is null or empty.
+func (p PatchOperation) MarshalJSON() ([]byte, error) {
+	type plain PatchOperation
+	if p.Op != "add" && p.Op != "replace" && p.Op != "test" {
+		return json.Marshal(plain(p))
+	}
+	return json.Marshal(struct {
+		Op    string `json:"op"`
+		Path  string `json:"path"`
+		Value any    `json:"value"`
+	}{p.Op, p.Path, p.Value})
+}
+
+// PatchConflictError reports a patch operation that does not match the document it is applied
+// to, which means the target drifted from the schema the patch was generated against.
+type PatchConflictError struct {
+	Index  int
+	Op     PatchOperation
+	Reason string
+}
+
+func (e *PatchConflictError) Error() string {
+	return fmt.Sprintf("patch conflict at operation %d (%s %s): %s", e.Index, e.Op.Op, e.Op.Path, e.Reason)
+}
+
+// DiffJSONPatch returns an RFC 6902 patch that turns the normalized form of a into the normalized
+// form of b. Arrays of named entries (tables, fields, resolvers, indexes, triggers) are matched
+// by name. Every replace and remove is preceded by a test of the value it expects, and every
+// edit inside a named entry by a test of that entry's name, so applying the patch to a drifted
+// schema fails instead of silently overwriting it.
+func DiffJSONPatch(a, b contract.Schema) ([]PatchOperation, error) {
+	docA, err := schemaDocument(contract.NormalizeSchema(a))
+	if err != nil {
+		return nil, err
+	}
+	docB, err := schemaDocument(contract.NormalizeSchema(b))
+	if err != nil {
+		return nil, err
+	}
+	return diffValues("", docA, docB), nil
+}
+
+// ApplyJSONPatch applies an RFC 6902 patch to the normalized form of the schema, the form
+// DiffJSONPatch addresses, so a target whose tables or fields are merely listed in another order
+// is not reported as drifted. A failed test or a missing path is returned as a
+// *PatchConflictError and leaves s untouched.
+func ApplyJSONPatch(s contract.Schema, ops []PatchOperation) (contract.Schema, error) {
+	doc, err := schemaDocument(contract.NormalizeSchema(s))
+	if err != nil {
+		return contract.Schema{}, err
+	}
+	for i, op := range ops {
+		doc, err = applyOperation(doc, op)
+		if err != nil {
+			return contract.Schema{}, &PatchConflictError{Index: i, Op: op, Reason: err.Error()}
+		}
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return contract.Schema{}, err
+	}
+	return contract.ParseSchemaJSON(data)
+}
+
+func schemaDocument(s contract.Schema) (any, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	var doc any
+	err = json.Unmarshal(data, &doc)
+	return doc, err
+}
+
+func diffValues(path string, a, b any) []PatchOperation {
+	if reflect.DeepEqual(a, b) {
+		return nil
+	}
+	switch av := a.(type) {
+	case map[string]any:
+		if bv, ok := b.(map[string]any); ok {
+			return diffObjects(path, av, bv)
+		}
+	case []any:
+		if bv, ok := b.([]any); ok && namedEntries(av) && namedEntries(bv) && sameRelativeOrder(av, bv) {
+			return diffNamedArrays(path, av, bv)
+		}
+	}
+	return []PatchOperation{{Op: "test", Path: path, Value: a}, {Op: "replace", Path: path, Value: b}}
+}
+
+func diffObjects(path string, a, b map[string]any) []PatchOperation {
+	var ops []PatchOperation
+	for _, key := range sortedMapKeys(a) {
+		child := path + "/" + escapePointer(key)
+		if bv, ok := b[key]; ok {
+			ops = append(ops, diffValues(child, a[key], bv)...)
+		} else {
+			ops = append(ops, PatchOperation{Op: "test", Path: child, Value: a[key]}, PatchOperation{Op: "remove", Path: child})
+		}
+	}
+	for _, key := range sortedMapKeys(b) {
+		if _, ok := a[key]; !ok {
+			ops = append(ops, PatchOperation{Op: "add", Path: path + "/" + escapePointer(key), Value: b[key]})
+		}
+	}
+	return ops
+}
+
+// diffNamedArrays removes entries missing from b (last first, so earlier indexes stay valid),
+// edits the entries both sides share, then inserts b's new entries at their final positions.
+func diffNamedArrays(path string, a, b []any) []PatchOperation {
+	inB := map[string]any{}
+	for _, item := range b {
+		inB[entryName(item)] = item
+	}
+	inA := map[string]bool{}
+	for _, item := range a {
+		inA[entryName(item)] = true
+	}
+
+	var ops []PatchOperation
+	var kept []any
+	for i := len(a) - 1; i >= 0; i-- {
+		if _, ok := inB[entryName(a[i])]; !ok {
+			p := path + "/" + strconv.Itoa(i)
+			ops = append(ops, PatchOperation{Op: "test", Path: p, Value: a[i]}, PatchOperation{Op: "remove", Path: p})
+		}
+	}
+	for _, item := range a {
+		if _, ok := inB[entryName(item)]; ok {
+			kept = append(kept, item)
+		}
+	}
+	for i, item := range kept {
+		p := path + "/" + strconv.Itoa(i)
+		if inner := diffValues(p, item, inB[entryName(item)]); len(inner) > 0 {
+			ops = append(ops, PatchOperation{Op: "test", Path: p + "/name", Value: entryName(item)})
+			ops = append(ops, inner...)
+		}
+	}
+	for i, item := range b {
+		if !inA[entryName(item)] {
+			ops = append(ops, PatchOperation{Op: "add", Path: path + "/" + strconv.Itoa(i), Value: item})
+		}
+	}
+	return ops
+}
+
+func namedEntries(items []any) bool {
+	seen := map[string]bool{}
+	for _, item := range items {
+		name := entryName(item)
+		if name == "" || seen[name] {
+			return false
+		}
+		seen[name] = true
+	}
+	return true
+}
+
+// sameRelativeOrder reports whether the entries a and b share appear in the same order, which
+// diffNamedArrays relies on to place insertions.
+func sameRelativeOrder(a, b []any) bool {
+	pos := map[string]int{}
+	for i, item := range b {
+		pos[entryName(item)] = i
+	}
+	last := -1
+	for _, item := range a {
+		if i, ok := pos[entryName(item)]; ok {
+			if i < last {
+				return false
+			}
+			last = i
+		}
+	}
+	return true
+}
+
+func entryName(item any) string {
+	if m, ok := item.(map[string]any); ok {
+		if name, ok := m["name"].(string); ok {
+			return name
+		}
+	}
+	return ""
+}
+
+func sortedMapKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func escapePointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+func parsePointer(path string) ([]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer %q", path)
+	}
+	tokens := strings.Split(path[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(t, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func applyOperation(doc any, op PatchOperation) (any, error) {
+	tokens, err := parsePointer(op.Path)
+	if err != nil {
+		return doc, err
+	}
+	switch op.Op {
+	case "test":
+		current, err := lookupPointer(doc, tokens)
+		if err != nil {
+			return doc, err
+		}
+		if !reflect.DeepEqual(current, normalizeValue(op.Value)) {
+			return doc, fmt.Errorf("expected %s, found %s", compactJSON(op.Value), compactJSON(current))
+		}
+		return doc, nil
+	case "add":
+		return mutatePointer(doc, tokens, func(parent any, key string) (any, error) { return insertValue(parent, key, normalizeValue(op.Value)) })
+	case "remove":
+		return mutatePointer(doc, tokens, removeValue)
+	case "replace":
+		doc, err := mutatePointer(doc, tokens, removeValue)
+		if err != nil {
+			return doc, err
+		}
+		return mutatePointer(doc, tokens, func(parent any, key string) (any, error) { return insertValue(parent, key, normalizeValue(op.Value)) })
+	case "move", "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return doc, err
+		}
+		value, err := lookupPointer(doc, from)
+		if err != nil {
+			return doc, err
+		}
+		value = normalizeValue(value) // copy, so the source and destination do not share maps
+		if op.Op == "move" {
+			if doc, err = mutatePointer(doc, from, removeValue); err != nil {
+				return doc, err
+			}
+		}
+		return mutatePointer(doc, tokens, func(parent any, key string) (any, error) { return insertValue(parent, key, value) })
+	default:
+		return doc, fmt.Errorf("unsupported op %q", op.Op)
+	}
+}
+
+func lookupPointer(doc any, tokens []string) (any, error) {
+	current := doc
+	for _, t := range tokens {
+		switch node := current.(type) {
+		case map[string]any:
+			v, ok := node[t]
+			if !ok {
+				return nil, fmt.Errorf("path segment %q not found", t)
+			}
+			current = v
+		case []any:
+			i, err := arrayIndex(t, len(node))
+			if err != nil {
+				return nil, err
+			}
+			current = node[i]
+		default:
+			return nil, fmt.Errorf("path segment %q not found", t)
+		}
+	}
+	return current, nil
+}
+
+// mutatePointer rebuilds the path to the last token's parent and lets fn change that parent.
+func mutatePointer(doc any, tokens []string, fn func(parent any, key string) (any, error)) (any, error) {
+	if len(tokens) == 0 {
+		return doc, fmt.Errorf("cannot modify the document root")
+	}
+	if len(tokens) == 1 {
+		return fn(doc, tokens[0])
+	}
+	head := tokens[0]
+	switch node := doc.(type) {
+	case map[string]any:
+		child, ok := node[head]
+		if !ok {
+			return doc, fmt.Errorf("path segment %q not found", head)
+		}
+		updated, err := mutatePointer(child, tokens[1:], fn)
+		if err != nil {
+			return doc, err
+		}
+		node[head] = updated
+		return node, nil
+	case []any:
+		i, err := arrayIndex(head, len(node))
+		if err != nil {
+			return doc, err
+		}
+		updated, err := mutatePointer(node[i], tokens[1:], fn)
+		if err != nil {
+			return doc, err
+		}
+		node[i] = updated
+		return node, nil
+	}
+	return doc, fmt.Errorf("path segment %q not found", head)
+}
+
+func insertValue(parent any, key string, value any) (any, error) {
+	switch node := parent.(type) {
+	case map[string]any:
+		node[key] = value
+		return node, nil
+	case []any:
+		i := len(node)
+		if key != "-" {
+			var err error
+			if i, err = arrayIndex(key, len(node)+1); err != nil {
+				return parent, err
+			}
+		}
+		out := make([]any, 0, len(node)+1)
+		out = append(out, node[:i]...)
+		out = append(out, value)
+		return append(out, node[i:]...), nil
+	}
+	return parent, fmt.Errorf("cannot add %q to a non-container", key)
+}
+
+func removeValue(parent any, key string) (any, error) {
+	switch node := parent.(type) {
+	case map[string]any:
+		if _, ok := node[key]; !ok {
+			return parent, fmt.Errorf("path segment %q not found", key)
+		}
+		delete(node, key)
+		return node, nil
+	case []any:
+		i, err := arrayIndex(key, len(node))
+		if err != nil {
+			return parent, err
+		}
+		return append(node[:i:i], node[i+1:]...), nil
+	}
+	return parent, fmt.Errorf("path segment %q not found", key)
+}
+
+func arrayIndex(token string, length int) (int, error) {
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || i >= length || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("array index %q out of range", token)
+	}
+	return i, nil
+}
+
+// normalizeValue round-trips a value through JSON so it compares equal to decoded documents.
+func normalizeValue(v any) any {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var out any
+	if err := json.Unmarshal(data, &out); err != nil {
+		return v
+	}
+	return out
+}
+
+func compactJSON(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
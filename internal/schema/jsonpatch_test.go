@@ -0,0 +1,145 @@
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+func patchFixtures() (contract.Schema, contract.Schema) {
+	base := contract.Schema{Tables: []contract.Table{
+		{Name: "User", Fields: []contract.Field{{Name: "id", Type: "String", Primary: true}, {Name: "legacy", Type: "String"}, {Name: "name", Type: "String"}},
+			Indexes: []contract.Index{{Name: "name", Type: "DEFAULT"}}},
+		{Name: "Old", Fields: []contract.Field{{Name: "id", Type: "String"}}},
+		{Name: "Role", Fields: []contract.Field{{Name: "id", Type: "String"}}, Meta: map[string]any{"owner": "a/b~c"}},
+	}}
+	target := contract.Schema{Tables: []contract.Table{
+		{Name: "User", Fields: []contract.Field{{Name: "id", Type: "String", Primary: true}, {Name: "email", Type: "String", Nullable: true}, {Name: "name", Type: "String", MaxSize: 64}},
+			Indexes:  []contract.Index{{Name: "name", Type: "LUCENE", Fields: []string{"name"}}},
+			Triggers: []contract.Trigger{{Name: "audit", Event: "PreSave"}}},
+		{Name: "Audit", Fields: []contract.Field{{Name: "id", Type: "String"}}},
+		{Name: "Role", Fields: []contract.Field{{Name: "id", Type: "String"}}, Meta: map[string]any{"owner": "a/b~c", "tier": "gold"}},
+	}}
+	return base, target
+}
+
+func TestJSONPatchRoundTrip(t *testing.T) {
+	base, target := patchFixtures()
+	ops, err := DiffJSONPatch(base, target)
+	if err != nil {
+		t.Fatalf("diff err: %v", err)
+	}
+
+	// The patch survives serialization as a plain RFC 6902 document.
+	data, err := json.Marshal(ops)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var decoded []PatchOperation
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	got, err := ApplyJSONPatch(contract.NormalizeSchema(base), decoded)
+	if err != nil {
+		t.Fatalf("apply err: %v\npatch: %s", err, data)
+	}
+	if !reflect.DeepEqual(contract.NormalizeSchema(got), contract.NormalizeSchema(target)) {
+		t.Fatalf("patched schema differs from target: %+v", DiffSchemas(got, target))
+	}
+	if !strings.Contains(string(data), `"op":"test"`) || !strings.Contains(string(data), `"path":"/tables/0/meta/tier"`) {
+		t.Fatalf("expected guarded, name-matched ops, got %s", data)
+	}
+
+	if ops, _ := DiffJSONPatch(base, base); len(ops) != 0 {
+		t.Fatalf("identical schemas must produce an empty patch, got %+v", ops)
+	}
+}
+
+func TestJSONPatchAppliesToUnnormalizedTarget(t *testing.T) {
+	base, target := patchFixtures()
+	ops, err := DiffJSONPatch(base, target)
+	if err != nil {
+		t.Fatalf("diff err: %v", err)
+	}
+
+	// The same schema as it might sit in a file: tables and fields in authoring order.
+	reordered := contract.Schema{}
+	for i := len(base.Tables) - 1; i >= 0; i-- {
+		table := base.Tables[i]
+		fields := make([]contract.Field, 0, len(table.Fields))
+		for j := len(table.Fields) - 1; j >= 0; j-- {
+			fields = append(fields, table.Fields[j])
+		}
+		table.Fields = fields
+		reordered.Tables = append(reordered.Tables, table)
+	}
+	if reflect.DeepEqual(reordered, contract.NormalizeSchema(reordered)) {
+		t.Fatalf("fixture should not already be normalized")
+	}
+
+	got, err := ApplyJSONPatch(reordered, ops)
+	if err != nil {
+		t.Fatalf("reordering alone must not count as drift: %v", err)
+	}
+	if !reflect.DeepEqual(contract.NormalizeSchema(got), contract.NormalizeSchema(target)) {
+		t.Fatalf("patched schema differs from target: %+v", DiffSchemas(got, target))
+	}
+}
+
+func TestJSONPatchDetectsDrift(t *testing.T) {
+	base, target := patchFixtures()
+	ops, err := DiffJSONPatch(base, target)
+	if err != nil {
+		t.Fatalf("diff err: %v", err)
+	}
+
+	drifted := contract.NormalizeSchema(base)
+	drifted.Tables[2].Indexes[0].Type = "VECTOR" // the index the patch rewrites changed since it was made
+	_, err = ApplyJSONPatch(drifted, ops)
+	var conflict *PatchConflictError
+	if !errors.As(err, &conflict) || conflict.Op.Op != "test" {
+		t.Fatalf("expected a test conflict, got %v", err)
+	}
+
+	shifted := contract.NormalizeSchema(base)
+	shifted.Tables = append([]contract.Table{{Name: "Account"}}, shifted.Tables...)
+	if _, err := ApplyJSONPatch(shifted, ops); !errors.As(err, &conflict) {
+		t.Fatalf("expected shifted tables to conflict, got %v", err)
+	}
+}
+
+func TestApplyJSONPatchOperations(t *testing.T) {
+	s := contract.Schema{Tables: []contract.Table{{Name: "A", Fields: []contract.Field{{Name: "id", Type: "String"}}}}}
+	ops := []PatchOperation{
+		{Op: "copy", From: "/tables/0", Path: "/tables/-"},
+		{Op: "replace", Path: "/tables/1/name", Value: "B"},
+		{Op: "move", From: "/tables/0/fields/0", Path: "/tables/1/fields/0"},
+	}
+	got, err := ApplyJSONPatch(s, ops)
+	if err != nil {
+		t.Fatalf("apply err: %v", err)
+	}
+	if len(got.Tables) != 2 || got.Tables[1].Name != "B" || len(got.Tables[0].Fields) != 0 || len(got.Tables[1].Fields) != 2 {
+		t.Fatalf("unexpected result: %+v", got)
+	}
+
+	bad := [][]PatchOperation{
+		{{Op: "remove", Path: "/tables/5"}},
+		{{Op: "remove", Path: "/tables/0/missing"}},
+		{{Op: "replace", Path: "", Value: 1}},
+		{{Op: "add", Path: "tables"}},
+		{{Op: "frobnicate", Path: "/tables"}},
+		{{Op: "test", Path: "/tables/0/name", Value: "Z"}},
+		{{Op: "move", From: "/nope", Path: "/tables/-"}},
+	}
+	for _, ops := range bad {
+		if _, err := ApplyJSONPatch(s, ops); err == nil {
+			t.Fatalf("expected error for %+v", ops)
+		}
+	}
+}
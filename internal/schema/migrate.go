@@ -0,0 +1,238 @@
+package schema
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+// MigrationStepKind names a single migration action.
+type MigrationStepKind string
+
+const (
+	StepAddTable           MigrationStepKind = "add_table"
+	StepAddField           MigrationStepKind = "add_field"
+	StepRelaxNullability   MigrationStepKind = "relax_nullability"
+	StepBackfill           MigrationStepKind = "backfill"
+	StepTightenNullability MigrationStepKind = "tighten_nullability"
+	StepChangeField        MigrationStepKind = "change_field"
+	StepUpdateResolvers    MigrationStepKind = "update_resolvers"
+	StepDropField          MigrationStepKind = "drop_field"
+	StepDropTable          MigrationStepKind = "drop_table"
+)
+
+// MigrationStep is one ordered action in a migration plan.
+//
+// Backfill steps change data rather than the schema: they set Value on every row where Field
+// is null. All other steps are applied to the schema with ApplyMigrationStep.
+type MigrationStep struct {
+	Kind        MigrationStepKind   `json:"kind"`
+	Table       string              `json:"table"`
+	Field       string              `json:"field,omitempty"`
+	Description string              `json:"description"`
+	TableDef    *contract.Table     `json:"tableDefinition,omitempty"`
+	FieldDef    *contract.Field     `json:"fieldDefinition,omitempty"`
+	Resolvers   []contract.Resolver `json:"resolvers,omitempty"`
+	Value       any                 `json:"value,omitempty"`
+}
+
+// MigrationPlan is an ordered set of steps that moves a base schema to a target schema.
+// Base and Target are schema checksums, so a saved plan can be checked against the live schema
+// before it is applied.
+type MigrationPlan struct {
+	ID     string          `json:"id"`
+	Base   string          `json:"base"`
+	Target string          `json:"target"`
+	Steps  []MigrationStep `json:"steps"`
+	Schema contract.Schema `json:"schema"`
+}
+
+// SchemaChecksum returns a stable hash of the normalized schema.
+func SchemaChecksum(s contract.Schema) string {
+	data, _ := json.Marshal(contract.NormalizeSchema(s))
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// PlanMigration turns the diff between base and target into ordered steps: new tables, new
+// fields (always added nullable), backfills, nullability tightening, field changes, resolver
+// updates, then field and table drops. Data is never removed before the new shape exists.
+func PlanMigration(base, target contract.Schema) MigrationPlan {
+	diff := DiffSchemas(base, target)
+	normalizedTarget := contract.NormalizeSchema(target)
+
+	var adds, backfills, tightens, changes, resolvers, drops []MigrationStep
+
+	for _, t := range diff.AddedTables {
+		table := t
+		adds = append(adds, MigrationStep{Kind: StepAddTable, Table: t.Name, TableDef: &table,
+			Description: fmt.Sprintf("add table %s", t.Name)})
+	}
+
+	for _, td := range diff.TableDiffs {
+		for _, f := range td.AddedFields {
+			added := f
+			added.Nullable = true
+			adds = append(adds, MigrationStep{Kind: StepAddField, Table: td.Name, Field: f.Name, FieldDef: &added,
+				Description: fmt.Sprintf("add nullable field %s.%s (%s)", td.Name, f.Name, f.Type)})
+			if !f.Nullable {
+				backfills = append(backfills, backfillStep(td.Name, f))
+				tightens = append(tightens, tightenStep(td.Name, f))
+			}
+		}
+		for _, fd := range td.ModifiedFields {
+			to := fd.To
+			switch {
+			case fd.From.Nullable && !to.Nullable:
+				backfills = append(backfills, backfillStep(td.Name, to))
+				tightens = append(tightens, tightenStep(td.Name, to))
+			case !fd.From.Nullable && to.Nullable:
+				adds = append(adds, MigrationStep{Kind: StepRelaxNullability, Table: td.Name, Field: fd.Name,
+					Description: fmt.Sprintf("make %s.%s nullable", td.Name, fd.Name)})
+			}
+			from := fd.From
+			from.Nullable = to.Nullable
+			if !reflect.DeepEqual(from, to) {
+				changes = append(changes, MigrationStep{Kind: StepChangeField, Table: td.Name, Field: fd.Name, FieldDef: &to,
+					Description: fmt.Sprintf("change %s.%s (%s -> %s)", td.Name, fd.Name, fd.From.Type, to.Type)})
+			}
+		}
+		if len(td.AddedResolvers)+len(td.RemovedResolvers)+len(td.ModifiedResolvers) > 0 {
+			var defs []contract.Resolver
+			if t, ok := normalizedTarget.Table(td.Name); ok {
+				defs = t.Resolvers
+			}
+			resolvers = append(resolvers, MigrationStep{Kind: StepUpdateResolvers, Table: td.Name, Resolvers: defs,
+				Description: fmt.Sprintf("update resolvers on %s", td.Name)})
+		}
+		for _, f := range td.RemovedFields {
+			drops = append(drops, MigrationStep{Kind: StepDropField, Table: td.Name, Field: f.Name,
+				Description: fmt.Sprintf("drop field %s.%s", td.Name, f.Name)})
+		}
+	}
+
+	var tableDrops []MigrationStep
+	for _, t := range diff.RemovedTables {
+		tableDrops = append(tableDrops, MigrationStep{Kind: StepDropTable, Table: t.Name,
+			Description: fmt.Sprintf("drop table %s", t.Name)})
+	}
+
+	steps := []MigrationStep{}
+	for _, group := range [][]MigrationStep{adds, backfills, tightens, changes, resolvers, drops, tableDrops} {
+		steps = append(steps, group...)
+	}
+
+	plan := MigrationPlan{
+		Base:   SchemaChecksum(base),
+		Target: SchemaChecksum(target),
+		Steps:  steps,
+		Schema: normalizedTarget,
+	}
+	sum := sha256.Sum256([]byte(plan.Base + plan.Target))
+	plan.ID = hex.EncodeToString(sum[:6])
+	return plan
+}
+
+func backfillStep(table string, f contract.Field) MigrationStep {
+	desc := fmt.Sprintf("backfill %s.%s with %v", table, f.Name, f.Default)
+	if f.Default == nil {
+		desc = fmt.Sprintf("backfill %s.%s (no defaultValue; set one in the target schema)", table, f.Name)
+	}
+	return MigrationStep{Kind: StepBackfill, Table: table, Field: f.Name, Value: f.Default, Description: desc}
+}
+
+func tightenStep(table string, f contract.Field) MigrationStep {
+	return MigrationStep{Kind: StepTightenNullability, Table: table, Field: f.Name,
+		Description: fmt.Sprintf("make %s.%s non-nullable", table, f.Name)}
+}
+
+// ApplyMigrationStep returns a copy of s with the step applied. Backfill steps leave the schema
+// unchanged.
+func ApplyMigrationStep(s contract.Schema, step MigrationStep) (contract.Schema, error) {
+	out := contract.Schema{Tables: make([]contract.Table, len(s.Tables))}
+	copy(out.Tables, s.Tables)
+
+	tableIdx := -1
+	for i, t := range out.Tables {
+		if t.Name == step.Table {
+			tableIdx = i
+			break
+		}
+	}
+
+	switch step.Kind {
+	case StepAddTable:
+		if tableIdx >= 0 {
+			return s, fmt.Errorf("table %s already exists", step.Table)
+		}
+		if step.TableDef == nil {
+			return s, fmt.Errorf("add_table %s has no table definition", step.Table)
+		}
+		out.Tables = append(out.Tables, *step.TableDef)
+		return out, nil
+	case StepDropTable:
+		if tableIdx < 0 {
+			return s, fmt.Errorf("table %s not found", step.Table)
+		}
+		out.Tables = append(out.Tables[:tableIdx], out.Tables[tableIdx+1:]...)
+		return out, nil
+	case StepBackfill:
+		return out, nil
+	}
+
+	if tableIdx < 0 {
+		return s, fmt.Errorf("table %s not found", step.Table)
+	}
+	table := out.Tables[tableIdx]
+	fields := make([]contract.Field, len(table.Fields))
+	copy(fields, table.Fields)
+	fieldIdx := -1
+	for i, f := range fields {
+		if f.Name == step.Field {
+			fieldIdx = i
+			break
+		}
+	}
+
+	switch step.Kind {
+	case StepAddField:
+		if fieldIdx >= 0 {
+			return s, fmt.Errorf("field %s.%s already exists", step.Table, step.Field)
+		}
+		if step.FieldDef == nil {
+			return s, fmt.Errorf("add_field %s.%s has no field definition", step.Table, step.Field)
+		}
+		fields = append(fields, *step.FieldDef)
+	case StepUpdateResolvers:
+		table.Resolvers = step.Resolvers
+	default:
+		if fieldIdx < 0 {
+			return s, fmt.Errorf("field %s.%s not found", step.Table, step.Field)
+		}
+		switch step.Kind {
+		case StepRelaxNullability:
+			fields[fieldIdx].Nullable = true
+		case StepTightenNullability:
+			fields[fieldIdx].Nullable = false
+		case StepChangeField:
+			if step.FieldDef == nil {
+				return s, fmt.Errorf("change_field %s.%s has no field definition", step.Table, step.Field)
+			}
+			changed := *step.FieldDef
+			changed.Nullable = fields[fieldIdx].Nullable
+			fields[fieldIdx] = changed
+		case StepDropField:
+			fields = append(fields[:fieldIdx], fields[fieldIdx+1:]...)
+		default:
+			return s, fmt.Errorf("unknown migration step %q", step.Kind)
+		}
+	}
+
+	table.Fields = fields
+	out.Tables[tableIdx] = table
+	return out, nil
+}
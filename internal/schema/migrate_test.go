@@ -0,0 +1,110 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+func migrationFixtures() (contract.Schema, contract.Schema) {
+	base := contract.Schema{Tables: []contract.Table{
+		{Name: "User", Fields: []contract.Field{
+			{Name: "id", Type: "String", Primary: true},
+			{Name: "legacy", Type: "String", Nullable: true},
+			{Name: "nickname", Type: "String", Nullable: true},
+			{Name: "age", Type: "Int"},
+			{Name: "score", Type: "Int"},
+		}},
+		{Name: "Old", Fields: []contract.Field{{Name: "id", Type: "String"}}},
+	}}
+	target := contract.Schema{Tables: []contract.Table{
+		{Name: "User", Fields: []contract.Field{
+			{Name: "id", Type: "String", Primary: true},
+			{Name: "email", Type: "String", Default: "unknown@example.com"},
+			{Name: "bio", Type: "String", Nullable: true},
+			{Name: "nickname", Type: "String", Default: "anon"},
+			{Name: "age", Type: "Int", Nullable: true},
+			{Name: "score", Type: "Float"},
+		}, Resolvers: []contract.Resolver{{Name: "roles", Resolver: "db.from(\"Role\")"}}},
+		{Name: "Role", Fields: []contract.Field{{Name: "id", Type: "String", Primary: true}}},
+	}}
+	return base, target
+}
+
+func TestPlanMigrationOrdersSteps(t *testing.T) {
+	base, target := migrationFixtures()
+	plan := PlanMigration(base, target)
+
+	var got []string
+	for _, s := range plan.Steps {
+		got = append(got, string(s.Kind)+" "+s.Table+"."+s.Field)
+	}
+	want := []string{
+		"add_table Role.",
+		"add_field User.bio",
+		"add_field User.email",
+		"relax_nullability User.age",
+		"backfill User.email",
+		"backfill User.nickname",
+		"tighten_nullability User.email",
+		"tighten_nullability User.nickname",
+		"change_field User.nickname",
+		"change_field User.score",
+		"update_resolvers User.",
+		"drop_field User.legacy",
+		"drop_table Old.",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected plan:\n got %v\nwant %v", got, want)
+	}
+	if !plan.Steps[2].FieldDef.Nullable || plan.Steps[5].Value != "anon" {
+		t.Fatalf("expected nullable add and default backfill: %+v %+v", plan.Steps[2], plan.Steps[5])
+	}
+	if again := PlanMigration(base, target); again.ID != plan.ID || plan.ID == "" {
+		t.Fatalf("expected a stable plan id, got %q and %q", plan.ID, again.ID)
+	}
+}
+
+func TestApplyMigrationStepsReachTarget(t *testing.T) {
+	base, target := migrationFixtures()
+	plan := PlanMigration(base, target)
+
+	current := base
+	for _, step := range plan.Steps {
+		next, err := ApplyMigrationStep(current, step)
+		if err != nil {
+			t.Fatalf("apply %s: %v", step.Description, err)
+		}
+		current = next
+	}
+	if SchemaChecksum(current) != plan.Target {
+		t.Fatalf("applying every step should reach the target, diff: %+v", DiffSchemas(current, target))
+	}
+	if SchemaChecksum(base) != plan.Base {
+		t.Fatalf("applying steps must not mutate the base schema")
+	}
+}
+
+func TestApplyMigrationStepErrors(t *testing.T) {
+	base, _ := migrationFixtures()
+	cases := []MigrationStep{
+		{Kind: StepAddTable, Table: "User", TableDef: &contract.Table{Name: "User"}},
+		{Kind: StepAddTable, Table: "New"},
+		{Kind: StepDropTable, Table: "Ghost"},
+		{Kind: StepAddField, Table: "Ghost", Field: "x"},
+		{Kind: StepAddField, Table: "User", Field: "age", FieldDef: &contract.Field{Name: "age"}},
+		{Kind: StepAddField, Table: "User", Field: "x"},
+		{Kind: StepDropField, Table: "User", Field: "ghost"},
+		{Kind: StepChangeField, Table: "User", Field: "age"},
+		{Kind: "rename", Table: "User", Field: "age"},
+	}
+	for _, step := range cases {
+		if _, err := ApplyMigrationStep(base, step); err == nil {
+			t.Fatalf("expected error for %+v", step)
+		}
+	}
+	if _, err := ApplyMigrationStep(base, MigrationStep{Kind: StepBackfill, Table: "User", Field: "age"}); err != nil {
+		t.Fatalf("backfill should not change the schema: %v", err)
+	}
+}
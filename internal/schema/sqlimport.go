@@ -0,0 +1,1096 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+// SQLWarning reports a construct ImportSQL skipped or could only approximate.
+type SQLWarning struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+func (w SQLWarning) String() string { return fmt.Sprintf("line %d: %s", w.Line, w.Message) }
+
+// ImportSQL translates Postgres or MySQL DDL into a schema. It reads CREATE TABLE, CREATE INDEX
+// and ALTER TABLE ... ADD CONSTRAINT (plus the ALTER COLUMN forms pg_dump writes) and maps:
+//
+//   - column types to Onyx types: varchar(n) becomes String with maxSize n, integer types
+//     become Byte, Short, Int or Long, timestamps become Timestamp, json becomes
+//     EmbeddedObject and arrays EmbeddedList;
+//   - PRIMARY KEY, UNIQUE and NOT NULL to the field flags, serial and identity columns to the
+//     Sequence generator and uuid defaults such as gen_random_uuid() to UUID;
+//   - literal defaults to defaultValue;
+//   - each single-column foreign key to a pair of suggested resolvers: one on the referencing
+//     table returning the referenced row, and one on the referenced table listing the
+//     referencing rows.
+//
+// Names are kept as written, without schema qualifiers. Everything else (views, functions,
+// checks, composite keys, expression indexes, computed defaults) is skipped with a warning.
+// The error is only set when the input cannot be tokenized.
+func ImportSQL(src string) (contract.Schema, []SQLWarning, error) {
+	toks, err := tokenizeSQL(src)
+	if err != nil {
+		return contract.Schema{}, nil, err
+	}
+	imp := &sqlImporter{tables: map[string]*contract.Table{}, lines: map[string]int{}}
+	var stmt []sqlToken
+	for _, tok := range toks {
+		if tok.is(";") {
+			imp.statement(stmt)
+			stmt = nil
+			continue
+		}
+		stmt = append(stmt, tok)
+	}
+	imp.statement(stmt)
+	imp.finish()
+
+	s := contract.Schema{}
+	for _, key := range imp.order {
+		s.Tables = append(s.Tables, *imp.tables[key])
+	}
+	return contract.NormalizeSchema(s), imp.warnings, nil
+}
+
+type sqlTokenKind int
+
+const (
+	sqlWord sqlTokenKind = iota
+	sqlIdent
+	sqlString
+	sqlNumber
+	sqlPunct
+)
+
+type sqlToken struct {
+	kind sqlTokenKind
+	text string
+	line int
+}
+
+// is reports whether the token is the punctuation p.
+func (t sqlToken) is(p string) bool { return t.kind == sqlPunct && t.text == p }
+
+// word reports whether the token is the unquoted keyword w, case-insensitively.
+func (t sqlToken) word(w string) bool { return t.kind == sqlWord && strings.EqualFold(t.text, w) }
+
+func tokenizeSQL(src string) ([]sqlToken, error) {
+	var toks []sqlToken
+	runes := []rune(src)
+	line := 1
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == '\n':
+			line++
+			i++
+		case unicode.IsSpace(r):
+			i++
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-', r == '#':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			start := line
+			i += 2
+			for i < len(runes) && !(runes[i] == '*' && i+1 < len(runes) && runes[i+1] == '/') {
+				if runes[i] == '\n' {
+					line++
+				}
+				i++
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("line %d: unterminated comment", start)
+			}
+			i += 2
+		case r == '\'' || r == '"' || r == '`':
+			start := line
+			var b strings.Builder
+			i++
+			for {
+				if i >= len(runes) {
+					return nil, fmt.Errorf("line %d: unterminated quoted text", start)
+				}
+				if runes[i] == r {
+					if i+1 < len(runes) && runes[i+1] == r {
+						b.WriteRune(r)
+						i += 2
+						continue
+					}
+					i++
+					break
+				}
+				if runes[i] == '\n' {
+					line++
+				}
+				b.WriteRune(runes[i])
+				i++
+			}
+			kind := sqlIdent
+			if r == '\'' {
+				kind = sqlString
+			}
+			toks = append(toks, sqlToken{kind: kind, text: b.String(), line: start})
+		case r == '$' && dollarTag(runes, i) != "":
+			tag := dollarTag(runes, i)
+			start := line
+			body := i + len([]rune(tag))
+			end := strings.Index(string(runes[body:]), tag)
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated %s string", start, tag)
+			}
+			text := []rune(string(runes[body:])[:end])
+			line += strings.Count(string(text), "\n")
+			toks = append(toks, sqlToken{kind: sqlString, text: string(text), line: start})
+			i = body + len(text) + len([]rune(tag))
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			j := i
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.' ||
+				((runes[j] == 'e' || runes[j] == 'E') && j+1 < len(runes) && (unicode.IsDigit(runes[j+1]) || runes[j+1] == '-' || runes[j+1] == '+')) ||
+				((runes[j] == '-' || runes[j] == '+') && j > i && (runes[j-1] == 'e' || runes[j-1] == 'E'))) {
+				j++
+			}
+			toks = append(toks, sqlToken{kind: sqlNumber, text: string(runes[i:j]), line: line})
+			i = j
+		case unicode.IsLetter(r) || r == '_':
+			j := i
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_' || runes[j] == '$') {
+				j++
+			}
+			toks = append(toks, sqlToken{kind: sqlWord, text: string(runes[i:j]), line: line})
+			i = j
+		default:
+			toks = append(toks, sqlToken{kind: sqlPunct, text: string(r), line: line})
+			i++
+		}
+	}
+	return toks, nil
+}
+
+// dollarTag returns the Postgres dollar-quote opener ($$ or $tag$) starting at i, if any.
+func dollarTag(runes []rune, i int) string {
+	for j := i + 1; j < len(runes); j++ {
+		if runes[j] == '$' {
+			return string(runes[i : j+1])
+		}
+		if !unicode.IsLetter(runes[j]) && !unicode.IsDigit(runes[j]) && runes[j] != '_' {
+			return ""
+		}
+	}
+	return ""
+}
+
+// sqlParser walks the tokens of one statement or clause.
+type sqlParser struct {
+	toks []sqlToken
+	pos  int
+}
+
+func (p *sqlParser) done() bool { return p.pos >= len(p.toks) }
+
+func (p *sqlParser) peek() sqlToken {
+	if p.done() {
+		return sqlToken{kind: sqlPunct}
+	}
+	return p.toks[p.pos]
+}
+
+func (p *sqlParser) next() sqlToken {
+	tok := p.peek()
+	if !p.done() {
+		p.pos++
+	}
+	return tok
+}
+
+// line is the line of the current token, or of the last one at the end.
+func (p *sqlParser) line() int {
+	if p.done() {
+		if len(p.toks) == 0 {
+			return 0
+		}
+		return p.toks[len(p.toks)-1].line
+	}
+	return p.toks[p.pos].line
+}
+
+// accept consumes the keyword sequence when the tokens match it.
+func (p *sqlParser) accept(words ...string) bool {
+	for i, w := range words {
+		if p.pos+i >= len(p.toks) || !p.toks[p.pos+i].word(w) {
+			return false
+		}
+	}
+	p.pos += len(words)
+	return true
+}
+
+func (p *sqlParser) acceptPunct(s string) bool {
+	if p.peek().is(s) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// name reads a possibly qualified name and returns its last part.
+func (p *sqlParser) name() (string, bool) {
+	tok := p.peek()
+	if tok.kind != sqlWord && tok.kind != sqlIdent {
+		return "", false
+	}
+	p.pos++
+	name := tok.text
+	for p.peek().is(".") && p.pos+1 < len(p.toks) && (p.toks[p.pos+1].kind == sqlWord || p.toks[p.pos+1].kind == sqlIdent) {
+		name = p.toks[p.pos+1].text
+		p.pos += 2
+	}
+	return name, true
+}
+
+// group consumes a parenthesized group and returns the tokens inside it.
+func (p *sqlParser) group() ([]sqlToken, bool) {
+	if !p.peek().is("(") {
+		return nil, false
+	}
+	start := p.pos + 1
+	depth := 0
+	for !p.done() {
+		tok := p.next()
+		switch {
+		case tok.is("("):
+			depth++
+		case tok.is(")"):
+			depth--
+			if depth == 0 {
+				return p.toks[start : p.pos-1], true
+			}
+		}
+	}
+	return p.toks[start:], false
+}
+
+// splitTop splits tokens on commas outside parentheses.
+func splitTop(toks []sqlToken) [][]sqlToken {
+	var parts [][]sqlToken
+	depth, start := 0, 0
+	for i, tok := range toks {
+		switch {
+		case tok.is("("):
+			depth++
+		case tok.is(")"):
+			depth--
+		case tok.is(",") && depth == 0:
+			parts = append(parts, toks[start:i])
+			start = i + 1
+		}
+	}
+	if start < len(toks) {
+		parts = append(parts, toks[start:])
+	}
+	return parts
+}
+
+// columnList reads "(a, b)". Sort orders, prefix lengths and operator classes are dropped; an
+// entry that is not a plain column makes ok false.
+func (p *sqlParser) columnList() (cols []string, ok bool) {
+	inner, closed := p.group()
+	if !closed {
+		return nil, false
+	}
+	for _, part := range splitTop(inner) {
+		sub := &sqlParser{toks: part}
+		col, isName := sub.name()
+		if !isName || sub.peek().is(".") {
+			return nil, false
+		}
+		if sub.peek().is("(") {
+			// MySQL prefix length: name(10).
+			if g, _ := sub.group(); len(g) != 1 || g[0].kind != sqlNumber {
+				return nil, false
+			}
+		}
+		for !sub.done() {
+			tok := sub.next()
+			if tok.kind != sqlWord {
+				return nil, false
+			}
+		}
+		cols = append(cols, col)
+	}
+	return cols, len(cols) > 0
+}
+
+type foreignKey struct {
+	line                    int
+	table, column           string
+	refTable, refColumn     string
+	composite, unknownTable bool
+}
+
+type sqlImporter struct {
+	tables   map[string]*contract.Table
+	order    []string
+	lines    map[string]int
+	fks      []foreignKey
+	warnings []SQLWarning
+}
+
+func (imp *sqlImporter) warn(line int, format string, args ...any) {
+	imp.warnings = append(imp.warnings, SQLWarning{Line: line, Message: fmt.Sprintf(format, args...)})
+}
+
+func (imp *sqlImporter) table(name string) *contract.Table {
+	return imp.tables[strings.ToLower(name)]
+}
+
+func (imp *sqlImporter) statement(toks []sqlToken) {
+	if len(toks) == 0 {
+		return
+	}
+	p := &sqlParser{toks: toks}
+	line := p.line()
+	switch {
+	case p.accept("create"):
+		p.accept("or", "replace")
+		for p.accept("temporary") || p.accept("temp") || p.accept("unlogged") || p.accept("global") || p.accept("local") {
+		}
+		switch {
+		case p.accept("table"):
+			imp.createTable(p, line)
+		case p.accept("unique", "index"):
+			imp.createIndex(p, line, true)
+		case p.accept("index"):
+			imp.createIndex(p, line, false)
+		default:
+			imp.warn(line, "skipped unsupported statement CREATE %s", strings.ToUpper(p.peek().text))
+		}
+	case p.accept("alter", "table"):
+		imp.alterTable(p, line)
+	case p.accept("set"), p.accept("begin"), p.accept("commit"), p.accept("start"), p.accept("use"):
+		// Session and transaction control carries no schema.
+	default:
+		imp.warn(line, "skipped unsupported statement %s", strings.ToUpper(toks[0].text))
+	}
+}
+
+func (imp *sqlImporter) createTable(p *sqlParser, line int) {
+	p.accept("if", "not", "exists")
+	name, ok := p.name()
+	if !ok {
+		imp.warn(line, "skipped CREATE TABLE without a table name")
+		return
+	}
+	if imp.table(name) != nil {
+		imp.warn(line, "skipped duplicate CREATE TABLE %s", name)
+		return
+	}
+	body, closed := p.group()
+	if !closed {
+		imp.warn(line, "skipped CREATE TABLE %s: only column lists are supported", name)
+		return
+	}
+
+	t := &contract.Table{Name: name}
+	imp.tables[strings.ToLower(name)] = t
+	imp.order = append(imp.order, strings.ToLower(name))
+	imp.lines[strings.ToLower(name)] = line
+	for _, elem := range splitTop(body) {
+		if len(elem) == 0 {
+			continue
+		}
+		ep := &sqlParser{toks: elem}
+		if isTableConstraint(ep) {
+			imp.tableConstraint(t, ep)
+		} else {
+			imp.column(t, ep)
+		}
+	}
+
+	for !p.done() {
+		tok := p.next()
+		switch {
+		case tok.word("partition") && p.peek().word("by"):
+			imp.warn(tok.line, "table %s: partitioning is not imported; set the partition field by hand", name)
+		case tok.word("inherits"):
+			imp.warn(tok.line, "table %s: inheritance is not imported", name)
+		}
+	}
+}
+
+func isTableConstraint(p *sqlParser) bool {
+	tok := p.peek()
+	if tok.kind != sqlWord {
+		return false
+	}
+	switch strings.ToLower(tok.text) {
+	case "constraint", "primary", "foreign", "check", "exclude", "key", "index", "fulltext", "spatial", "like":
+		return true
+	case "unique":
+		// A column named unique would be quoted; UNIQUE here starts a constraint.
+		return true
+	}
+	return false
+}
+
+func (imp *sqlImporter) tableConstraint(t *contract.Table, p *sqlParser) {
+	line := p.line()
+	constraint := ""
+	if p.accept("constraint") {
+		constraint, _ = p.name()
+	}
+	switch {
+	case p.accept("primary", "key"):
+		cols, ok := p.columnList()
+		if !ok {
+			imp.warn(line, "table %s: skipped primary key on expressions", t.Name)
+			return
+		}
+		imp.setPrimaryKey(t, cols, line)
+	case p.accept("unique"):
+		if !p.accept("key") {
+			p.accept("index")
+		}
+		idxName := constraint
+		if !p.peek().is("(") {
+			idxName, _ = p.name()
+		}
+		cols, ok := p.columnList()
+		if !ok {
+			imp.warn(line, "table %s: skipped unique constraint on expressions", t.Name)
+			return
+		}
+		imp.addUnique(t, idxName, cols, line)
+	case p.accept("foreign", "key"):
+		cols, ok := p.columnList()
+		if !ok || !p.accept("references") {
+			imp.warn(line, "table %s: skipped malformed foreign key", t.Name)
+			return
+		}
+		imp.references(t, cols, p, line)
+	case p.accept("fulltext"):
+		if !p.accept("key") {
+			p.accept("index")
+		}
+		imp.keyIndex(t, p, "LUCENE", line)
+	case p.accept("key"), p.accept("index"):
+		imp.keyIndex(t, p, "", line)
+	case p.accept("check"):
+		imp.warn(line, "table %s: CHECK constraints are not imported", t.Name)
+	default:
+		imp.warn(line, "table %s: skipped unsupported table element %s", t.Name, strings.ToUpper(p.peek().text))
+	}
+}
+
+// keyIndex reads MySQL's inline KEY/INDEX [name] [USING method] (cols).
+func (imp *sqlImporter) keyIndex(t *contract.Table, p *sqlParser, indexType string, line int) {
+	name := ""
+	if !p.peek().is("(") {
+		name, _ = p.name()
+	}
+	if p.accept("using") {
+		p.next()
+	}
+	cols, ok := p.columnList()
+	if !ok {
+		imp.warn(line, "table %s: skipped index on expressions", t.Name)
+		return
+	}
+	imp.addIndex(t, name, indexType, cols)
+}
+
+func (imp *sqlImporter) column(t *contract.Table, p *sqlParser) {
+	line := p.line()
+	name, ok := p.name()
+	if !ok {
+		imp.warn(line, "table %s: skipped unreadable column definition", t.Name)
+		return
+	}
+	typ, ok := imp.columnType(p, line, t.Name+"."+name)
+	if !ok {
+		return
+	}
+	f := contract.Field{Name: name, Type: typ.onyx, Nullable: true, MaxSize: typ.maxSize, Generator: typ.generator}
+	primary := false
+	var defaultGenerator string
+
+	for !p.done() {
+		tok := p.peek()
+		switch {
+		case p.accept("constraint"):
+			p.name()
+		case p.accept("not", "null"):
+			f.Nullable = false
+		case p.accept("null"):
+			f.Nullable = true
+		case p.accept("primary", "key"):
+			primary = true
+			p.accept("asc")
+			p.accept("desc")
+		case p.accept("unique"):
+			if !p.accept("key") {
+				p.accept("index")
+			}
+			f.Unique = true
+		case p.accept("default"):
+			value, generator, ok := imp.defaultValue(p, f.Type, t.Name+"."+name)
+			if ok {
+				f.Default = value
+			}
+			defaultGenerator = generator
+		case p.accept("references"):
+			imp.references(t, []string{name}, p, tok.line)
+		case p.accept("auto_increment"), p.accept("autoincrement"):
+			f.Generator = "Sequence"
+		case p.accept("generated"):
+			if p.accept("always", "as", "identity") || p.accept("by", "default", "as", "identity") {
+				f.Generator = "Sequence"
+				p.group()
+				continue
+			}
+			imp.warn(tok.line, "column %s.%s: computed columns are not imported; the value is stored as written", t.Name, name)
+			p.accept("always")
+			p.accept("as")
+			p.group()
+			p.accept("stored")
+			p.accept("virtual")
+		case p.accept("identity"):
+			f.Generator = "Sequence"
+			p.group()
+		case p.accept("check"):
+			p.group()
+			imp.warn(tok.line, "column %s.%s: CHECK constraints are not imported", t.Name, name)
+		case p.accept("collate"), p.accept("charset"), p.accept("character", "set"), p.accept("comment"):
+			p.next()
+		case p.accept("on", "update"):
+			imp.skipExpression(p)
+			imp.warn(tok.line, "column %s.%s: ON UPDATE defaults are not imported", t.Name, name)
+		default:
+			imp.warn(tok.line, "column %s.%s: ignored unsupported option %s", t.Name, name, strings.ToUpper(tok.text))
+			p.pos = len(p.toks)
+		}
+	}
+
+	if f.Generator == "" {
+		f.Generator = defaultGenerator
+	}
+	if primary {
+		f.Nullable = false
+	}
+	t.Fields = append(t.Fields, f)
+	if primary {
+		imp.setPrimaryKey(t, []string{name}, line)
+	}
+}
+
+type sqlColumnType struct {
+	onyx      string
+	maxSize   int
+	generator string
+}
+
+// typeWords may follow the first word of a type name.
+var typeWords = map[string]bool{
+	"precision": true, "varying": true, "with": true, "without": true, "time": true, "zone": true,
+	"unsigned": true, "signed": true, "zerofill": true, "large": true, "object": true,
+}
+
+// columnType reads a column type and maps it to an Onyx type. ok is false when the column
+// cannot be imported at all.
+func (imp *sqlImporter) columnType(p *sqlParser, line int, column string) (sqlColumnType, bool) {
+	base, args, array := readType(p)
+	if base == "" {
+		imp.warn(line, "column %s: skipped column without a type", column)
+		return sqlColumnType{}, false
+	}
+	if array {
+		return sqlColumnType{onyx: "EmbeddedList"}, true
+	}
+
+	size := 0
+	if len(args) > 0 {
+		size, _ = strconv.Atoi(args[0])
+	}
+	switch base {
+	case "varchar", "character varying", "nvarchar", "varchar2", "nvarchar2", "string":
+		return sqlColumnType{onyx: "String", maxSize: size}, true
+	case "char", "character", "nchar", "bpchar":
+		if size <= 1 {
+			return sqlColumnType{onyx: "Char"}, true
+		}
+		return sqlColumnType{onyx: "String", maxSize: size}, true
+	case "text", "tinytext", "mediumtext", "longtext", "citext", "clob", "ntext", "uuid", "uniqueidentifier",
+		"inet", "cidr", "macaddr", "xml", "enum", "set", "interval", "money":
+		return sqlColumnType{onyx: "String"}, true
+	case "boolean", "bool":
+		return sqlColumnType{onyx: "Boolean"}, true
+	case "bit":
+		if size <= 1 {
+			return sqlColumnType{onyx: "Boolean"}, true
+		}
+		imp.warn(line, "column %s: bit(%d) imported as Long", column, size)
+		return sqlColumnType{onyx: "Long"}, true
+	case "tinyint":
+		if size == 1 {
+			return sqlColumnType{onyx: "Boolean"}, true
+		}
+		return sqlColumnType{onyx: "Byte"}, true
+	case "smallint", "int2":
+		return sqlColumnType{onyx: "Short"}, true
+	case "smallserial", "serial2":
+		return sqlColumnType{onyx: "Short", generator: "Sequence"}, true
+	case "int", "integer", "int4", "mediumint":
+		return sqlColumnType{onyx: "Int"}, true
+	case "serial", "serial4":
+		return sqlColumnType{onyx: "Int", generator: "Sequence"}, true
+	case "bigint", "int8":
+		return sqlColumnType{onyx: "Long"}, true
+	case "bigserial", "serial8":
+		return sqlColumnType{onyx: "Long", generator: "Sequence"}, true
+	case "real", "float4":
+		return sqlColumnType{onyx: "Float"}, true
+	case "float", "float8", "double", "double precision":
+		return sqlColumnType{onyx: "Double"}, true
+	case "numeric", "decimal", "number":
+		if len(args) >= 1 && (len(args) == 1 || args[1] == "0") && size > 0 && size <= 18 {
+			return sqlColumnType{onyx: "Long"}, true
+		}
+		imp.warn(line, "column %s: %s imported as Double; exact precision is lost", column, base)
+		return sqlColumnType{onyx: "Double"}, true
+	case "date":
+		return sqlColumnType{onyx: "Date"}, true
+	case "timestamp", "timestamptz", "datetime", "datetime2", "smalldatetime", "datetimeoffset",
+		"timestamp with time zone", "timestamp without time zone":
+		return sqlColumnType{onyx: "Timestamp"}, true
+	case "time", "timetz", "time with time zone", "time without time zone":
+		imp.warn(line, "column %s: time of day imported as String", column)
+		return sqlColumnType{onyx: "String"}, true
+	case "json", "jsonb":
+		return sqlColumnType{onyx: "EmbeddedObject"}, true
+	case "bytea", "blob", "tinyblob", "mediumblob", "longblob", "binary", "varbinary", "image":
+		imp.warn(line, "column %s: binary type %s imported as String; store it encoded", column, base)
+		return sqlColumnType{onyx: "String"}, true
+	}
+	imp.warn(line, "column %s: unknown type %s imported as String", column, base)
+	return sqlColumnType{onyx: "String"}, true
+}
+
+// readType consumes a type name, its arguments and any array suffix. The base is lowercased
+// with modifiers such as unsigned removed.
+func readType(p *sqlParser) (base string, args []string, array bool) {
+	var words []string
+	tok := p.peek()
+	if tok.kind != sqlWord && tok.kind != sqlIdent {
+		return "", nil, false
+	}
+	if name, _ := p.name(); name != "" {
+		words = append(words, strings.ToLower(name))
+	}
+	for {
+		switch {
+		case p.peek().kind == sqlWord && typeWords[strings.ToLower(p.peek().text)]:
+			words = append(words, strings.ToLower(p.next().text))
+		case p.peek().is("(") && args == nil:
+			inner, _ := p.group()
+			args = []string{}
+			for _, part := range splitTop(inner) {
+				if len(part) > 0 {
+					args = append(args, part[0].text)
+				}
+			}
+		case p.peek().is("["):
+			p.next()
+			p.acceptPunct("]")
+			array = true
+		case p.accept("array"):
+			array = true
+		default:
+			var kept []string
+			for _, w := range words {
+				if w != "unsigned" && w != "signed" && w != "zerofill" {
+					kept = append(kept, w)
+				}
+			}
+			return strings.Join(kept, " "), args, array
+		}
+	}
+}
+
+// defaultValue reads a DEFAULT clause. Literals become values of the field type; the uuid and
+// sequence functions become generators; other expressions are skipped with a warning.
+func (imp *sqlImporter) defaultValue(p *sqlParser, fieldType, column string) (value any, generator string, ok bool) {
+	line := p.line()
+	start := p.pos
+	imp.skipExpression(p)
+	expr := p.toks[start:p.pos]
+
+	// Unwrap (literal) and drop ::type casts.
+	for len(expr) >= 2 && expr[0].is("(") && expr[len(expr)-1].is(")") {
+		expr = expr[1 : len(expr)-1]
+	}
+	for i := 0; i+1 < len(expr); i++ {
+		if expr[i].is(":") && expr[i+1].is(":") {
+			expr = expr[:i]
+			break
+		}
+	}
+
+	negative := false
+	if len(expr) == 2 && expr[0].is("-") && expr[1].kind == sqlNumber {
+		negative, expr = true, expr[1:]
+	}
+	if len(expr) == 1 {
+		tok := expr[0]
+		switch {
+		case tok.word("null"):
+			return nil, "", false
+		case tok.kind == sqlString || tok.kind == sqlNumber || tok.word("true") || tok.word("false"):
+			text := tok.text
+			if negative {
+				text = "-" + text
+			}
+			if v, ok := literalValue(tok.kind, text, fieldType); ok {
+				return v, "", true
+			}
+		}
+	}
+	if len(expr) >= 1 && expr[0].kind == sqlWord {
+		switch fn := strings.ToLower(expr[0].text); fn {
+		case "gen_random_uuid", "uuid_generate_v4", "uuid_generate_v1", "uuid", "newid", "uuid_to_bin":
+			return nil, "UUID", false
+		case "nextval":
+			return nil, "Sequence", false
+		}
+	}
+	text := make([]string, 0, len(expr))
+	for _, tok := range expr {
+		text = append(text, tok.text)
+	}
+	imp.warn(line, "column %s: default %s is not imported", column, strings.Join(text, ""))
+	return nil, "", false
+}
+
+// skipExpression consumes tokens up to the next column option keyword at the top level.
+func (imp *sqlImporter) skipExpression(p *sqlParser) {
+	first := true
+	for !p.done() {
+		tok := p.peek()
+		if !first && tok.kind == sqlWord && columnOptionWords[strings.ToLower(tok.text)] {
+			return
+		}
+		first = false
+		if tok.is("(") {
+			p.group()
+			continue
+		}
+		p.next()
+	}
+}
+
+var columnOptionWords = map[string]bool{
+	"not": true, "null": true, "primary": true, "unique": true, "default": true, "references": true,
+	"auto_increment": true, "autoincrement": true, "generated": true, "identity": true, "check": true,
+	"collate": true, "comment": true, "constraint": true, "on": true, "charset": true, "character": true,
+}
+
+func literalValue(kind sqlTokenKind, text, fieldType string) (any, bool) {
+	lower := strings.ToLower(text)
+	switch fieldType {
+	case "Boolean":
+		switch lower {
+		case "true", "t", "1", "yes", "y":
+			return true, true
+		case "false", "f", "0", "no", "n":
+			return false, true
+		}
+		return nil, false
+	case "Byte", "Short", "Int", "Long":
+		n, err := strconv.ParseInt(text, 10, 64)
+		return n, err == nil
+	case "Float", "Double":
+		n, err := strconv.ParseFloat(text, 64)
+		return n, err == nil
+	case "String", "Char", "Date", "Timestamp":
+		if kind == sqlString || kind == sqlNumber {
+			return text, true
+		}
+	}
+	return nil, false
+}
+
+func (imp *sqlImporter) createIndex(p *sqlParser, line int, unique bool) {
+	p.accept("concurrently")
+	p.accept("if", "not", "exists")
+	name := ""
+	if !p.peek().word("on") {
+		name, _ = p.name()
+	}
+	if !p.accept("on") {
+		imp.warn(line, "skipped CREATE INDEX without ON")
+		return
+	}
+	p.accept("only")
+	tableName, _ := p.name()
+	t := imp.table(tableName)
+	if t == nil {
+		imp.warn(line, "skipped index %s on unknown table %s", name, tableName)
+		return
+	}
+	indexType := ""
+	if p.accept("using") {
+		if method := strings.ToLower(p.next().text); method == "gin" || method == "gist" {
+			indexType = "LUCENE"
+		}
+	}
+	cols, ok := p.columnList()
+	if !ok {
+		imp.warn(line, "table %s: skipped index %s on expressions", t.Name, name)
+		return
+	}
+	for !p.done() {
+		if tok := p.next(); tok.word("where") {
+			imp.warn(tok.line, "table %s: partial index %s imported without its WHERE clause", t.Name, name)
+			break
+		}
+	}
+	if unique {
+		imp.addUnique(t, name, cols, line)
+		return
+	}
+	imp.addIndex(t, name, indexType, cols)
+}
+
+func (imp *sqlImporter) alterTable(p *sqlParser, line int) {
+	p.accept("if", "exists")
+	p.accept("only")
+	name, _ := p.name()
+	t := imp.table(name)
+	if t == nil {
+		imp.warn(line, "skipped ALTER TABLE on unknown table %s", name)
+		return
+	}
+	for _, action := range splitTop(p.toks[p.pos:]) {
+		ap := &sqlParser{toks: action}
+		actionLine := ap.line()
+		switch {
+		case ap.accept("add"):
+			if ap.accept("column") || !isTableConstraint(ap) {
+				ap.accept("if", "not", "exists")
+				imp.column(t, ap)
+				continue
+			}
+			imp.tableConstraint(t, ap)
+		case ap.accept("alter"):
+			ap.accept("column")
+			col, _ := ap.name()
+			imp.alterColumn(t, col, ap, actionLine)
+		default:
+			imp.warn(actionLine, "table %s: skipped unsupported ALTER TABLE action %s", t.Name, strings.ToUpper(ap.peek().text))
+		}
+	}
+}
+
+func (imp *sqlImporter) alterColumn(t *contract.Table, col string, p *sqlParser, line int) {
+	f := findField(t, col)
+	if f == nil {
+		imp.warn(line, "table %s: skipped ALTER COLUMN on unknown column %s", t.Name, col)
+		return
+	}
+	switch {
+	case p.accept("set", "not", "null"):
+		f.Nullable = false
+	case p.accept("drop", "not", "null"):
+		f.Nullable = true
+	case p.accept("set", "default"):
+		value, generator, ok := imp.defaultValue(p, f.Type, t.Name+"."+col)
+		if ok {
+			f.Default = value
+		}
+		if generator != "" {
+			f.Generator = generator
+		}
+	case p.accept("add", "generated"):
+		f.Generator = "Sequence"
+	default:
+		imp.warn(line, "table %s: skipped unsupported ALTER COLUMN %s action", t.Name, col)
+	}
+}
+
+func (imp *sqlImporter) references(t *contract.Table, cols []string, p *sqlParser, line int) {
+	refTable, _ := p.name()
+	var refCols []string
+	if p.peek().is("(") {
+		refCols, _ = p.columnList()
+	}
+	// ON DELETE/UPDATE actions, MATCH and DEFERRABLE clauses do not affect the import.
+	for !p.done() && !(p.peek().kind == sqlWord && columnOptionWords[strings.ToLower(p.peek().text)] && !p.peek().word("on")) {
+		p.next()
+	}
+	fk := foreignKey{line: line, table: t.Name, refTable: refTable, composite: len(cols) != 1 || len(refCols) > 1}
+	if len(cols) > 0 {
+		fk.column = cols[0]
+	}
+	if len(refCols) == 1 {
+		fk.refColumn = refCols[0]
+	}
+	imp.fks = append(imp.fks, fk)
+}
+
+func (imp *sqlImporter) setPrimaryKey(t *contract.Table, cols []string, line int) {
+	if len(cols) > 1 {
+		imp.warn(line, "table %s: composite primary key (%s) is not supported; add a single key field", t.Name, strings.Join(cols, ", "))
+		return
+	}
+	f := findField(t, cols[0])
+	if f == nil {
+		imp.warn(line, "table %s: primary key names unknown column %s", t.Name, cols[0])
+		return
+	}
+	for i := range t.Fields {
+		if t.Fields[i].Primary && t.Fields[i].Name != f.Name {
+			imp.warn(line, "table %s: second primary key %s ignored", t.Name, f.Name)
+			return
+		}
+	}
+	f.Primary = true
+	f.Nullable = false
+}
+
+func (imp *sqlImporter) addUnique(t *contract.Table, name string, cols []string, line int) {
+	if len(cols) == 1 {
+		if f := findField(t, cols[0]); f != nil {
+			f.Unique = true
+			return
+		}
+		imp.warn(line, "table %s: unique constraint names unknown column %s", t.Name, cols[0])
+		return
+	}
+	imp.warn(line, "table %s: composite unique constraint (%s) imported as a non-unique index", t.Name, strings.Join(cols, ", "))
+	imp.addIndex(t, name, "", cols)
+}
+
+// addIndex records an index. Single-column indexes follow the Onyx convention of naming the
+// index after its field.
+func (imp *sqlImporter) addIndex(t *contract.Table, name, indexType string, cols []string) {
+	idx := contract.Index{Name: cols[0], Type: indexType}
+	if len(cols) > 1 {
+		if name == "" {
+			name = t.Name + "_" + strings.Join(cols, "_") + "_idx"
+		}
+		idx = contract.Index{Name: name, Type: indexType, Fields: cols}
+	}
+	for _, existing := range t.Indexes {
+		if existing.Name == idx.Name {
+			return
+		}
+	}
+	t.Indexes = append(t.Indexes, idx)
+}
+
+func findField(t *contract.Table, name string) *contract.Field {
+	for i := range t.Fields {
+		if strings.EqualFold(t.Fields[i].Name, name) {
+			return &t.Fields[i]
+		}
+	}
+	return nil
+}
+
+// finish turns foreign keys into suggested resolvers and flags tables without a primary key.
+func (imp *sqlImporter) finish() {
+	for _, fk := range imp.fks {
+		child := imp.table(fk.table)
+		parent := imp.table(fk.refTable)
+		switch {
+		case fk.composite:
+			imp.warn(fk.line, "table %s: composite foreign key to %s has no resolver suggestion", fk.table, fk.refTable)
+			continue
+		case parent == nil:
+			imp.warn(fk.line, "table %s: foreign key %s references unknown table %s", fk.table, fk.column, fk.refTable)
+			continue
+		}
+		refColumn := fk.refColumn
+		if refColumn == "" {
+			refColumn = primaryKeyName(parent)
+		}
+		if refColumn == "" {
+			imp.warn(fk.line, "table %s: foreign key %s references %s, which has no primary key", fk.table, fk.column, parent.Name)
+			continue
+		}
+
+		imp.addResolver(child, fk.line, resolverBaseName(fk.column, parent.Name),
+			lookupScript(parent.Name, refColumn, fk.column, false))
+		imp.addResolver(parent, fk.line, pluralName(lowerFirst(child.Name)),
+			lookupScript(child.Name, fk.column, refColumn, true))
+	}
+
+	for _, key := range imp.order {
+		t := imp.tables[key]
+		if primaryKeyName(t) == "" {
+			imp.warn(imp.lines[key], "table %s has no single-column primary key", t.Name)
+		}
+	}
+	sort.SliceStable(imp.warnings, func(i, j int) bool { return imp.warnings[i].Line < imp.warnings[j].Line })
+}
+
+// addResolver adds a suggested resolver unless its name is already taken by a field or another
+// resolver on the table.
+func (imp *sqlImporter) addResolver(t *contract.Table, line int, name, script string) {
+	if findField(t, name) != nil {
+		imp.warn(line, "table %s: suggested resolver %s clashes with a column and was skipped", t.Name, name)
+		return
+	}
+	for _, r := range t.Resolvers {
+		if r.Name == name {
+			imp.warn(line, "table %s: suggested resolver %s already exists; skipped %s", t.Name, name, script)
+			return
+		}
+	}
+	t.Resolvers = append(t.Resolvers, contract.Resolver{Name: name, Resolver: script})
+}
+
+func primaryKeyName(t *contract.Table) string {
+	for _, f := range t.Fields {
+		if f.Primary {
+			return f.Name
+		}
+	}
+	return ""
+}
+
+// resolverBaseName names the resolver for a foreign key column: role_id and roleId become role;
+// other names fall back to the referenced table.
+func resolverBaseName(column, refTable string) string {
+	for _, suffix := range []string{"_id", "Id", "ID", "_ID"} {
+		if base := strings.TrimSuffix(column, suffix); base != column && base != "" {
+			return base
+		}
+	}
+	return lowerFirst(refTable)
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+func pluralName(s string) string {
+	if strings.HasSuffix(strings.ToLower(s), "s") {
+		return s
+	}
+	if strings.HasSuffix(s, "y") && len(s) > 1 && !strings.ContainsRune("aeiou", rune(s[len(s)-2])) {
+		return s[:len(s)-1] + "ies"
+	}
+	return s + "s"
+}
@@ -0,0 +1,238 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+func tableByName(t *testing.T, s contract.Schema, name string) contract.Table {
+	t.Helper()
+	for _, tbl := range s.Tables {
+		if tbl.Name == name {
+			return tbl
+		}
+	}
+	t.Fatalf("table %s not found in %+v", name, s.Tables)
+	return contract.Table{}
+}
+
+func fieldByName(t *testing.T, tbl contract.Table, name string) contract.Field {
+	t.Helper()
+	for _, f := range tbl.Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	t.Fatalf("field %s.%s not found", tbl.Name, name)
+	return contract.Field{}
+}
+
+func hasWarning(warnings []SQLWarning, substr string) bool {
+	for _, w := range warnings {
+		if strings.Contains(w.Message, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestImportSQLPostgres(t *testing.T) {
+	src := `
+-- pg_dump style
+SET statement_timeout = 0;
+CREATE TABLE public.roles (
+    id serial PRIMARY KEY,
+    name varchar(64) NOT NULL UNIQUE
+);
+
+CREATE TABLE IF NOT EXISTS "users" (
+    id uuid DEFAULT gen_random_uuid() NOT NULL,
+    email character varying(120) NOT NULL,
+    active boolean DEFAULT true,
+    score numeric(10,2),
+    visits integer DEFAULT 0,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    tags text[],
+    settings jsonb,
+    role_id integer REFERENCES roles(id) ON DELETE CASCADE,
+    CONSTRAINT users_pkey PRIMARY KEY (id),
+    CHECK (visits >= 0)
+);
+
+CREATE INDEX users_email_idx ON ONLY public.users USING btree (email);
+CREATE UNIQUE INDEX users_role_email ON users (role_id, email);
+CREATE VIEW active_users AS SELECT * FROM users WHERE active;
+`
+	s, warnings, err := ImportSQL(src)
+	if err != nil {
+		t.Fatalf("ImportSQL: %v", err)
+	}
+	if len(s.Tables) != 2 {
+		t.Fatalf("expected 2 tables, got %+v", s.Tables)
+	}
+
+	roles := tableByName(t, s, "roles")
+	id := fieldByName(t, roles, "id")
+	if id.Type != "Int" || !id.Primary || id.Nullable || id.Generator != "Sequence" {
+		t.Fatalf("unexpected roles.id: %+v", id)
+	}
+	if name := fieldByName(t, roles, "name"); name.Type != "String" || name.MaxSize != 64 || !name.Unique || name.Nullable {
+		t.Fatalf("unexpected roles.name: %+v", name)
+	}
+
+	users := tableByName(t, s, "users")
+	checks := map[string]contract.Field{
+		"id":         {Name: "id", Type: "String", Primary: true, Generator: "UUID"},
+		"email":      {Name: "email", Type: "String", MaxSize: 120},
+		"active":     {Name: "active", Type: "Boolean", Nullable: true, Default: true},
+		"score":      {Name: "score", Type: "Double", Nullable: true},
+		"visits":     {Name: "visits", Type: "Int", Nullable: true, Default: int64(0)},
+		"created_at": {Name: "created_at", Type: "Timestamp"},
+		"tags":       {Name: "tags", Type: "EmbeddedList", Nullable: true},
+		"settings":   {Name: "settings", Type: "EmbeddedObject", Nullable: true},
+		"role_id":    {Name: "role_id", Type: "Int", Nullable: true},
+	}
+	for name, want := range checks {
+		got := fieldByName(t, users, name)
+		if got.Type != want.Type || got.Primary != want.Primary || got.Nullable != want.Nullable ||
+			got.MaxSize != want.MaxSize || got.Generator != want.Generator || got.Default != want.Default {
+			t.Fatalf("users.%s = %+v, want %+v", name, got, want)
+		}
+	}
+
+	if len(users.Indexes) != 2 || users.Indexes[0].Name != "email" ||
+		users.Indexes[1].Name != "users_role_email" || len(users.Indexes[1].Fields) != 2 {
+		t.Fatalf("unexpected indexes: %+v", users.Indexes)
+	}
+
+	if len(users.Resolvers) != 1 || users.Resolvers[0].Name != "role" ||
+		users.Resolvers[0].Resolver != `db.from("roles").where(eq("id", this.role_id)).firstOrNull()` {
+		t.Fatalf("unexpected users resolvers: %+v", users.Resolvers)
+	}
+	if len(roles.Resolvers) != 1 || roles.Resolvers[0].Name != "users" ||
+		roles.Resolvers[0].Resolver != `db.from("users").where(eq("role_id", this.id)).list()` {
+		t.Fatalf("unexpected roles resolvers: %+v", roles.Resolvers)
+	}
+
+	for _, want := range []string{"numeric imported as Double", "default now() is not imported", "CHECK constraints", "composite unique constraint", "CREATE VIEW"} {
+		if !hasWarning(warnings, want) {
+			t.Errorf("missing warning %q in %v", want, warnings)
+		}
+	}
+	if hasWarning(warnings, "SET") {
+		t.Errorf("SET should be ignored silently: %v", warnings)
+	}
+}
+
+func TestImportSQLMySQL(t *testing.T) {
+	src := "CREATE TABLE `Author` (\n" +
+		"  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,\n" +
+		"  `name` VARCHAR(80) NOT NULL COMMENT 'display name',\n" +
+		"  `initial` CHAR(1),\n" +
+		"  `verified` TINYINT(1) NOT NULL DEFAULT '0',\n" +
+		"  `bio` TEXT,\n" +
+		"  PRIMARY KEY (`id`),\n" +
+		"  KEY `name_idx` (`name`),\n" +
+		"  FULLTEXT KEY `bio_ft` (`bio`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;\n" +
+		"CREATE TABLE `Book` (\n" +
+		"  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,\n" +
+		"  `authorId` BIGINT UNSIGNED NOT NULL,\n" +
+		"  `price` DECIMAL(10,0),\n" +
+		"  `published` DATE\n" +
+		");\n" +
+		"ALTER TABLE `Book` ADD CONSTRAINT `fk_author` FOREIGN KEY (`authorId`) REFERENCES `Author` (`id`);\n"
+
+	s, warnings, err := ImportSQL(src)
+	if err != nil {
+		t.Fatalf("ImportSQL: %v", err)
+	}
+	author := tableByName(t, s, "Author")
+	if id := fieldByName(t, author, "id"); id.Type != "Long" || !id.Primary || id.Generator != "Sequence" {
+		t.Fatalf("unexpected Author.id: %+v", id)
+	}
+	if f := fieldByName(t, author, "initial"); f.Type != "Char" {
+		t.Fatalf("unexpected Author.initial: %+v", f)
+	}
+	if f := fieldByName(t, author, "verified"); f.Type != "Boolean" || f.Default != false || f.Nullable {
+		t.Fatalf("unexpected Author.verified: %+v", f)
+	}
+	if len(author.Indexes) != 2 || author.Indexes[0].Name != "bio" || author.Indexes[0].Type != "LUCENE" || author.Indexes[1].Name != "name" {
+		t.Fatalf("unexpected Author indexes: %+v", author.Indexes)
+	}
+
+	book := tableByName(t, s, "Book")
+	if f := fieldByName(t, book, "price"); f.Type != "Long" {
+		t.Fatalf("unexpected Book.price: %+v", f)
+	}
+	if f := fieldByName(t, book, "published"); f.Type != "Date" {
+		t.Fatalf("unexpected Book.published: %+v", f)
+	}
+	if len(book.Resolvers) != 1 || book.Resolvers[0].Name != "author" {
+		t.Fatalf("unexpected Book resolvers: %+v", book.Resolvers)
+	}
+	if len(author.Resolvers) != 1 || author.Resolvers[0].Name != "books" {
+		t.Fatalf("unexpected Author resolvers: %+v", author.Resolvers)
+	}
+	if len(warnings) != 0 {
+		t.Fatalf("expected no warnings, got %v", warnings)
+	}
+
+	if diags := ValidateSchema(s); HasErrors(diags) {
+		t.Fatalf("imported schema should validate: %v", diags)
+	}
+}
+
+func TestImportSQLWarnings(t *testing.T) {
+	src := `CREATE TABLE orders (
+  tenant int,
+  num int,
+  note time,
+  payload bytea,
+  shape geometry,
+  PRIMARY KEY (tenant, num)
+);
+CREATE TABLE lines (id int PRIMARY KEY, order_id int REFERENCES missing(id));
+CREATE INDEX lower_note ON orders (lower(note));
+ALTER TABLE orders DROP COLUMN note;
+CREATE FUNCTION f() RETURNS int AS $$ SELECT 1; $$ LANGUAGE sql;
+`
+	s, warnings, err := ImportSQL(src)
+	if err != nil {
+		t.Fatalf("ImportSQL: %v", err)
+	}
+	if len(s.Tables) != 2 {
+		t.Fatalf("unexpected tables: %+v", s.Tables)
+	}
+	for _, want := range []string{
+		"composite primary key",
+		"time of day imported as String",
+		"binary type bytea",
+		"unknown type geometry",
+		"references unknown table missing",
+		"index lower_note on expressions",
+		"ALTER TABLE action DROP",
+		"CREATE FUNCTION",
+		"table orders has no single-column primary key",
+	} {
+		if !hasWarning(warnings, want) {
+			t.Errorf("missing warning %q in %v", want, warnings)
+		}
+	}
+	if warnings[0].Line == 0 || !strings.HasPrefix(warnings[0].String(), "line ") {
+		t.Fatalf("expected line numbers, got %v", warnings[0])
+	}
+}
+
+func TestImportSQLErrors(t *testing.T) {
+	for _, src := range []string{"CREATE TABLE t (name text DEFAULT 'oops);", "/* open", "SELECT $$ body"} {
+		if _, _, err := ImportSQL(src); err == nil {
+			t.Errorf("expected error for %q", src)
+		}
+	}
+	if s, warnings, err := ImportSQL(""); err != nil || len(s.Tables) != 0 || len(warnings) != 0 {
+		t.Fatalf("empty input: %+v %v %v", s, warnings, err)
+	}
+}
@@ -0,0 +1,384 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+// ResolverTag holds a raw resolver script for a struct field, used instead of the generated
+// script from onyx:"resolver=Type(childField,parentField)".
+const ResolverTag = "onyxResolver"
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// FromStructs derives a schema from Go struct models. Each model becomes a table named after its
+// type, and each exported field becomes an attribute named after its json tag (or the field name
+// in lowerCamel case). The onyx struct tag adjusts the attribute:
+//
+//	primaryKey, nullable, unique    key and constraint flags
+//	index, index=LUCENE             adds an index on the field, optionally of a given type
+//	type=Timestamp                  overrides the mapped Onyx type
+//	generator=UUID, maxSize=64      identifier generator and maximum length
+//	default=value                   default value, parsed for the field's kind
+//	name=other                      overrides the attribute name
+//	resolver=Type(childField,parentField)
+//	                                declares a resolver instead of an attribute
+//	-                               skips the field
+//
+// A blank field tagged onyx:"table=Name,partition=field" renames the table or sets its partition.
+// Pointer fields are nullable. Go types map to Onyx types: string to String, bool to Boolean,
+// int8 to Byte, int16 to Short, int32 and smaller unsigned ints to Int, other integers to Long,
+// float32 to Float, float64 to Double, time.Time to Timestamp, slices to EmbeddedList, and
+// structs, maps and interfaces to EmbeddedObject.
+func FromStructs(models ...any) (contract.Schema, error) {
+	var schema contract.Schema
+	seen := map[string]bool{}
+	for _, model := range models {
+		table, err := tableFromStruct(model)
+		if err != nil {
+			return contract.Schema{}, err
+		}
+		if seen[table.Name] {
+			return contract.Schema{}, fmt.Errorf("duplicate table %s", table.Name)
+		}
+		seen[table.Name] = true
+		schema.Tables = append(schema.Tables, table)
+	}
+	return contract.NormalizeSchema(schema), nil
+}
+
+func tableFromStruct(model any) (contract.Table, error) {
+	t := reflect.TypeOf(model)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return contract.Table{}, fmt.Errorf("model %T is not a struct", model)
+	}
+
+	table := contract.Table{Name: t.Name()}
+	if err := addStructFields(&table, t); err != nil {
+		return contract.Table{}, fmt.Errorf("model %s: %w", t.Name(), err)
+	}
+
+	primary := 0
+	for _, f := range table.Fields {
+		if f.Primary {
+			primary++
+		}
+	}
+	if primary != 1 {
+		return contract.Table{}, fmt.Errorf("model %s: want exactly one primaryKey field, found %d", t.Name(), primary)
+	}
+	return table, nil
+}
+
+func addStructFields(table *contract.Table, t reflect.Type) error {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, hasTag := sf.Tag.Lookup("onyx")
+		if tag == "-" {
+			continue
+		}
+		opts, err := parseTagOptions(tag)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", sf.Name, err)
+		}
+
+		if sf.Name == "_" {
+			if v, ok := opts["table"]; ok {
+				table.Name = v
+			}
+			if v, ok := opts["partition"]; ok {
+				table.Partition = v
+			}
+			continue
+		}
+		if sf.Anonymous && !hasTag && sf.Type.Kind() == reflect.Struct {
+			if err := addStructFields(table, sf.Type); err != nil {
+				return err
+			}
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+
+		name := fieldName(sf, opts)
+		if name == "" {
+			continue
+		}
+
+		script, hasScript := sf.Tag.Lookup(ResolverTag)
+		if spec, ok := opts["resolver"]; ok || hasScript {
+			if !hasScript {
+				if script, err = resolverScript(spec, sf.Type); err != nil {
+					return fmt.Errorf("field %s: %w", sf.Name, err)
+				}
+			}
+			table.Resolvers = append(table.Resolvers, contract.Resolver{Name: name, Resolver: script})
+			continue
+		}
+
+		field, err := fieldFromStruct(name, sf.Type, opts)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", sf.Name, err)
+		}
+		table.Fields = append(table.Fields, field)
+		if indexType, ok := opts["index"]; ok {
+			if indexType == "" {
+				indexType = "DEFAULT"
+			}
+			table.Indexes = append(table.Indexes, contract.Index{Name: name, Type: indexType})
+		}
+	}
+	return nil
+}
+
+func fieldFromStruct(name string, t reflect.Type, opts map[string]string) (contract.Field, error) {
+	field := contract.Field{Name: name}
+	for key, value := range opts {
+		switch key {
+		case "primaryKey":
+			field.Primary = true
+		case "nullable":
+			field.Nullable = true
+		case "unique":
+			field.Unique = true
+		case "type":
+			field.Type = value
+		case "generator":
+			field.Generator = value
+		case "maxSize":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return field, fmt.Errorf("invalid maxSize %q", value)
+			}
+			field.MaxSize = n
+		case "index", "name":
+		case "default":
+		case "table", "partition":
+			return field, fmt.Errorf("%s is only allowed on a blank _ field", key)
+		default:
+			return field, fmt.Errorf("unknown onyx tag option %q", key)
+		}
+	}
+
+	base := t
+	if base.Kind() == reflect.Pointer {
+		field.Nullable = true
+		base = base.Elem()
+	}
+	if field.Type == "" {
+		onyxType, err := onyxType(base)
+		if err != nil {
+			return field, err
+		}
+		field.Type = onyxType
+	}
+	if value, ok := opts["default"]; ok {
+		def, err := parseDefault(value, base)
+		if err != nil {
+			return field, err
+		}
+		field.Default = def
+	}
+	return field, nil
+}
+
+func onyxType(t reflect.Type) (string, error) {
+	if t == timeType {
+		return "Timestamp", nil
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "String", nil
+	case reflect.Bool:
+		return "Boolean", nil
+	case reflect.Int8:
+		return "Byte", nil
+	case reflect.Int16:
+		return "Short", nil
+	case reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return "Int", nil
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return "Long", nil
+	case reflect.Float32:
+		return "Float", nil
+	case reflect.Float64:
+		return "Double", nil
+	case reflect.Slice, reflect.Array:
+		return "EmbeddedList", nil
+	case reflect.Struct, reflect.Map, reflect.Interface:
+		return "EmbeddedObject", nil
+	}
+	return "", fmt.Errorf("unsupported Go type %s", t)
+}
+
+func parseDefault(value string, t reflect.Type) (any, error) {
+	switch t.Kind() {
+	case reflect.Bool:
+		return strconv.ParseBool(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid default %q for %s", value, t)
+		}
+		return float64(n), nil
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid default %q for %s", value, t)
+		}
+		return f, nil
+	}
+	return value, nil
+}
+
+// resolverScript builds the resolver for a Type(childField,parentField) spec, matching the
+// cascade graph format: childField lives on Type and equals this row's parentField. Slice fields
+// resolve to a list and single values to the first match.
+func resolverScript(spec string, t reflect.Type) (string, error) {
+	open := strings.Index(spec, "(")
+	if open <= 0 || !strings.HasSuffix(spec, ")") {
+		return "", fmt.Errorf("invalid resolver %q: want Type(childField,parentField)", spec)
+	}
+	target := spec[:open]
+	fields := strings.Split(spec[open+1:len(spec)-1], ",")
+	if len(fields) != 2 || strings.TrimSpace(fields[0]) == "" || strings.TrimSpace(fields[1]) == "" {
+		return "", fmt.Errorf("invalid resolver %q: want Type(childField,parentField)", spec)
+	}
+	many := t.Kind() == reflect.Slice || t.Kind() == reflect.Array
+	return lookupScript(target, strings.TrimSpace(fields[0]), strings.TrimSpace(fields[1]), many), nil
+}
+
+// lookupScript is the resolver script matching rows of target whose childField equals this
+// row's parentField, as a list when many is set and the first match otherwise.
+func lookupScript(target, childField, parentField string, many bool) string {
+	fetch := "firstOrNull()"
+	if many {
+		fetch = "list()"
+	}
+	return fmt.Sprintf("db.from(%q).where(eq(%q, this.%s)).%s", target, childField, parentField, fetch)
+}
+
+func fieldName(sf reflect.StructField, opts map[string]string) string {
+	if name, ok := opts["name"]; ok && name != "" {
+		return name
+	}
+	if jsonTag, ok := sf.Tag.Lookup("json"); ok {
+		name, _, _ := strings.Cut(jsonTag, ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return lowerCamel(sf.Name)
+}
+
+// lowerCamel lowercases the leading run of capitals, keeping the last one when it starts the
+// next word: ID -> id, UserID -> userID, URLPath -> urlPath.
+func lowerCamel(name string) string {
+	runes := []rune(name)
+	i := 0
+	for i < len(runes) && unicode.IsUpper(runes[i]) {
+		i++
+	}
+	if i == 0 {
+		return name
+	}
+	if i > 1 && i < len(runes) {
+		i--
+	}
+	for j := 0; j < i; j++ {
+		runes[j] = unicode.ToLower(runes[j])
+	}
+	return string(runes)
+}
+
+// parseTagOptions splits a tag on commas outside parentheses into key or key=value options.
+func parseTagOptions(tag string) (map[string]string, error) {
+	opts := map[string]string{}
+	depth, start := 0, 0
+	flush := func(end int) error {
+		part := strings.TrimSpace(tag[start:end])
+		if part == "" {
+			return nil
+		}
+		key, value, _ := strings.Cut(part, "=")
+		if _, dup := opts[key]; dup {
+			return fmt.Errorf("duplicate onyx tag option %q", key)
+		}
+		opts[key] = value
+		return nil
+	}
+	for i, r := range tag {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				if err := flush(i); err != nil {
+					return nil, err
+				}
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced parentheses in onyx tag %q", tag)
+	}
+	if err := flush(len(tag)); err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
+
+var (
+	modelSetsMu sync.RWMutex
+	modelSets   = map[string][]any{}
+)
+
+// RegisterModels adds models to a named set, typically from an init function, so tools such as
+// onyx-schema-go --from-go can build the schema without knowing the model types.
+func RegisterModels(set string, models ...any) {
+	modelSetsMu.Lock()
+	defer modelSetsMu.Unlock()
+	modelSets[set] = append(modelSets[set], models...)
+}
+
+// RegisteredModelSets lists the registered set names in sorted order.
+func RegisteredModelSets() []string {
+	modelSetsMu.RLock()
+	defer modelSetsMu.RUnlock()
+	names := make([]string, 0, len(modelSets))
+	for name := range modelSets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FromRegistered builds the schema for a registered model set.
+func FromRegistered(set string) (contract.Schema, error) {
+	modelSetsMu.RLock()
+	models, ok := modelSets[set]
+	modelSetsMu.RUnlock()
+	if !ok {
+		return contract.Schema{}, fmt.Errorf("no models registered as %q (registered: %s)", set, strings.Join(RegisteredModelSets(), ", "))
+	}
+	return FromStructs(models...)
+}
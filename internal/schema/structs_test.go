@@ -0,0 +1,201 @@
+package schema
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+type structAddress struct {
+	Street string `json:"street"`
+}
+
+type structBase struct {
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type structUser struct {
+	_ struct{} `onyx:"table=User,partition=region"`
+	structBase
+
+	ID       string            `json:"id" onyx:"primaryKey,generator=UUID,maxSize=36"`
+	Email    string            `json:"email" onyx:"unique,index"`
+	Bio      string            `onyx:"index=LUCENE"`
+	Age      *int32            `json:"age"`
+	Active   bool              `json:"active" onyx:"default=true"`
+	Score    float64           `json:"score"`
+	Region   string            `json:"region"`
+	Address  structAddress     `json:"address"`
+	Tags     []string          `json:"tags"`
+	Extra    map[string]string `json:"extra"`
+	Roles    []structRole      `json:"roles" onyx:"resolver=UserRole(userId,id)"`
+	Profile  *structProfile    `json:"profile" onyx:"resolver=UserProfile(userId,id)"`
+	Custom   []structRole      `json:"custom" onyxResolver:"db.from(\"Role\").list()"`
+	Internal string            `json:"-"`
+	Skipped  string            `onyx:"-"`
+	hidden   string
+}
+
+type structRole struct {
+	RoleID int64 `onyx:"primaryKey"`
+}
+
+type structProfile struct{}
+
+func TestFromStructsMapsTypesAndTags(t *testing.T) {
+	s, err := FromStructs(structUser{}, &structRole{})
+	if err != nil {
+		t.Fatalf("FromStructs: %v", err)
+	}
+	if len(s.Tables) != 2 || s.Tables[0].Name != "User" || s.Tables[1].Name != "structRole" {
+		t.Fatalf("unexpected tables: %+v", s.Tables)
+	}
+	user := s.Tables[0]
+	if user.Partition != "region" {
+		t.Fatalf("expected partition region, got %q", user.Partition)
+	}
+
+	fields := map[string]contract.Field{}
+	for _, f := range user.Fields {
+		fields[f.Name] = f
+	}
+	want := map[string]contract.Field{
+		"createdAt": {Name: "createdAt", Type: "Timestamp"},
+		"id":        {Name: "id", Type: "String", Primary: true, Generator: "UUID", MaxSize: 36},
+		"email":     {Name: "email", Type: "String", Unique: true},
+		"bio":       {Name: "bio", Type: "String"},
+		"age":       {Name: "age", Type: "Int", Nullable: true},
+		"active":    {Name: "active", Type: "Boolean", Default: true},
+		"score":     {Name: "score", Type: "Double"},
+		"region":    {Name: "region", Type: "String"},
+		"address":   {Name: "address", Type: "EmbeddedObject"},
+		"tags":      {Name: "tags", Type: "EmbeddedList"},
+		"extra":     {Name: "extra", Type: "EmbeddedObject"},
+	}
+	if len(fields) != len(want) {
+		t.Fatalf("expected %d fields, got %+v", len(want), user.Fields)
+	}
+	for name, w := range want {
+		if !reflect.DeepEqual(fields[name], w) {
+			t.Fatalf("field %s: expected %+v, got %+v", name, w, fields[name])
+		}
+	}
+
+	wantIndexes := []contract.Index{{Name: "bio", Type: "LUCENE"}, {Name: "email", Type: "DEFAULT"}}
+	if !reflect.DeepEqual(user.Indexes, wantIndexes) {
+		t.Fatalf("unexpected indexes: %+v", user.Indexes)
+	}
+
+	resolvers := map[string]string{}
+	for _, r := range user.Resolvers {
+		resolvers[r.Name] = r.Resolver
+	}
+	wantResolvers := map[string]string{
+		"roles":   `db.from("UserRole").where(eq("userId", this.id)).list()`,
+		"profile": `db.from("UserProfile").where(eq("userId", this.id)).firstOrNull()`,
+		"custom":  `db.from("Role").list()`,
+	}
+	if !reflect.DeepEqual(resolvers, wantResolvers) {
+		t.Fatalf("unexpected resolvers: %+v", resolvers)
+	}
+
+	if got := s.Tables[1].Fields; len(got) != 1 || got[0].Name != "roleID" || got[0].Type != "Long" || !got[0].Primary {
+		t.Fatalf("unexpected role fields: %+v", got)
+	}
+}
+
+func TestFromStructsIntegerWidths(t *testing.T) {
+	type widths struct {
+		ID  int     `onyx:"primaryKey"`
+		I8  int8    `json:"i8"`
+		I16 int16   `json:"i16"`
+		U8  uint8   `json:"u8"`
+		F32 float32 `json:"f32"`
+		Def int64   `json:"def" onyx:"default=7"`
+	}
+	s, err := FromStructs(widths{})
+	if err != nil {
+		t.Fatalf("FromStructs: %v", err)
+	}
+	got := map[string]string{}
+	for _, f := range s.Tables[0].Fields {
+		got[f.Name] = f.Type
+		if f.Name == "def" && f.Default != float64(7) {
+			t.Fatalf("expected numeric default 7, got %#v", f.Default)
+		}
+	}
+	want := map[string]string{"id": "Long", "i8": "Byte", "i16": "Short", "u8": "Int", "f32": "Float", "def": "Long"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestFromStructsErrors(t *testing.T) {
+	type noKey struct {
+		Name string
+	}
+	type twoKeys struct {
+		A string `onyx:"primaryKey"`
+		B string `onyx:"primaryKey"`
+	}
+	type badOption struct {
+		ID string `onyx:"primaryKey,sparkly"`
+	}
+	type badType struct {
+		ID string `onyx:"primaryKey"`
+		Ch chan int
+	}
+	type badResolver struct {
+		ID    string `onyx:"primaryKey"`
+		Roles []int  `onyx:"resolver=Role"`
+	}
+	type badMaxSize struct {
+		ID string `onyx:"primaryKey,maxSize=big"`
+	}
+	cases := map[string]struct {
+		model any
+		want  string
+	}{
+		"not a struct": {42, "is not a struct"},
+		"no key":       {noKey{}, "found 0"},
+		"two keys":     {twoKeys{}, "found 2"},
+		"bad option":   {badOption{}, `unknown onyx tag option "sparkly"`},
+		"bad type":     {badType{}, "unsupported Go type"},
+		"bad resolver": {badResolver{}, "invalid resolver"},
+		"bad maxSize":  {badMaxSize{}, "invalid maxSize"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := FromStructs(tc.model)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+
+	if _, err := FromStructs(structRole{}, &structRole{}); err == nil || !strings.Contains(err.Error(), "duplicate table") {
+		t.Fatalf("expected duplicate table error, got %v", err)
+	}
+}
+
+func TestLowerCamel(t *testing.T) {
+	for in, want := range map[string]string{"ID": "id", "UserID": "userID", "URLPath": "urlPath", "Name": "name", "x": "x"} {
+		if got := lowerCamel(in); got != want {
+			t.Fatalf("lowerCamel(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestFromRegistered(t *testing.T) {
+	RegisterModels("structs_test", structRole{})
+	s, err := FromRegistered("structs_test")
+	if err != nil || len(s.Tables) != 1 {
+		t.Fatalf("unexpected result: %+v, %v", s, err)
+	}
+	if _, err := FromRegistered("missing"); err == nil || !strings.Contains(err.Error(), "structs_test") {
+		t.Fatalf("expected error listing registered sets, got %v", err)
+	}
+}
@@ -0,0 +1,333 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+// DiagnosticSeverity ranks a diagnostic. Every check currently reports errors.
+type DiagnosticSeverity string
+
+const DiagnosticError DiagnosticSeverity = "error"
+
+// Diagnostic codes reported by ValidateSchema.
+const (
+	CodeEmptyTableName       = "empty_table_name"
+	CodeDuplicateTable       = "duplicate_table"
+	CodeEmptyFieldName       = "empty_field_name"
+	CodeDuplicateField       = "duplicate_field"
+	CodeUnknownType          = "unknown_type"
+	CodeMissingPrimaryKey    = "missing_primary_key"
+	CodeMultiplePrimaryKeys  = "multiple_primary_keys"
+	CodeNullablePrimaryKey   = "nullable_primary_key"
+	CodeIndexUnknownField    = "index_unknown_field"
+	CodeResolverUnknownTable = "resolver_unknown_table"
+	CodeReservedIdentifier   = "reserved_identifier"
+	CodePartitionUnknown     = "partition_unknown_field"
+)
+
+// Diagnostic is one problem found in a schema. Pointer is an RFC 6901 JSON pointer to the
+// offending value in the source document.
+type Diagnostic struct {
+	Severity DiagnosticSeverity `json:"severity"`
+	Code     string             `json:"code"`
+	Table    string             `json:"table,omitempty"`
+	Field    string             `json:"field,omitempty"`
+	Pointer  string             `json:"pointer"`
+	Message  string             `json:"message"`
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s: %s (%s)", d.Severity, d.Message, d.Pointer)
+}
+
+// HasErrors reports whether any diagnostic has error severity.
+func HasErrors(diags []Diagnostic) bool {
+	for _, d := range diags {
+		if d.Severity == DiagnosticError {
+			return true
+		}
+	}
+	return false
+}
+
+// FieldTypes is the set of attribute types Onyx accepts.
+var FieldTypes = []string{
+	"String", "Boolean", "Char", "Byte", "Short", "Int", "Long", "Float", "Double",
+	"Date", "Timestamp", "EmbeddedObject", "EmbeddedList",
+}
+
+var resolverTablePattern = regexp.MustCompile(`db\s*\.\s*from\s*\(\s*["']([^"']+)["']`)
+
+// ValidateSchema checks s for problems the API would reject or silently mishandle. Pointers
+// refer to the tables format, in the order of s.Tables.
+func ValidateSchema(s contract.Schema) []Diagnostic {
+	return validate(s, tablesLayout(s))
+}
+
+// ValidateSchemaJSON parses a schema document and validates it, with pointers into data in
+// whichever format (tables or entities, optionally wrapped) it was written.
+func ValidateSchemaJSON(data []byte) ([]Diagnostic, error) {
+	s, err := contract.ParseSchemaJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	var raw any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	return validate(s, sourceLayout(raw)), nil
+}
+
+// layout maps schema elements back to JSON pointers. Slices are indexed like the parsed schema.
+type layout []tableLayout
+
+type tableLayout struct {
+	ptr       string
+	partition string
+	fields    []string
+	// primary points at the declaration making a field the primary key, when it differs from
+	// the field itself (the entities identifier).
+	primary   string
+	indexes   []string
+	resolvers []string
+}
+
+func (l layout) table(i int) tableLayout {
+	if i < len(l) {
+		return l[i]
+	}
+	return tableLayout{ptr: "/tables/" + strconv.Itoa(i)}
+}
+
+func (t tableLayout) field(i int) string    { return at(t.fields, i, t.ptr+"/fields") }
+func (t tableLayout) index(i int) string    { return at(t.indexes, i, t.ptr+"/indexes") }
+func (t tableLayout) resolver(i int) string { return at(t.resolvers, i, t.ptr+"/resolvers") }
+
+func at(ptrs []string, i int, fallback string) string {
+	if i < len(ptrs) {
+		return ptrs[i]
+	}
+	return fallback + "/" + strconv.Itoa(i)
+}
+
+func tablesLayout(s contract.Schema) layout {
+	out := make(layout, len(s.Tables))
+	for i := range s.Tables {
+		ptr := "/tables/" + strconv.Itoa(i)
+		out[i] = tableLayout{ptr: ptr, partition: ptr + "/partition"}
+	}
+	return out
+}
+
+// sourceLayout walks a decoded document the way contract.ParseSchemaJSON does, skipping the
+// same entries, so positions in the parsed schema line up with raw array indexes.
+func sourceLayout(raw any) layout {
+	root, _ := raw.(map[string]any)
+	prefix := ""
+	if list, ok := root["schemas"].([]any); ok && len(list) > 0 {
+		if latest, ok := list[len(list)-1].(map[string]any); ok {
+			root = latest
+			prefix = "/schemas/" + strconv.Itoa(len(list)-1)
+		}
+	}
+	if nested, ok := root["schema"].(map[string]any); ok {
+		root = nested
+		prefix += "/schema"
+	}
+
+	key, fieldsKey := "tables", "fields"
+	items, ok := root["tables"].([]any)
+	if !ok {
+		key, fieldsKey = "entities", "attributes"
+		items, _ = root["entities"].([]any)
+	}
+
+	var out layout
+	for i, item := range items {
+		obj, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		ptr := prefix + "/" + key + "/" + strconv.Itoa(i)
+		t := tableLayout{ptr: ptr, partition: ptr + "/partition"}
+		if key == "entities" {
+			t.primary = ptr + "/identifier"
+		}
+		fields, _ := obj[fieldsKey].([]any)
+		for j, f := range fields {
+			if _, ok := f.(map[string]any); ok {
+				t.fields = append(t.fields, ptr+"/"+fieldsKey+"/"+strconv.Itoa(j))
+			}
+		}
+		indexes, _ := obj["indexes"].([]any)
+		for j, idx := range indexes {
+			if m, ok := idx.(map[string]any); ok {
+				if _, ok := m["name"].(string); ok {
+					t.indexes = append(t.indexes, ptr+"/indexes/"+strconv.Itoa(j))
+				}
+			}
+		}
+		resolvers, _ := obj["resolvers"].([]any)
+		for j, r := range resolvers {
+			switch rv := r.(type) {
+			case string:
+				t.resolvers = append(t.resolvers, ptr+"/resolvers/"+strconv.Itoa(j))
+			case map[string]any:
+				if _, ok := rv["name"].(string); ok {
+					t.resolvers = append(t.resolvers, ptr+"/resolvers/"+strconv.Itoa(j))
+				}
+			}
+		}
+		out = append(out, t)
+	}
+	return out
+}
+
+func validate(s contract.Schema, l layout) []Diagnostic {
+	var diags []Diagnostic
+	report := func(code, table, field, ptr, format string, args ...any) {
+		diags = append(diags, Diagnostic{
+			Severity: DiagnosticError,
+			Code:     code,
+			Table:    table,
+			Field:    field,
+			Pointer:  ptr,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	tableNames := map[string]bool{}
+	for _, t := range s.Tables {
+		tableNames[t.Name] = true
+	}
+
+	seenTables := map[string]bool{}
+	for ti, table := range s.Tables {
+		tl := l.table(ti)
+		if table.Name == "" {
+			report(CodeEmptyTableName, "", "", tl.ptr+"/name", "table name cannot be empty")
+			continue
+		}
+		if seenTables[table.Name] {
+			report(CodeDuplicateTable, table.Name, "", tl.ptr+"/name", "duplicate table name: %s", table.Name)
+			continue
+		}
+		seenTables[table.Name] = true
+		if isReserved(table.Name) {
+			report(CodeReservedIdentifier, table.Name, "", tl.ptr+"/name", "table name %s is reserved", table.Name)
+		}
+
+		fieldNames := map[string]bool{}
+		var primaries []int
+		for fi, field := range table.Fields {
+			ptr := tl.field(fi)
+			if field.Name == "" {
+				report(CodeEmptyFieldName, table.Name, "", ptr+"/name", "table %s has a field with empty name", table.Name)
+				continue
+			}
+			if fieldNames[field.Name] {
+				report(CodeDuplicateField, table.Name, field.Name, ptr+"/name", "table %s has duplicate field name: %s", table.Name, field.Name)
+				continue
+			}
+			fieldNames[field.Name] = true
+			if isReserved(field.Name) {
+				report(CodeReservedIdentifier, table.Name, field.Name, ptr+"/name", "table %s field name %s is reserved", table.Name, field.Name)
+			}
+			if !isFieldType(field.Type) {
+				report(CodeUnknownType, table.Name, field.Name, ptr+"/type", "table %s field %s has unknown type %q%s", table.Name, field.Name, field.Type, typeHint(field.Type))
+			}
+			if field.Primary {
+				primaries = append(primaries, fi)
+			}
+		}
+
+		switch len(primaries) {
+		case 0:
+			ptr := tl.ptr
+			if tl.primary != "" {
+				ptr = tl.primary
+			}
+			report(CodeMissingPrimaryKey, table.Name, "", ptr, "table %s has no primary key", table.Name)
+		case 1:
+		default:
+			names := make([]string, 0, len(primaries))
+			for _, fi := range primaries {
+				names = append(names, table.Fields[fi].Name)
+			}
+			report(CodeMultiplePrimaryKeys, table.Name, names[1], tl.field(primaries[1])+"/primaryKey", "table %s has multiple primary keys: %s", table.Name, strings.Join(names, ", "))
+		}
+		for _, fi := range primaries {
+			field := table.Fields[fi]
+			if !field.Nullable {
+				continue
+			}
+			ptr := tl.field(fi) + "/nullable"
+			if tl.primary != "" {
+				ptr = tl.field(fi) + "/isNullable"
+			}
+			report(CodeNullablePrimaryKey, table.Name, field.Name, ptr, "table %s primary key %s cannot be nullable", table.Name, field.Name)
+		}
+
+		for ii, idx := range table.Indexes {
+			ptr := tl.index(ii)
+			if len(idx.Fields) == 0 {
+				if !fieldNames[idx.Name] {
+					report(CodeIndexUnknownField, table.Name, idx.Name, ptr+"/name", "table %s index %s references unknown field %s", table.Name, idx.Name, idx.Name)
+				}
+				continue
+			}
+			for k, name := range idx.Fields {
+				if !fieldNames[name] {
+					report(CodeIndexUnknownField, table.Name, name, ptr+"/fields/"+strconv.Itoa(k), "table %s index %s references unknown field %s", table.Name, idx.Name, name)
+				}
+			}
+		}
+
+		for ri, res := range table.Resolvers {
+			ptr := tl.resolver(ri)
+			if isReserved(res.Name) {
+				report(CodeReservedIdentifier, table.Name, res.Name, ptr, "table %s resolver name %s is reserved", table.Name, res.Name)
+			}
+			for _, m := range resolverTablePattern.FindAllStringSubmatch(res.Resolver, -1) {
+				if !tableNames[m[1]] {
+					report(CodeResolverUnknownTable, table.Name, res.Name, ptr+"/resolver", "table %s resolver %s references unknown table %s", table.Name, res.Name, m[1])
+				}
+			}
+		}
+
+		if table.Partition != "" && !fieldNames[table.Partition] {
+			report(CodePartitionUnknown, table.Name, table.Partition, tl.partition, "table %s partition field %s does not exist", table.Name, table.Partition)
+		}
+	}
+
+	return diags
+}
+
+// isReserved reports names Onyx keeps for itself, such as the __full_text__ search field.
+func isReserved(name string) bool {
+	return strings.HasPrefix(name, "__")
+}
+
+func isFieldType(t string) bool {
+	for _, known := range FieldTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+func typeHint(t string) string {
+	for _, known := range FieldTypes {
+		if strings.EqualFold(t, known) {
+			return fmt.Sprintf("; did you mean %q?", known)
+		}
+	}
+	return ""
+}
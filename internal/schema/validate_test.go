@@ -0,0 +1,155 @@
+package schema
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+func diagCodes(diags []Diagnostic) []string {
+	var codes []string
+	for _, d := range diags {
+		codes = append(codes, d.Code)
+	}
+	return codes
+}
+
+func TestValidateSchemaValid(t *testing.T) {
+	s := contract.Schema{Tables: []contract.Table{
+		{
+			Name:      "User",
+			Partition: "region",
+			Fields: []contract.Field{
+				{Name: "id", Type: "String", Primary: true},
+				{Name: "region", Type: "String"},
+				{Name: "email", Type: "String", Nullable: true},
+			},
+			Indexes:   []contract.Index{{Name: "email"}, {Name: "byRegion", Fields: []string{"region", "email"}}},
+			Resolvers: []contract.Resolver{{Name: "roles", Resolver: `db.from("Role").where(eq("userId", this.id)).list()`}},
+		},
+		{Name: "Role", Fields: []contract.Field{{Name: "id", Type: "Long", Primary: true}, {Name: "userId", Type: "String"}}},
+	}}
+	if diags := ValidateSchema(s); len(diags) != 0 {
+		t.Fatalf("expected no diagnostics, got %v", diags)
+	}
+}
+
+func TestValidateSchemaChecks(t *testing.T) {
+	pk := contract.Field{Name: "id", Type: "String", Primary: true}
+	tests := []struct {
+		name    string
+		table   contract.Table
+		code    string
+		field   string
+		pointer string
+		message string
+	}{
+		{"unknown type", contract.Table{Name: "T", Fields: []contract.Field{pk, {Name: "n", Type: "int"}}},
+			CodeUnknownType, "n", "/tables/0/fields/1/type", `did you mean "Int"?`},
+		{"no primary key", contract.Table{Name: "T", Fields: []contract.Field{{Name: "id", Type: "String"}}},
+			CodeMissingPrimaryKey, "", "/tables/0", "has no primary key"},
+		{"multiple primary keys", contract.Table{Name: "T", Fields: []contract.Field{pk, {Name: "other", Type: "String", Primary: true}}},
+			CodeMultiplePrimaryKeys, "other", "/tables/0/fields/1/primaryKey", "id, other"},
+		{"nullable primary key", contract.Table{Name: "T", Fields: []contract.Field{{Name: "id", Type: "String", Primary: true, Nullable: true}}},
+			CodeNullablePrimaryKey, "id", "/tables/0/fields/0/nullable", "cannot be nullable"},
+		{"index field", contract.Table{Name: "T", Fields: []contract.Field{pk}, Indexes: []contract.Index{{Name: "byX", Fields: []string{"id", "x"}}}},
+			CodeIndexUnknownField, "x", "/tables/0/indexes/0/fields/1", "unknown field x"},
+		{"index name", contract.Table{Name: "T", Fields: []contract.Field{pk}, Indexes: []contract.Index{{Name: "email"}}},
+			CodeIndexUnknownField, "email", "/tables/0/indexes/0/name", "unknown field email"},
+		{"resolver table", contract.Table{Name: "T", Fields: []contract.Field{pk}, Resolvers: []contract.Resolver{{Name: "r", Resolver: "db.from('Missing').list()"}}},
+			CodeResolverUnknownTable, "r", "/tables/0/resolvers/0/resolver", "unknown table Missing"},
+		{"reserved field", contract.Table{Name: "T", Fields: []contract.Field{pk, {Name: "__full_text__", Type: "String"}}},
+			CodeReservedIdentifier, "__full_text__", "/tables/0/fields/1/name", "is reserved"},
+		{"partition", contract.Table{Name: "T", Partition: "region", Fields: []contract.Field{pk}},
+			CodePartitionUnknown, "region", "/tables/0/partition", "partition field region"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := ValidateSchema(contract.Schema{Tables: []contract.Table{tt.table}})
+			if len(diags) != 1 {
+				t.Fatalf("expected one diagnostic, got %v", diags)
+			}
+			d := diags[0]
+			if d.Code != tt.code || d.Severity != DiagnosticError || d.Table != "T" || d.Field != tt.field || d.Pointer != tt.pointer {
+				t.Fatalf("unexpected diagnostic %+v", d)
+			}
+			if !strings.Contains(d.Message, tt.message) {
+				t.Fatalf("expected message containing %q, got %q", tt.message, d.Message)
+			}
+		})
+	}
+}
+
+func TestValidateSchemaNamesSkipFurtherChecks(t *testing.T) {
+	s := contract.Schema{Tables: []contract.Table{
+		{Name: ""},
+		{Name: "A", Fields: []contract.Field{{Name: "id", Type: "String", Primary: true}, {Name: ""}, {Name: "id", Type: "bogus"}}},
+		{Name: "A"},
+	}}
+	want := []string{CodeEmptyTableName, CodeEmptyFieldName, CodeDuplicateField, CodeDuplicateTable}
+	if got := diagCodes(ValidateSchema(s)); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestValidateSchemaJSONPointers(t *testing.T) {
+	data := []byte(`{"schemas": [{}, {"schema": {"entities": [
+  "skipped",
+  {
+    "name": "User",
+    "identifier": {"name": "id"},
+    "attributes": [
+      1,
+      {"name": "id", "type": "String", "isNullable": true},
+      {"name": "age", "type": "Integer"}
+    ],
+    "indexes": [{"type": "DEFAULT"}, {"name": "byAge", "fields": ["years"]}],
+    "resolvers": [{"resolver": "ignored"}, {"name": "roles", "resolver": "db.from(\"Role\").list()"}]
+  },
+  {"name": "Audit", "attributes": [{"name": "at", "type": "Timestamp"}]}
+]}}]}`)
+
+	diags, err := ValidateSchemaJSON(data)
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	got := map[string]string{}
+	for _, d := range diags {
+		got[d.Code] = d.Pointer
+	}
+	prefix := "/schemas/1/schema/entities"
+	want := map[string]string{
+		CodeNullablePrimaryKey:   prefix + "/1/attributes/1/isNullable",
+		CodeUnknownType:          prefix + "/1/attributes/2/type",
+		CodeIndexUnknownField:    prefix + "/1/indexes/1/fields/0",
+		CodeResolverUnknownTable: prefix + "/1/resolvers/1/resolver",
+		CodeMissingPrimaryKey:    prefix + "/2/identifier",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestValidateSchemaJSONExampleIsValid(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "examples", "api", "onyx.schema.json"))
+	if err != nil {
+		t.Fatalf("read example schema: %v", err)
+	}
+	diags, err := ValidateSchemaJSON(data)
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if HasErrors(diags) {
+		t.Fatalf("expected example schema to be valid, got %v", diags)
+	}
+}
+
+func TestValidateSchemaJSONInvalid(t *testing.T) {
+	if _, err := ValidateSchemaJSON([]byte("{")); err == nil {
+		t.Fatalf("expected parse error")
+	}
+}
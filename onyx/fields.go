@@ -0,0 +1,230 @@
+package onyx
+
+import (
+	"time"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+// Typed field descriptors build the same conditions as Eq, Gt, Like and friends, but only expose
+// the operators that make sense for the field's type and check values at compile time:
+//
+//	email := onyx.NewStringField("email")
+//	age := onyx.NewIntField("age").Nullable()
+//	q := db.From("User").Where(email.StartsWith("ada")).And(age.Gte(18)).OrderBy(age.Desc())
+//
+// Generated clients declare one descriptor per attribute in <Table>Fields.
+
+// FieldRef is implemented by every field descriptor.
+type FieldRef interface {
+	FieldName() string
+}
+
+// FieldNames returns the names of the given fields, for Select and GroupBy.
+func FieldNames(fields ...FieldRef) []string {
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		names = append(names, f.FieldName())
+	}
+	return names
+}
+
+// Number lists the Go types an IntField or FloatField compares against.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+type fieldBase struct{ name string }
+
+// FieldName returns the attribute name.
+func (f fieldBase) FieldName() string { return f.name }
+
+// Asc sorts by the field in ascending order.
+func (f fieldBase) Asc() Sort { return contract.Asc(f.name) }
+
+// Desc sorts by the field in descending order.
+func (f fieldBase) Desc() Sort { return contract.Desc(f.name) }
+
+type equalityField[T any] struct{ fieldBase }
+
+// Eq matches rows where the field equals v.
+func (f equalityField[T]) Eq(v T) Condition { return contract.Eq(f.name, v) }
+
+// Neq matches rows where the field differs from v.
+func (f equalityField[T]) Neq(v T) Condition { return contract.Neq(f.name, v) }
+
+// In matches rows where the field is one of values.
+func (f equalityField[T]) In(values ...T) Condition { return contract.In(f.name, toAny(values)) }
+
+// NotIn matches rows where the field is none of values.
+func (f equalityField[T]) NotIn(values ...T) Condition {
+	return contract.NotIn(f.name, toAny(values))
+}
+
+type orderedField[T any] struct{ equalityField[T] }
+
+// Gt matches rows where the field is greater than v.
+func (f orderedField[T]) Gt(v T) Condition { return contract.Gt(f.name, v) }
+
+// Gte matches rows where the field is greater than or equal to v.
+func (f orderedField[T]) Gte(v T) Condition { return contract.Gte(f.name, v) }
+
+// Lt matches rows where the field is less than v.
+func (f orderedField[T]) Lt(v T) Condition { return contract.Lt(f.name, v) }
+
+// Lte matches rows where the field is less than or equal to v.
+func (f orderedField[T]) Lte(v T) Condition { return contract.Lte(f.name, v) }
+
+// Between matches rows where the field lies in [from, to].
+func (f orderedField[T]) Between(from, to T) Condition { return contract.Between(f.name, from, to) }
+
+type nullableOps struct{ name string }
+
+// IsNull matches rows where the field is null.
+func (n nullableOps) IsNull() Condition { return contract.IsNull(n.name) }
+
+// NotNull matches rows where the field is set.
+func (n nullableOps) NotNull() Condition { return contract.NotNull(n.name) }
+
+// StringField describes a String attribute.
+type StringField struct{ equalityField[string] }
+
+// NewStringField declares a String attribute.
+func NewStringField(name string) StringField {
+	return StringField{equalityField[string]{fieldBase{name}}}
+}
+
+// Like matches the field against a pattern.
+func (f StringField) Like(pattern string) Condition { return contract.Like(f.name, pattern) }
+
+// StartsWith matches values beginning with prefix.
+func (f StringField) StartsWith(prefix string) Condition { return contract.StartsWith(f.name, prefix) }
+
+// Contains matches values containing s.
+func (f StringField) Contains(s string) Condition { return contract.Contains(f.name, s) }
+
+// Nullable returns the descriptor of a nullable String attribute.
+func (f StringField) Nullable() NullableStringField {
+	return NullableStringField{f, nullableOps{f.name}}
+}
+
+// NullableStringField describes a nullable String attribute.
+type NullableStringField struct {
+	StringField
+	nullableOps
+}
+
+// NumberField describes a numeric attribute compared against T.
+type NumberField[T Number] struct{ orderedField[T] }
+
+// IntField describes an integer attribute (Byte, Short, Int or Long).
+type IntField = NumberField[int64]
+
+// FloatField describes a Float or Double attribute.
+type FloatField = NumberField[float64]
+
+// NewNumberField declares a numeric attribute compared against T.
+func NewNumberField[T Number](name string) NumberField[T] {
+	return NumberField[T]{orderedField[T]{equalityField[T]{fieldBase{name}}}}
+}
+
+// NewIntField declares an integer attribute.
+func NewIntField(name string) IntField { return NewNumberField[int64](name) }
+
+// NewFloatField declares a Float or Double attribute.
+func NewFloatField(name string) FloatField { return NewNumberField[float64](name) }
+
+// Nullable returns the descriptor of a nullable numeric attribute.
+func (f NumberField[T]) Nullable() NullableNumberField[T] {
+	return NullableNumberField[T]{f, nullableOps{f.name}}
+}
+
+// NullableNumberField describes a nullable numeric attribute.
+type NullableNumberField[T Number] struct {
+	NumberField[T]
+	nullableOps
+}
+
+// NullableIntField describes a nullable integer attribute.
+type NullableIntField = NullableNumberField[int64]
+
+// NullableFloatField describes a nullable Float or Double attribute.
+type NullableFloatField = NullableNumberField[float64]
+
+// BoolField describes a Boolean attribute.
+type BoolField struct{ fieldBase }
+
+// NewBoolField declares a Boolean attribute.
+func NewBoolField(name string) BoolField { return BoolField{fieldBase{name}} }
+
+// Eq matches rows where the field equals v.
+func (f BoolField) Eq(v bool) Condition { return contract.Eq(f.name, v) }
+
+// Neq matches rows where the field differs from v.
+func (f BoolField) Neq(v bool) Condition { return contract.Neq(f.name, v) }
+
+// Nullable returns the descriptor of a nullable Boolean attribute.
+func (f BoolField) Nullable() NullableBoolField {
+	return NullableBoolField{f, nullableOps{f.name}}
+}
+
+// NullableBoolField describes a nullable Boolean attribute.
+type NullableBoolField struct {
+	BoolField
+	nullableOps
+}
+
+// TimeField describes a Timestamp attribute.
+type TimeField struct{ orderedField[time.Time] }
+
+// NewTimeField declares a Timestamp attribute.
+func NewTimeField(name string) TimeField {
+	return TimeField{orderedField[time.Time]{equalityField[time.Time]{fieldBase{name}}}}
+}
+
+// Nullable returns the descriptor of a nullable Timestamp attribute.
+func (f TimeField) Nullable() NullableTimeField {
+	return NullableTimeField{f, nullableOps{f.name}}
+}
+
+// NullableTimeField describes a nullable Timestamp attribute.
+type NullableTimeField struct {
+	TimeField
+	nullableOps
+}
+
+// RefField describes an identifier attribute, such as a primary key or a column holding another
+// table's key, whose values have type T. Besides equality it matches against nested queries.
+type RefField[T comparable] struct{ equalityField[T] }
+
+// NewRefField declares an identifier attribute.
+func NewRefField[T comparable](name string) RefField[T] {
+	return RefField[T]{equalityField[T]{fieldBase{name}}}
+}
+
+// Within matches rows whose field value appears in the nested query's results.
+func (f RefField[T]) Within(q Query) Condition { return contract.Within(f.name, q) }
+
+// NotWithin matches rows whose field value does not appear in the nested query's results.
+func (f RefField[T]) NotWithin(q Query) Condition { return contract.NotWithin(f.name, q) }
+
+// Nullable returns the descriptor of a nullable identifier attribute.
+func (f RefField[T]) Nullable() NullableRefField[T] {
+	return NullableRefField[T]{f, nullableOps{f.name}}
+}
+
+// NullableRefField describes a nullable identifier attribute.
+type NullableRefField[T comparable] struct {
+	RefField[T]
+	nullableOps
+}
+
+func toAny[T any](values []T) []any {
+	out := make([]any, 0, len(values))
+	for _, v := range values {
+		out = append(out, v)
+	}
+	return out
+}
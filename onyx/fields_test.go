@@ -0,0 +1,69 @@
+package onyx
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTypedFieldsMatchUntypedConditions(t *testing.T) {
+	when := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	email := NewStringField("email")
+	bio := NewStringField("bio").Nullable()
+	age := NewIntField("age").Nullable()
+	score := NewFloatField("score")
+	small := NewNumberField[int32]("rank")
+	active := NewBoolField("isActive")
+	created := NewTimeField("createdAt")
+	deleted := NewTimeField("deletedAt").Nullable()
+	id := NewRefField[string]("id")
+	owner := NewRefField[string]("ownerId").Nullable()
+
+	cases := []struct {
+		name        string
+		typed, want any
+	}{
+		{"eq", email.Eq("a@b.c"), Eq("email", "a@b.c")},
+		{"neq", email.Neq("x"), Neq("email", "x")},
+		{"in", email.In("a", "b"), In("email", []any{"a", "b"})},
+		{"not in", id.NotIn("1"), NotIn("id", []any{"1"})},
+		{"like", email.Like("%@onyx.dev"), Like("email", "%@onyx.dev")},
+		{"starts with", bio.StartsWith("Hi"), StartsWith("bio", "Hi")},
+		{"contains", email.Contains("onyx"), Contains("email", "onyx")},
+		{"is null", bio.IsNull(), IsNull("bio")},
+		{"not null", owner.NotNull(), NotNull("ownerId")},
+		{"gt", age.Gt(18), Gt("age", int64(18))},
+		{"gte", score.Gte(0.5), Gte("score", 0.5)},
+		{"lt", small.Lt(3), Lt("rank", int32(3))},
+		{"lte", created.Lte(when), Lte("createdAt", when)},
+		{"between", deleted.Between(when, when.Add(time.Hour)), Between("deletedAt", when, when.Add(time.Hour))},
+		{"bool", active.Eq(true), Eq("isActive", true)},
+		{"within", owner.Within(stubMarshalQuery{}), Within("ownerId", stubMarshalQuery{})},
+		{"not within", id.NotWithin(stubMarshalQuery{}), NotWithin("id", stubMarshalQuery{})},
+		{"asc", age.Asc(), Asc("age")},
+		{"desc", created.Desc(), Desc("createdAt")},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := json.Marshal(tc.typed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, err := json.Marshal(tc.want)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != string(want) {
+				t.Fatalf("typed %s, untyped %s", got, want)
+			}
+		})
+	}
+}
+
+func TestFieldNames(t *testing.T) {
+	got := FieldNames(NewStringField("email"), NewIntField("age").Nullable(), NewRefField[int64]("id"))
+	if !reflect.DeepEqual(got, []string{"email", "age", "id"}) {
+		t.Fatalf("unexpected names %v", got)
+	}
+}
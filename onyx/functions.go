@@ -1,6 +1,10 @@
 package onyx
 
-import "github.com/OnyxDevTools/onyx-database-go/contract"
+import (
+	"context"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
 
 // Re-export contract helpers to keep the public surface stable.
 func Asc(field string) Sort                        { return contract.Asc(field) }
@@ -26,8 +30,25 @@ func Within(field string, query Query) Condition    { return contract.Within(fie
 func NotWithin(field string, query Query) Condition { return contract.NotWithin(field, query) }
 func Cascade(spec string) CascadeSpec               { return contract.Cascade(spec) }
 func NewCascadeBuilder() CascadeBuilder             { return contract.NewCascadeBuilder() }
+func ParseCascadeSpec(spec string) ([]CascadeGraph, error) {
+	return contract.ParseCascadeSpec(spec)
+}
+func CascadeFromGraphs(graphs []CascadeGraph) CascadeSpec { return contract.CascadeFromGraphs(graphs) }
 func NewError(code, message string, meta map[string]any) *Error {
 	return contract.NewError(code, message, meta)
 }
 func NormalizeSchema(s Schema) Schema             { return contract.NormalizeSchema(s) }
 func ParseSchemaJSON(data []byte) (Schema, error) { return contract.ParseSchemaJSON(data) }
+func ParseSchemaHistoryJSON(data []byte) ([]SchemaRevision, error) {
+	return contract.ParseSchemaHistoryJSON(data)
+}
+func SchemaToEntities(s Schema) []Entity { return contract.SchemaToEntities(s) }
+func Matches(cond Condition, record map[string]any) (bool, error) {
+	return contract.Matches(cond, record)
+}
+func FilterRecords(cond Condition, records []map[string]any) (QueryResults, error) {
+	return contract.FilterRecords(cond, records)
+}
+func WithoutCoalescing(ctx context.Context) context.Context {
+	return contract.WithoutCoalescing(ctx)
+}
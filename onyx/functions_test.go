@@ -16,12 +16,15 @@ func (s stubMarshalQuery) Or(condition contract.Condition) contract.Query    { r
 func (s stubMarshalQuery) Search(queryText string, minScore ...float64) contract.Query {
 	return s
 }
-func (s stubMarshalQuery) Select(fields ...string) contract.Query                  { return s }
-func (s stubMarshalQuery) GroupBy(fields ...string) contract.Query                 { return s }
-func (s stubMarshalQuery) Resolve(paths ...string) contract.Query                  { return s }
-func (s stubMarshalQuery) OrderBy(sorts ...contract.Sort) contract.Query           { return s }
-func (s stubMarshalQuery) Limit(limit int) contract.Query                          { return s }
-func (s stubMarshalQuery) SetUpdates(updates map[string]any) contract.Query        { return s }
+func (s stubMarshalQuery) Select(fields ...string) contract.Query           { return s }
+func (s stubMarshalQuery) GroupBy(fields ...string) contract.Query          { return s }
+func (s stubMarshalQuery) Resolve(paths ...string) contract.Query           { return s }
+func (s stubMarshalQuery) OrderBy(sorts ...contract.Sort) contract.Query    { return s }
+func (s stubMarshalQuery) Limit(limit int) contract.Query                   { return s }
+func (s stubMarshalQuery) SetUpdates(updates map[string]any) contract.Query { return s }
+func (s stubMarshalQuery) Filter(records []map[string]any) (contract.QueryResults, error) {
+	return records, nil
+}
 func (s stubMarshalQuery) MarshalJSON() ([]byte, error)                            { return []byte(`{"query":"ok"}`), nil }
 func (s stubMarshalQuery) List(ctx context.Context) (contract.QueryResults, error) { return nil, nil }
 func (s stubMarshalQuery) Page(ctx context.Context, cursor string) (contract.PageResult, error) {
@@ -31,6 +34,7 @@ func (s stubMarshalQuery) Stream(ctx context.Context) (contract.Iterator, error)
 func (s stubMarshalQuery) Update(ctx context.Context) (int, error)               { return 0, nil }
 func (s stubMarshalQuery) Delete(ctx context.Context) (int, error)               { return 0, nil }
 func (s stubMarshalQuery) InPartition(string) contract.Query                     { return s }
+func (s stubMarshalQuery) Fresh() contract.Query                                 { return s }
 
 func TestReExportedHelpers(t *testing.T) {
 	assertJSONEqual := func(t *testing.T, got, want any) {
@@ -1,6 +1,12 @@
 package onyx
 
-import "context"
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
 
 // ListInto executes the query and decodes the results into dest.
 // Dest should be a pointer to a slice or struct; Decode uses JSON tags on generated models.
@@ -31,3 +37,87 @@ func (lr ListResult) Decode(dest any) error {
 	}
 	return lr.results.Decode(dest)
 }
+
+// Close stops a client's background work, such as the table watchers started for
+// QueryCacheConfig.WatchTables, and waits for it to finish. Clients with nothing to stop,
+// including test fakes, are left alone.
+func Close(c Client) error {
+	if closer, ok := c.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// ErrCodeConflict is the Error code reported when an optimistic-locking save loses a race.
+const ErrCodeConflict = contract.ErrCodeConflict
+
+// IsConflict reports whether err is an optimistic-locking conflict.
+func IsConflict(err error) bool { return contract.IsConflict(err) }
+
+const (
+	defaultConflictAttempts = 5
+	defaultConflictBackoff  = 10 * time.Millisecond
+)
+
+// ConflictRetry configures RetryOnConflictWith. Zero fields take their defaults.
+type ConflictRetry struct {
+	// MaxAttempts bounds the number of runs (default 5).
+	MaxAttempts int
+	// Backoff is the delay before the first retry; it doubles per attempt (default 10ms).
+	Backoff time.Duration
+	// Sleep waits d between attempts and returns ctx.Err() if ctx ends first. It defaults to a
+	// timer; tests inject one that returns immediately.
+	Sleep func(ctx context.Context, d time.Duration) error
+}
+
+// RetryOnConflict runs fn, re-running it while it fails with a version conflict.
+// fn should perform the whole read-modify-write cycle so each attempt starts from a fresh read.
+// maxAttempts defaults to 5; the last conflict error is returned once attempts are exhausted.
+func RetryOnConflict(ctx context.Context, fn func(ctx context.Context) error, maxAttempts ...int) error {
+	var opts ConflictRetry
+	if len(maxAttempts) > 0 {
+		opts.MaxAttempts = maxAttempts[0]
+	}
+	return RetryOnConflictWith(ctx, opts, fn)
+}
+
+// RetryOnConflictWith is RetryOnConflict with explicit attempts, backoff and sleep.
+func RetryOnConflictWith(ctx context.Context, opts ConflictRetry, fn func(ctx context.Context) error) error {
+	attempts := opts.MaxAttempts
+	if attempts <= 0 {
+		attempts = defaultConflictAttempts
+	}
+	delay := opts.Backoff
+	if delay <= 0 {
+		delay = defaultConflictBackoff
+	}
+	sleep := opts.Sleep
+	if sleep == nil {
+		sleep = sleepContext
+	}
+
+	var err error
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			if serr := sleep(ctx, delay); serr != nil {
+				return serr
+			}
+			delay *= 2
+		}
+		if err = fn(ctx); !IsConflict(err) {
+			return err
+		}
+	}
+	return err
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
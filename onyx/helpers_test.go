@@ -2,7 +2,9 @@ package onyx
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/OnyxDevTools/onyx-database-go/contract"
 )
@@ -18,12 +20,15 @@ func (s *stubQuery) Or(condition contract.Condition) contract.Query    { return
 func (s *stubQuery) Search(queryText string, minScore ...float64) contract.Query {
 	return s
 }
-func (s *stubQuery) Select(fields ...string) contract.Query                  { return s }
-func (s *stubQuery) GroupBy(fields ...string) contract.Query                 { return s }
-func (s *stubQuery) Resolve(paths ...string) contract.Query                  { return s }
-func (s *stubQuery) OrderBy(sorts ...contract.Sort) contract.Query           { return s }
-func (s *stubQuery) Limit(limit int) contract.Query                          { return s }
-func (s *stubQuery) SetUpdates(updates map[string]any) contract.Query        { return s }
+func (s *stubQuery) Select(fields ...string) contract.Query           { return s }
+func (s *stubQuery) GroupBy(fields ...string) contract.Query          { return s }
+func (s *stubQuery) Resolve(paths ...string) contract.Query           { return s }
+func (s *stubQuery) OrderBy(sorts ...contract.Sort) contract.Query    { return s }
+func (s *stubQuery) Limit(limit int) contract.Query                   { return s }
+func (s *stubQuery) SetUpdates(updates map[string]any) contract.Query { return s }
+func (s *stubQuery) Filter(records []map[string]any) (contract.QueryResults, error) {
+	return records, nil
+}
 func (s *stubQuery) MarshalJSON() ([]byte, error)                            { return nil, nil }
 func (s *stubQuery) List(ctx context.Context) (contract.QueryResults, error) { return s.results, s.err }
 func (s *stubQuery) Page(ctx context.Context, cursor string) (contract.PageResult, error) {
@@ -33,6 +38,7 @@ func (s *stubQuery) Stream(ctx context.Context) (contract.Iterator, error) { ret
 func (s *stubQuery) Update(ctx context.Context) (int, error)               { return 0, nil }
 func (s *stubQuery) Delete(ctx context.Context) (int, error)               { return 0, nil }
 func (s *stubQuery) InPartition(string) contract.Query                     { return s }
+func (s *stubQuery) Fresh() contract.Query                                 { return s }
 
 func TestListIntoDecodesResults(t *testing.T) {
 	q := &stubQuery{
@@ -90,3 +96,69 @@ func TestListIntoPropagatesError(t *testing.T) {
 		t.Fatalf("expected error propagation, got %v", err)
 	}
 }
+
+func TestRetryOnConflict(t *testing.T) {
+	var slept []time.Duration
+	opts := ConflictRetry{Backoff: time.Second, Sleep: func(ctx context.Context, d time.Duration) error {
+		slept = append(slept, d)
+		return nil
+	}}
+	calls := 0
+	err := RetryOnConflictWith(context.Background(), opts, func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return contract.NewError(ErrCodeConflict, "conflict", nil)
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Fatalf("expected success on third attempt, got %v after %d calls", err, calls)
+	}
+	if len(slept) != 2 || slept[0] != time.Second || slept[1] != 2*time.Second {
+		t.Fatalf("expected doubling backoff, got %v", slept)
+	}
+
+	calls = 0
+	err = RetryOnConflict(context.Background(), func(ctx context.Context) error {
+		calls++
+		return contract.NewError(ErrCodeConflict, "conflict", nil)
+	}, 2)
+	if !IsConflict(err) || calls != 2 {
+		t.Fatalf("expected conflict after 2 attempts, got %v after %d calls", err, calls)
+	}
+
+	boom := errors.New("boom")
+	calls = 0
+	if err := RetryOnConflict(context.Background(), func(ctx context.Context) error { calls++; return boom }); err != boom || calls != 1 {
+		t.Fatalf("expected non-conflict error to stop retries, got %v after %d calls", err, calls)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = RetryOnConflict(ctx, func(ctx context.Context) error {
+		return contract.NewError(ErrCodeConflict, "conflict", nil)
+	})
+	if err != context.Canceled {
+		t.Fatalf("expected cancellation, got %v", err)
+	}
+}
+
+type closingClient struct {
+	contract.Client
+	closed int
+}
+
+func (c *closingClient) Close() error {
+	c.closed++
+	return nil
+}
+
+func TestCloseStopsClientsThatHoldBackgroundWork(t *testing.T) {
+	c := &closingClient{}
+	if err := Close(c); err != nil || c.closed != 1 {
+		t.Fatalf("expected Close to be forwarded, got %v (closed=%d)", err, c.closed)
+	}
+	if err := Close(struct{ contract.Client }{}); err != nil {
+		t.Fatalf("clients without Close should be left alone, got %v", err)
+	}
+}
@@ -13,11 +13,31 @@ type (
 	Iterator                    = contract.Iterator
 	CascadeSpec                 = contract.CascadeSpec
 	CascadeBuilder              = contract.CascadeBuilder
+	CascadeGraph                = contract.CascadeGraph
 	CascadeClient               = contract.CascadeClient
+	CascadeRow                  = contract.CascadeRow
 	Schema                      = contract.Schema
+	SchemaRevision              = contract.SchemaRevision
 	Table                       = contract.Table
 	Field                       = contract.Field
 	Resolver                    = contract.Resolver
+	Index                       = contract.Index
+	Trigger                     = contract.Trigger
+	Entity                      = contract.Entity
+	EntityIdentifier            = contract.EntityIdentifier
+	EntityAttribute             = contract.EntityAttribute
+	QueryCacheConfig            = contract.QueryCacheConfig
+	QueryCacheStats             = contract.QueryCacheStats
+	CoalescingStats             = contract.CoalescingStats
+	ClientStats                 = contract.ClientStats
+	PatchOp                     = contract.PatchOp
+	PatchResult                 = contract.PatchResult
+	PatchStatus                 = contract.PatchStatus
+	UnitOfWork                  = contract.UnitOfWork
+	UnitOfWorkAction            = contract.UnitOfWorkAction
+	UnitOfWorkStatus            = contract.UnitOfWorkStatus
+	UnitOfWorkStep              = contract.UnitOfWorkStep
+	UnitOfWorkReport            = contract.UnitOfWorkReport
 	OnyxDocument                = contract.OnyxDocument
 	Document                    = contract.Document
 	OnyxDocumentsClient         = contract.OnyxDocumentsClient
@@ -45,3 +65,24 @@ type (
 	AIScriptApprovalRequest     = contract.AIScriptApprovalRequest
 	AIScriptApprovalResponse    = contract.AIScriptApprovalResponse
 )
+
+// Patch outcomes reported by Client.Patch and Client.PatchMany.
+const (
+	PatchUpdated = contract.PatchUpdated
+	PatchMissing = contract.PatchMissing
+	PatchFailed  = contract.PatchFailed
+)
+
+// Unit of work actions, step outcomes, and compensations reported by UnitOfWork.Commit.
+const (
+	UnitOfWorkSave           = contract.UnitOfWorkSave
+	UnitOfWorkUpdate         = contract.UnitOfWorkUpdate
+	UnitOfWorkDelete         = contract.UnitOfWorkDelete
+	UnitOfWorkPending        = contract.UnitOfWorkPending
+	UnitOfWorkApplied        = contract.UnitOfWorkApplied
+	UnitOfWorkFailed         = contract.UnitOfWorkFailed
+	UnitOfWorkRolledBack     = contract.UnitOfWorkRolledBack
+	UnitOfWorkRollbackFailed = contract.UnitOfWorkRollbackFailed
+	CompensateDelete         = contract.CompensateDelete
+	CompensateRestore        = contract.CompensateRestore
+)
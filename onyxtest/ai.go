@@ -0,0 +1,142 @@
+package onyxtest
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+// defaultModel is listed by GetModels and used for echo replies when no model is requested.
+const defaultModel = "onyx-test"
+
+// scriptMutationPattern flags scripts that write data and therefore need approval.
+var scriptMutationPattern = regexp.MustCompile(`\.\s*(save|delete|update|setUpdates)\s*\(`)
+
+// Chat answers with ChatHandler when set, otherwise with an assistant message echoing the last
+// user message.
+func (c *Client) Chat(ctx context.Context, req contract.AIChatCompletionRequest) (contract.AIChatCompletionResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return contract.AIChatCompletionResponse{}, err
+	}
+	if c.ChatHandler != nil {
+		return c.ChatHandler(ctx, req)
+	}
+	reply := ""
+	for _, m := range req.Messages {
+		if m.Role == "user" {
+			reply = m.Content
+		}
+	}
+	model := req.Model
+	if model == "" {
+		model = defaultModel
+	}
+	stop := "stop"
+	return contract.AIChatCompletionResponse{
+		ID:      "chatcmpl-onyxtest",
+		Object:  "chat.completion",
+		Created: c.now().Unix(),
+		Model:   model,
+		Choices: []contract.AIChatCompletionChoice{{
+			Message:      contract.AIChatMessage{Role: "assistant", Content: reply},
+			FinishReason: &stop,
+		}},
+	}, nil
+}
+
+// ChatStream streams the Chat reply one word per chunk.
+func (c *Client) ChatStream(ctx context.Context, req contract.AIChatCompletionRequest) (contract.AIChatStream, error) {
+	resp, err := c.Chat(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	var chunks []contract.AIChatCompletionChunk
+	for i, choice := range resp.Choices {
+		words := strings.SplitAfter(choice.Message.Content, " ")
+		for j, w := range words {
+			delta := contract.AIChatCompletionChunkDelta{Content: w}
+			if j == 0 {
+				delta.Role = choice.Message.Role
+			}
+			chunk := contract.AIChatCompletionChunk{ID: resp.ID, Object: "chat.completion.chunk", Created: resp.Created, Model: resp.Model,
+				Choices: []contract.AIChatCompletionChunkChoice{{Index: i, Delta: delta}}}
+			if j == len(words)-1 {
+				chunk.Choices[0].FinishReason = choice.FinishReason
+			}
+			chunks = append(chunks, chunk)
+		}
+	}
+	return &chatStream{ctx: ctx, chunks: chunks, pos: -1}, nil
+}
+
+func (c *Client) GetModels(ctx context.Context) (contract.AIModelsResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return contract.AIModelsResponse{}, err
+	}
+	models := c.Models
+	if len(models) == 0 {
+		models = []contract.AIModel{{ID: defaultModel, Object: "model", OwnedBy: "onyx"}}
+	}
+	return contract.AIModelsResponse{Object: "list", Data: append([]contract.AIModel{}, models...)}, nil
+}
+
+func (c *Client) GetModel(ctx context.Context, modelID string) (contract.AIModel, error) {
+	models, err := c.GetModels(ctx)
+	if err != nil {
+		return contract.AIModel{}, err
+	}
+	for _, m := range models.Data {
+		if m.ID == modelID {
+			return m, nil
+		}
+	}
+	return contract.AIModel{}, statusError(http.StatusNotFound, "not_found", "model %s not found", modelID)
+}
+
+// RequestScriptApproval requires approval for scripts that call save, delete or update.
+func (c *Client) RequestScriptApproval(ctx context.Context, req contract.AIScriptApprovalRequest) (contract.AIScriptApprovalResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return contract.AIScriptApprovalResponse{}, err
+	}
+	script := strings.TrimSpace(req.Script)
+	resp := contract.AIScriptApprovalResponse{NormalizedScript: script}
+	if scriptMutationPattern.MatchString(script) {
+		resp.RequiresApproval = true
+		resp.Findings = "script modifies data"
+		resp.ExpiresAtIso = c.now().Add(5 * time.Minute).UTC().Format(time.RFC3339)
+	}
+	return resp, nil
+}
+
+type chatStream struct {
+	ctx    context.Context
+	chunks []contract.AIChatCompletionChunk
+	pos    int
+	err    error
+}
+
+func (s *chatStream) Next() bool {
+	if s.err != nil {
+		return false
+	}
+	if err := s.ctx.Err(); err != nil {
+		s.err = err
+		return false
+	}
+	s.pos++
+	return s.pos < len(s.chunks)
+}
+
+func (s *chatStream) Chunk() contract.AIChatCompletionChunk {
+	if s.pos < 0 || s.pos >= len(s.chunks) {
+		return contract.AIChatCompletionChunk{}
+	}
+	return s.chunks[s.pos]
+}
+
+func (s *chatStream) Err() error   { return s.err }
+func (s *chatStream) Close() error { return nil }
@@ -0,0 +1,295 @@
+package onyxtest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+type cascadeClient struct {
+	client *Client
+	spec   contract.CascadeSpec
+}
+
+func (c *cascadeClient) Save(ctx context.Context, table string, entity any) error {
+	_, err := c.client.Save(ctx, table, entity, []string{c.spec.String()})
+	return err
+}
+
+// Delete removes the rows DryRunDelete reports, children before parents, under one lock.
+func (c *cascadeClient) Delete(ctx context.Context, table, id string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	c.client.mu.Lock()
+	defer c.client.mu.Unlock()
+	rows, err := c.collect(table, id)
+	if err != nil {
+		return err
+	}
+	for _, r := range rows {
+		st := c.client.tables[r.Table]
+		c.client.remove(st, st.keyFor(r.Record))
+	}
+	return nil
+}
+
+func (c *cascadeClient) DryRunDelete(ctx context.Context, table, id string) ([]contract.CascadeRow, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	c.client.mu.RLock()
+	defer c.client.mu.RUnlock()
+	return c.collect(table, id)
+}
+
+// collect walks the cascade graph from a row depth-first with the same rules as the SDK: spec
+// graphs and their nested graphs are followed, or, for an empty spec, every resolver keyed on
+// the primary key at every level. Rows already visited are skipped.
+func (c *cascadeClient) collect(table, id string) ([]contract.CascadeRow, error) {
+	graphs, err := contract.ParseCascadeSpec(c.spec.String())
+	if err != nil {
+		return nil, err
+	}
+	st, err := c.client.store(table)
+	if err != nil {
+		return nil, err
+	}
+	r, err := st.find(id, "")
+	if err != nil {
+		return nil, err
+	}
+	if r == nil {
+		return nil, fmt.Errorf("%s %s not found", table, id)
+	}
+	w := &cascadeWalk{client: c.client, visited: map[string]bool{}}
+	if err := w.visit(st, id, "", r.data, graphs, len(graphs) == 0); err != nil {
+		return nil, err
+	}
+	return w.rows, nil
+}
+
+type cascadeEdge struct {
+	name        string
+	table       string
+	childField  string
+	parentField string
+	graphs      []contract.CascadeGraph
+}
+
+type cascadeWalk struct {
+	client  *Client
+	visited map[string]bool
+	rows    []contract.CascadeRow
+}
+
+func (w *cascadeWalk) visit(st *tableStore, id, path string, record map[string]any, graphs []contract.CascadeGraph, followResolvers bool) error {
+	key := st.table.Name + "\x00" + st.keyFor(record).partition + "\x00" + id
+	if w.visited[key] {
+		return nil
+	}
+	w.visited[key] = true
+
+	var edges []cascadeEdge
+	if followResolvers {
+		for _, link := range resolverLinks(st.table) {
+			if link.SourceField == st.pk {
+				edges = append(edges, cascadeEdge{name: link.Resolver, table: link.Target, childField: link.TargetField, parentField: link.SourceField})
+			}
+		}
+	} else {
+		for _, g := range graphs {
+			childField, parentField, err := w.client.graphLink(st, g)
+			if err != nil {
+				return err
+			}
+			edges = append(edges, cascadeEdge{name: graphName(g), table: g.Type, childField: childField, parentField: parentField, graphs: g.Children})
+		}
+	}
+
+	for _, edge := range edges {
+		value, ok := record[edge.parentField]
+		if !ok || value == nil {
+			continue
+		}
+		child, err := w.client.store(edge.table)
+		if err != nil {
+			return err
+		}
+		childPath := edge.name
+		if path != "" {
+			childPath = path + "." + edge.name
+		}
+		for _, r := range child.sorted() {
+			if !valuesEqual(r.data[edge.childField], value) {
+				continue
+			}
+			if err := w.visit(child, idString(r.data[child.pk]), childPath, r.data, edge.graphs, followResolvers); err != nil {
+				return err
+			}
+		}
+	}
+
+	w.rows = append(w.rows, contract.CascadeRow{Table: st.table.Name, ID: id, Path: path, Record: cloneRecord(record)})
+	return nil
+}
+
+// UnitOfWork returns a unit of work that applies its operations in the order they were queued.
+// Since the fake holds all data in memory a failed commit restores every table exactly.
+func (c *Client) UnitOfWork() contract.UnitOfWork {
+	return &unitOfWork{client: c}
+}
+
+type unitOfWork struct {
+	client    *Client
+	ops       []uowOp
+	committed bool
+}
+
+type uowOp struct {
+	action        contract.UnitOfWorkAction
+	table         string
+	id            string
+	entity        any
+	relationships []string
+	updates       map[string]any
+}
+
+func (u *unitOfWork) Save(table string, entity any, relationships ...string) contract.UnitOfWork {
+	u.ops = append(u.ops, uowOp{action: contract.UnitOfWorkSave, table: table, entity: entity, relationships: relationships})
+	return u
+}
+
+func (u *unitOfWork) Update(table, id string, updates map[string]any) contract.UnitOfWork {
+	u.ops = append(u.ops, uowOp{action: contract.UnitOfWorkUpdate, table: table, id: id, updates: updates})
+	return u
+}
+
+func (u *unitOfWork) Delete(table, id string) contract.UnitOfWork {
+	u.ops = append(u.ops, uowOp{action: contract.UnitOfWorkDelete, table: table, id: id})
+	return u
+}
+
+func (u *unitOfWork) Commit(ctx context.Context) (contract.UnitOfWorkReport, error) {
+	if u.committed {
+		return contract.UnitOfWorkReport{}, fmt.Errorf("unit of work already committed")
+	}
+	u.committed = true
+	if err := ctx.Err(); err != nil {
+		return contract.UnitOfWorkReport{}, err
+	}
+
+	report := contract.UnitOfWorkReport{Steps: make([]contract.UnitOfWorkStep, len(u.ops))}
+	for i, op := range u.ops {
+		report.Steps[i] = contract.UnitOfWorkStep{Action: op.action, Table: op.table, ID: op.id, Status: contract.UnitOfWorkPending}
+	}
+
+	c := u.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	snapshot := c.snapshotRows()
+	changes := []change{}
+	c.pending = &changes
+	defer func() { c.pending = nil }()
+	for i, op := range u.ops {
+		step := &report.Steps[i]
+		if err := u.apply(op, step); err != nil {
+			step.Status, step.Err = contract.UnitOfWorkFailed, err
+			c.restoreRows(snapshot)
+			for j := 0; j < i; j++ {
+				report.Steps[j].Status = contract.UnitOfWorkRolledBack
+			}
+			return report, fmt.Errorf("unit of work %s %s %s: %w", step.Action, step.Table, step.ID, err)
+		}
+		step.Status = contract.UnitOfWorkApplied
+	}
+	report.Committed = true
+	c.deliver(changes...)
+	return report, nil
+}
+
+// apply runs one queued operation and records the compensation the SDK would use for it. The
+// caller holds the lock.
+func (u *unitOfWork) apply(op uowOp, step *contract.UnitOfWorkStep) error {
+	c := u.client
+	st, err := c.store(op.table)
+	if err != nil {
+		return err
+	}
+	switch op.action {
+	case contract.UnitOfWorkSave:
+		record, err := toRecord(op.entity)
+		if err != nil {
+			return err
+		}
+		existed := false
+		if id := record[st.pk]; id != nil {
+			_, existed = st.rows[st.keyFor(record)]
+		}
+		saved, err := c.save(op.table, record, op.relationships)
+		if err != nil {
+			return err
+		}
+		step.ID = idString(saved[st.pk])
+		step.Compensation = contract.CompensateDelete
+		if existed {
+			step.Compensation = contract.CompensateRestore
+		}
+		return nil
+	case contract.UnitOfWorkUpdate:
+		if strings.TrimSpace(op.id) == "" || len(op.updates) == 0 {
+			return fmt.Errorf("update id and updates are required")
+		}
+		r, err := st.find(op.id, "")
+		if err != nil {
+			return err
+		}
+		if r == nil {
+			return fmt.Errorf("record %s not found", op.id)
+		}
+		step.Compensation = contract.CompensateRestore
+		return c.update(st, r, op.updates)
+	default:
+		r, err := st.find(op.id, "")
+		if err != nil {
+			return err
+		}
+		if r == nil {
+			return statusError(http.StatusNotFound, "not_found", "%s %s not found", op.table, op.id)
+		}
+		c.remove(st, st.keyFor(r.data))
+		step.Compensation = contract.CompensateRestore
+		return nil
+	}
+}
+
+// tableSnapshot is a deep copy of a table's rows, taken before a unit of work runs.
+type tableSnapshot struct {
+	rows     map[rowKey]*row
+	sequence int64
+}
+
+func (c *Client) snapshotRows() map[string]tableSnapshot {
+	snap := make(map[string]tableSnapshot, len(c.tables))
+	for name, st := range c.tables {
+		rows := make(map[rowKey]*row, len(st.rows))
+		for k, r := range st.rows {
+			copied := *r
+			copied.data = cloneRecord(r.data)
+			rows[k] = &copied
+		}
+		snap[name] = tableSnapshot{rows: rows, sequence: st.sequence}
+	}
+	return snap
+}
+
+func (c *Client) restoreRows(snap map[string]tableSnapshot) {
+	for name, st := range c.tables {
+		if s, ok := snap[name]; ok {
+			st.rows, st.sequence = s.rows, s.sequence
+		}
+	}
+}
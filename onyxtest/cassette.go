@@ -0,0 +1,392 @@
+package onyxtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// CassetteMode selects whether a Cassette records, replays or passes requests through.
+type CassetteMode string
+
+const (
+	// CassetteReplay answers requests from the cassette file without touching the network.
+	CassetteReplay CassetteMode = "replay"
+	// CassetteRecord forwards requests and writes every interaction to the cassette file.
+	CassetteRecord CassetteMode = "record"
+	// CassettePassthrough forwards requests and records nothing.
+	CassettePassthrough CassetteMode = "passthrough"
+)
+
+// CassetteModeEnv is the environment variable NewCassette reads the mode from. It defaults to
+// replay, so tests never reach the network unless asked to.
+const CassetteModeEnv = "ONYX_CASSETTE_MODE"
+
+// redacted replaces scrubbed header, query and body values.
+const redacted = "[REDACTED]"
+
+// scrubbedHeaders are never written to a cassette.
+var scrubbedHeaders = []string{"x-onyx-key", "x-onyx-secret", "Authorization", "Cookie", "Set-Cookie"}
+
+// Cassette is an http.RoundTripper that records request/response pairs to a JSON file and
+// replays them later. Use it through Config.HTTPClient:
+//
+//	cas, err := onyxtest.NewCassette("testdata/users.cassette.json")
+//	defer cas.Close()
+//	cfg.HTTPClient = cas.Client()
+//
+// Replayed requests are matched on method, path, query and JSON body, with object keys in any
+// order. Each recorded interaction answers one request, in recording order. Streamed NDJSON and
+// SSE bodies are recorded up to the point the caller closed them.
+//
+// Credentials are scrubbed before anything is written: the x-onyx-key, x-onyx-secret,
+// Authorization and cookie headers are dropped, and every query parameter or JSON field named in
+// ScrubFields is replaced, in requests and responses alike.
+type Cassette struct {
+	// Path is the cassette file.
+	Path string
+	// Mode is read from CassetteModeEnv by NewCassette.
+	Mode CassetteMode
+	// Transport performs real requests when recording or passing through. Defaults to
+	// http.DefaultTransport.
+	Transport http.RoundTripper
+	// ScrubFields names query parameters and JSON fields, at any depth, whose values are
+	// redacted. Redacted values no longer tell requests apart when matching; note that "value"
+	// also appears in query conditions. NewCassette sets apiKey and apiSecret; append to it before
+	// the first request.
+	ScrubFields []string
+
+	mu           sync.Mutex
+	interactions []*interaction
+}
+
+type interaction struct {
+	Request  recordedRequest  `json:"request"`
+	Response recordedResponse `json:"response"`
+	used     bool
+	body     *recordingBody
+}
+
+type recordedRequest struct {
+	Method  string              `json:"method"`
+	Path    string              `json:"path"`
+	Query   string              `json:"query,omitempty"`
+	Headers map[string][]string `json:"headers,omitempty"`
+	Body    json.RawMessage     `json:"body,omitempty"`
+	Text    string              `json:"text,omitempty"`
+}
+
+type recordedResponse struct {
+	Status  int                 `json:"status"`
+	Headers map[string][]string `json:"headers,omitempty"`
+	// Body holds JSON responses; Text holds anything else, such as NDJSON or SSE streams.
+	Body json.RawMessage `json:"body,omitempty"`
+	Text string          `json:"text,omitempty"`
+}
+
+type cassetteFile struct {
+	Interactions []*interaction `json:"interactions"`
+}
+
+// NewCassette returns a cassette for path in the mode given by CassetteModeEnv. In replay mode
+// the file is loaded immediately and must exist.
+func NewCassette(path string) (*Cassette, error) {
+	mode := CassetteMode(strings.ToLower(strings.TrimSpace(os.Getenv(CassetteModeEnv))))
+	if mode == "" {
+		mode = CassetteReplay
+	}
+	switch mode {
+	case CassetteReplay, CassetteRecord, CassettePassthrough:
+	default:
+		return nil, fmt.Errorf("%s: unknown cassette mode %q", CassetteModeEnv, mode)
+	}
+	c := &Cassette{Path: path, Mode: mode, ScrubFields: []string{"apiKey", "apiSecret"}}
+	if mode == CassetteReplay {
+		if err := c.load(); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+func (c *Cassette) load() error {
+	data, err := os.ReadFile(c.Path)
+	if err != nil {
+		return fmt.Errorf("load cassette: %w", err)
+	}
+	var file cassetteFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("parse cassette %s: %w", c.Path, err)
+	}
+	// The file is indented. Matching compares compact bodies, and a single-record NDJSON stream
+	// must be replayed on one line.
+	for _, in := range file.Interactions {
+		for _, body := range []*json.RawMessage{&in.Request.Body, &in.Response.Body} {
+			if len(*body) == 0 {
+				continue
+			}
+			var buf bytes.Buffer
+			if err := json.Compact(&buf, *body); err != nil {
+				return fmt.Errorf("parse cassette %s: %w", c.Path, err)
+			}
+			*body = buf.Bytes()
+		}
+	}
+	c.interactions = file.Interactions
+	return nil
+}
+
+// Client returns an HTTP client that sends every request through the cassette.
+func (c *Cassette) Client() *http.Client {
+	return &http.Client{Transport: c}
+}
+
+// Close writes the recorded interactions in record mode; it does nothing otherwise.
+func (c *Cassette) Close() error {
+	if c.Mode != CassetteRecord {
+		return nil
+	}
+	c.mu.Lock()
+	file := cassetteFile{Interactions: make([]*interaction, 0, len(c.interactions))}
+	for _, in := range c.interactions {
+		recorded := *in
+		if in.body != nil {
+			recorded.Response.Body, recorded.Response.Text = c.encodeBody(in.body.bytes())
+		}
+		file.Interactions = append(file.Interactions, &recorded)
+	}
+	c.mu.Unlock()
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.Path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(c.Path, append(data, '\n'), 0o644)
+}
+
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	switch c.Mode {
+	case CassetteReplay:
+		return c.replay(req, body)
+	case CassetteRecord:
+		return c.record(req, body)
+	default:
+		return c.forward(req, body)
+	}
+}
+
+func (c *Cassette) forward(req *http.Request, body []byte) (*http.Response, error) {
+	out := req.Clone(req.Context())
+	out.Body = io.NopCloser(bytes.NewReader(body))
+	out.ContentLength = int64(len(body))
+	transport := c.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return transport.RoundTrip(out)
+}
+
+func (c *Cassette) record(req *http.Request, body []byte) (*http.Response, error) {
+	resp, err := c.forward(req, body)
+	if err != nil {
+		return nil, err
+	}
+	in := &interaction{Request: c.requestKey(req, body), Response: recordedResponse{Status: resp.StatusCode, Headers: map[string][]string{}}}
+	for k, v := range resp.Header {
+		if k == "Date" || k == "Content-Length" || isScrubbedHeader(k) {
+			continue
+		}
+		in.Response.Headers[k] = v
+	}
+	for k, v := range req.Header {
+		if !isScrubbedHeader(k) {
+			if in.Request.Headers == nil {
+				in.Request.Headers = map[string][]string{}
+			}
+			in.Request.Headers[k] = v
+		}
+	}
+	in.body = &recordingBody{ReadCloser: resp.Body, mu: &c.mu}
+	resp.Body = in.body
+
+	c.mu.Lock()
+	c.interactions = append(c.interactions, in)
+	c.mu.Unlock()
+	return resp, nil
+}
+
+func (c *Cassette) replay(req *http.Request, body []byte) (*http.Response, error) {
+	key := c.requestKey(req, body)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, in := range c.interactions {
+		if in.used || !in.Request.matches(key) {
+			continue
+		}
+		in.used = true
+		resp := &http.Response{
+			Status:     fmt.Sprintf("%d %s", in.Response.Status, http.StatusText(in.Response.Status)),
+			StatusCode: in.Response.Status,
+			Proto:      "HTTP/1.1",
+			ProtoMajor: 1,
+			ProtoMinor: 1,
+			Header:     http.Header{},
+			Request:    req,
+		}
+		for k, v := range in.Response.Headers {
+			resp.Header[k] = append([]string(nil), v...)
+		}
+		payload := []byte(in.Response.Text)
+		if len(in.Response.Body) > 0 {
+			payload = in.Response.Body
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(payload))
+		resp.ContentLength = int64(len(payload))
+		return resp, nil
+	}
+	return nil, fmt.Errorf("cassette %s: no unused interaction for %s %s", c.Path, key.Method, key.target())
+}
+
+// requestKey is the scrubbed, normalized form of a request that is stored and matched.
+func (c *Cassette) requestKey(req *http.Request, body []byte) recordedRequest {
+	key := recordedRequest{Method: req.Method, Path: req.URL.Path}
+	if q := req.URL.Query(); len(q) > 0 {
+		for name := range q {
+			if c.scrubs(name) {
+				q.Set(name, redacted)
+			}
+		}
+		key.Query = q.Encode()
+	}
+	key.Body, key.Text = c.encodeBody(body)
+	return key
+}
+
+func (r recordedRequest) target() string {
+	if r.Query == "" {
+		return r.Path
+	}
+	return r.Path + "?" + r.Query
+}
+
+func (r recordedRequest) matches(other recordedRequest) bool {
+	return r.Method == other.Method && r.target() == other.target() &&
+		bytes.Equal(r.Body, other.Body) && strings.TrimSpace(r.Text) == strings.TrimSpace(other.Text)
+}
+
+// encodeBody returns a JSON body in scrubbed, compact form with sorted keys, or any other body
+// as text with each NDJSON line or SSE data payload scrubbed.
+func (c *Cassette) encodeBody(body []byte) (json.RawMessage, string) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return nil, ""
+	}
+	if normalized, ok := c.normalizeJSON(trimmed); ok {
+		return normalized, ""
+	}
+	lines := strings.Split(string(body), "\n")
+	for i, line := range lines {
+		prefix, payload := "", strings.TrimSpace(line)
+		if rest, ok := strings.CutPrefix(payload, "data:"); ok {
+			prefix, payload = "data: ", strings.TrimSpace(rest)
+		}
+		if normalized, ok := c.normalizeJSON([]byte(payload)); ok {
+			lines[i] = prefix + string(normalized)
+		}
+	}
+	return nil, strings.Join(lines, "\n")
+}
+
+func (c *Cassette) normalizeJSON(data []byte) (json.RawMessage, bool) {
+	if len(data) == 0 || !json.Valid(data) {
+		return nil, false
+	}
+	var v any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil || dec.More() {
+		return nil, false
+	}
+	out, err := json.Marshal(c.scrubValue(v))
+	if err != nil {
+		return nil, false
+	}
+	return out, true
+}
+
+func (c *Cassette) scrubValue(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, item := range t {
+			if c.scrubs(k) {
+				t[k] = redacted
+			} else {
+				t[k] = c.scrubValue(item)
+			}
+		}
+	case []any:
+		for i, item := range t {
+			t[i] = c.scrubValue(item)
+		}
+	}
+	return v
+}
+
+func (c *Cassette) scrubs(name string) bool {
+	for _, f := range c.ScrubFields {
+		if strings.EqualFold(f, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func isScrubbedHeader(name string) bool {
+	for _, h := range scrubbedHeaders {
+		if strings.EqualFold(h, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// recordingBody keeps a copy of everything read from a response body so streams are recorded
+// up to the point the caller stopped reading.
+type recordingBody struct {
+	io.ReadCloser
+	mu  *sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *recordingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		b.mu.Lock()
+		b.buf.Write(p[:n])
+		b.mu.Unlock()
+	}
+	return n, err
+}
+
+// bytes returns the data read so far. The caller holds the cassette lock.
+func (b *recordingBody) bytes() []byte {
+	return append([]byte(nil), b.buf.Bytes()...)
+}
@@ -0,0 +1,156 @@
+package onyxtest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+	"github.com/OnyxDevTools/onyx-database-go/impl"
+)
+
+// cassetteSession runs the same calls in record and replay mode and returns what they observed.
+func cassetteSession(t *testing.T, db contract.Client) []any {
+	t.Helper()
+	ctx := context.Background()
+	var out []any
+
+	saved, err := db.Save(ctx, "Role", map[string]any{"userId": "u1", "name": "admin"}, nil)
+	if err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	out = append(out, saved)
+	rows, err := db.From("Role").Where(contract.Eq("userId", "u1")).List(ctx)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	out = append(out, rows)
+
+	it, err := db.From("Role").Stream(ctx)
+	if err != nil {
+		t.Fatalf("stream: %v", err)
+	}
+	for it.Next() {
+		out = append(out, it.Value())
+	}
+	it.Close()
+
+	if _, err := db.PutSecret(ctx, contract.OnyxSecret{Key: "api", Value: "s3cret"}); err != nil {
+		t.Fatalf("put secret: %v", err)
+	}
+	stream, err := db.ChatStream(ctx, contract.AIChatCompletionRequest{Messages: []contract.AIChatMessage{{Role: "user", Content: "recorded reply"}}})
+	if err != nil {
+		t.Fatalf("chat stream: %v", err)
+	}
+	var text string
+	for stream.Next() {
+		text += stream.Chunk().Choices[0].Delta.Content
+	}
+	stream.Close()
+	return append(out, text)
+}
+
+func TestCassetteRecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassettes", "roles.json")
+	srv := NewServer(testSchema())
+	cfg := srv.Config()
+
+	t.Setenv(CassetteModeEnv, "record")
+	rec, err := NewCassette(path)
+	if err != nil {
+		t.Fatalf("new cassette: %v", err)
+	}
+	rec.Transport = srv.Client().Transport
+	rec.ScrubFields = append(rec.ScrubFields, "value")
+	cfg.HTTPClient = rec.Client()
+	db, err := impl.Init(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	recorded := cassetteSession(t, db)
+	if err := rec.Close(); err != nil {
+		t.Fatalf("write cassette: %v", err)
+	}
+	srv.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read cassette: %v", err)
+	}
+	for _, leaked := range []string{srv.APIKey, srv.APISecret, "s3cret"} {
+		if strings.Contains(string(data), leaked) {
+			t.Fatalf("cassette leaks %q:\n%s", leaked, data)
+		}
+	}
+	if !strings.Contains(string(data), redacted) || !strings.Contains(string(data), "data: [DONE]") {
+		t.Fatalf("unexpected cassette:\n%s", data)
+	}
+
+	t.Setenv(CassetteModeEnv, "replay")
+	rep, err := NewCassette(path)
+	if err != nil {
+		t.Fatalf("load cassette: %v", err)
+	}
+	rep.ScrubFields = append(rep.ScrubFields, "value")
+	cfg.HTTPClient = rep.Client()
+	db, err = impl.Init(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	replayed := cassetteSession(t, db)
+	if !reflect.DeepEqual(replayed[:len(replayed)-1], recorded[:len(recorded)-1]) || replayed[len(replayed)-1] != "recorded reply" {
+		t.Fatalf("replay differs:\n%v\n%v", replayed, recorded)
+	}
+
+	_, err = db.From("Role").Where(contract.Eq("name", "never recorded")).List(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "no unused interaction") {
+		t.Fatalf("expected unmatched request error, got %v", err)
+	}
+	if _, err := db.Save(context.Background(), "Role", map[string]any{"userId": "u1", "name": "admin"}, nil); err == nil {
+		t.Fatalf("each interaction should answer only one request")
+	}
+}
+
+func TestCassetteModes(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Setenv(CassetteModeEnv, "")
+	if _, err := NewCassette(filepath.Join(dir, "missing.json")); err == nil {
+		t.Fatalf("replay should require an existing cassette")
+	}
+
+	t.Setenv(CassetteModeEnv, "rewind")
+	if _, err := NewCassette(filepath.Join(dir, "x.json")); err == nil {
+		t.Fatalf("expected unknown mode error")
+	}
+
+	srv := NewServer(testSchema())
+	defer srv.Close()
+	t.Setenv(CassetteModeEnv, "passthrough")
+	cas, err := NewCassette(filepath.Join(dir, "pass.json"))
+	if err != nil {
+		t.Fatalf("new cassette: %v", err)
+	}
+	cas.Transport = srv.Client().Transport
+	cfg := srv.Config()
+	cfg.HTTPClient = cas.Client()
+	db, err := impl.Init(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	if _, err := db.Save(context.Background(), "Permission", map[string]any{"id": "p1"}, nil); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if err := cas.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "pass.json")); !os.IsNotExist(err) {
+		t.Fatalf("passthrough should not write a cassette: %v", err)
+	}
+	if len(srv.DB.Records("Permission")) != 1 {
+		t.Fatalf("passthrough should reach the server")
+	}
+}
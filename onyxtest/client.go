@@ -0,0 +1,751 @@
+package onyxtest
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+// Client is an in-memory contract.Client. It is safe for concurrent use; the exported fields
+// must be set before the client is shared.
+type Client struct {
+	// Clock supplies timestamps for documents, secrets and schema revisions. Defaults to time.Now.
+	Clock func() time.Time
+	// ChatHandler answers Chat and ChatStream. When nil the reply echoes the last user message.
+	ChatHandler func(ctx context.Context, req contract.AIChatCompletionRequest) (contract.AIChatCompletionResponse, error)
+	// Models is returned by GetModels. When empty a single "onyx-test" model is listed.
+	Models []contract.AIModel
+
+	mu        sync.RWMutex
+	schema    contract.Schema
+	tables    map[string]*tableStore
+	seq       int64
+	documents map[string]contract.OnyxDocument
+	secrets   map[string]contract.OnyxSecret
+	revisions []contract.SchemaRevision
+	watchers  map[*watcher]struct{}
+	// pending collects changes while a unit of work runs; see emit.
+	pending *[]change
+}
+
+// tableStore holds the rows of one table keyed by partition and primary key, so the same id
+// can be stored once per partition. Queries are narrowed with InPartition.
+type tableStore struct {
+	table    contract.Table
+	pk       string
+	rows     map[rowKey]*row
+	sequence int64
+}
+
+// rowKey identifies a row; partition is empty for tables without a partition field and for
+// records that leave it unset.
+type rowKey struct {
+	partition string
+	id        string
+}
+
+type row struct {
+	seq       int64
+	partition string
+	data      map[string]any
+}
+
+var _ contract.Client = (*Client)(nil)
+
+// NewClient returns an empty fake database with the given schema. The schema is recorded as
+// revision 1 of the schema history.
+func NewClient(schema contract.Schema) *Client {
+	c := &Client{documents: map[string]contract.OnyxDocument{}, secrets: map[string]contract.OnyxSecret{}}
+	c.setSchema(schema)
+	return c
+}
+
+// Seed saves records into table as Save would, without relationships. It is a shorthand for
+// arranging test data.
+func (c *Client) Seed(table string, records ...any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, r := range records {
+		if _, err := c.save(table, r, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Records returns a copy of every row stored in table, across partitions, in insertion order.
+func (c *Client) Records(table string) []map[string]any {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	st, ok := c.tables[table]
+	if !ok {
+		return nil
+	}
+	rows := st.sorted()
+	out := make([]map[string]any, len(rows))
+	for i, r := range rows {
+		out[i] = cloneRecord(r.data)
+	}
+	return out
+}
+
+// Reset removes every row, document and secret while keeping the schema and its history.
+func (c *Client) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, st := range c.tables {
+		st.rows = map[rowKey]*row{}
+		st.sequence = 0
+	}
+	c.documents = map[string]contract.OnyxDocument{}
+	c.secrets = map[string]contract.OnyxSecret{}
+}
+
+func (c *Client) now() time.Time {
+	if c.Clock != nil {
+		return c.Clock()
+	}
+	return time.Now()
+}
+
+// setSchema replaces the schema, keeping the rows of tables that still exist, and records a
+// revision. The caller holds the lock or owns c exclusively.
+func (c *Client) setSchema(schema contract.Schema) {
+	tables := make(map[string]*tableStore, len(schema.Tables))
+	for _, t := range schema.Tables {
+		st := &tableStore{table: t, pk: primaryField(t), rows: map[rowKey]*row{}}
+		if old, ok := c.tables[t.Name]; ok {
+			st.rows, st.sequence = old.rows, old.sequence
+		}
+		tables[t.Name] = st
+	}
+	c.schema = cloneSchema(schema)
+	c.tables = tables
+	c.revisions = append(c.revisions, contract.SchemaRevision{
+		Revision:  strconv.Itoa(len(c.revisions) + 1),
+		CreatedAt: c.now().UTC(),
+		Schema:    cloneSchema(schema),
+	})
+}
+
+func (c *Client) store(table string) (*tableStore, error) {
+	st, ok := c.tables[table]
+	if !ok {
+		return nil, statusError(http.StatusNotFound, "not_found", "table %s not found in schema", table)
+	}
+	if st.pk == "" {
+		return nil, statusError(http.StatusBadRequest, "bad_request", "table %s has no primary key", table)
+	}
+	return st, nil
+}
+
+// keyFor returns the key record is stored under.
+func (st *tableStore) keyFor(record map[string]any) rowKey {
+	key := rowKey{id: idString(record[st.pk])}
+	if st.table.Partition != "" && record[st.table.Partition] != nil {
+		key.partition = fmt.Sprint(record[st.table.Partition])
+	}
+	return key
+}
+
+// find returns the row with id in partition. Without a partition it returns the unpartitioned
+// row, or the only row with that id in any partition; a nil row means none exists. An id stored
+// in several partitions is an error, since the caller has to say which one it means.
+func (st *tableStore) find(id, partition string) (*row, error) {
+	if r, ok := st.rows[rowKey{partition: partition, id: id}]; ok || partition != "" {
+		return r, nil
+	}
+	var found *row
+	for key, r := range st.rows {
+		if key.id != id {
+			continue
+		}
+		if found != nil {
+			return nil, statusError(http.StatusBadRequest, "bad_request", "%s %s exists in several partitions; specify one", st.table.Name, id)
+		}
+		found = r
+	}
+	return found, nil
+}
+
+func (st *tableStore) sorted() []*row {
+	rows := make([]*row, 0, len(st.rows))
+	for _, r := range st.rows {
+		rows = append(rows, r)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].seq < rows[j].seq })
+	return rows
+}
+
+// statusError builds the error the HTTP client would return for the given status.
+func statusError(status int, code, format string, args ...any) *contract.Error {
+	return &contract.Error{Code: code, Message: fmt.Sprintf(format, args...), Meta: map[string]any{"status": status}}
+}
+
+func (c *Client) From(table string) contract.Query {
+	return &query{client: c, table: table}
+}
+
+func (c *Client) Search(queryText string, minScore ...float64) contract.Query {
+	return c.From(allTables).Search(queryText, minScore...)
+}
+
+func (c *Client) Cascade(spec contract.CascadeSpec) contract.CascadeClient {
+	return &cascadeClient{client: c, spec: spec}
+}
+
+// Save inserts or replaces entity. Relationships are cascade specs: each graph's attribute is
+// removed from the record and its rows are saved into the graph's table with the link field
+// set from the saved parent. The returned record includes the saved children.
+func (c *Client) Save(ctx context.Context, table string, entity any, relationships []string) (map[string]any, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	saved, err := c.save(table, entity, relationships)
+	if err != nil {
+		return nil, err
+	}
+	return cloneRecord(saved), nil
+}
+
+func (c *Client) save(table string, entity any, relationships []string) (map[string]any, error) {
+	record, err := toRecord(entity)
+	if err != nil {
+		return nil, err
+	}
+	// The SDK sends relationships as one comma-separated spec, so nested graphs may refer to
+	// parents given in an earlier element.
+	graphs, err := contract.ParseCascadeSpec(strings.Join(relationships, ","))
+	if err != nil {
+		return nil, err
+	}
+	return c.saveGraph(table, record, graphs)
+}
+
+func (c *Client) saveGraph(table string, record map[string]any, graphs []contract.CascadeGraph) (map[string]any, error) {
+	st, err := c.store(table)
+	if err != nil {
+		return nil, err
+	}
+	children := map[string]any{}
+	for _, g := range graphs {
+		if v, ok := record[graphName(g)]; ok {
+			children[graphName(g)] = v
+			delete(record, graphName(g))
+		}
+	}
+	saved, err := c.put(st, record)
+	if err != nil {
+		return nil, err
+	}
+
+	out := cloneRecord(saved)
+	for _, g := range graphs {
+		value, ok := children[graphName(g)]
+		if !ok || value == nil {
+			continue
+		}
+		childField, parentField, err := c.graphLink(st, g)
+		if err != nil {
+			return nil, err
+		}
+		link := saved[parentField]
+		saveChild := func(item any) (any, error) {
+			child, ok := item.(map[string]any)
+			if !ok {
+				return nil, statusError(http.StatusBadRequest, "bad_request", "cascade %s: expected objects, got %T", graphName(g), item)
+			}
+			if link != nil {
+				child[childField] = link
+			}
+			return c.saveGraph(g.Type, child, g.Children)
+		}
+		if items, ok := value.([]any); ok {
+			savedItems := make([]any, 0, len(items))
+			for _, item := range items {
+				savedItem, err := saveChild(item)
+				if err != nil {
+					return nil, err
+				}
+				savedItems = append(savedItems, savedItem)
+			}
+			out[graphName(g)] = savedItems
+			continue
+		}
+		savedItem, err := saveChild(value)
+		if err != nil {
+			return nil, err
+		}
+		out[graphName(g)] = savedItem
+	}
+	return out, nil
+}
+
+// graphLink returns the child field and the parent field a cascade graph joins on. A graph
+// without a target field joins on the parent's primary key; a graph without fields takes the
+// join from the parent's resolver of the same name.
+func (c *Client) graphLink(parent *tableStore, g contract.CascadeGraph) (childField, parentField string, err error) {
+	switch {
+	case g.SourceField != "" && g.TargetField != "":
+		return g.SourceField, g.TargetField, nil
+	case g.SourceField != "":
+		return g.SourceField, parent.pk, nil
+	}
+	for _, link := range resolverLinks(parent.table) {
+		if link.Resolver == g.Name && link.Target == g.Type {
+			return link.TargetField, link.SourceField, nil
+		}
+	}
+	return "", "", statusError(http.StatusBadRequest, "bad_request", "cascade graph %s on %s has no field mapping", graphName(g), parent.table.Name)
+}
+
+func graphName(g contract.CascadeGraph) string {
+	if g.Name != "" {
+		return g.Name
+	}
+	return g.Type
+}
+
+// put stores a full record, generating the primary key and applying field defaults.
+func (c *Client) put(st *tableStore, record map[string]any) (map[string]any, error) {
+	id := record[st.pk]
+	if id == nil || id == "" {
+		pkField, _ := st.table.Field(st.pk)
+		switch pkField.Generator {
+		case "UUID":
+			record[st.pk] = newUUID()
+		case "Sequence":
+			st.sequence++
+			record[st.pk] = float64(st.sequence)
+		default:
+			return nil, statusError(http.StatusBadRequest, "bad_request", "%s requires a value for primary key %s", st.table.Name, st.pk)
+		}
+	} else if n, ok := id.(float64); ok && int64(n) > st.sequence {
+		st.sequence = int64(n)
+	}
+	for _, f := range st.table.Fields {
+		if _, ok := record[f.Name]; !ok && f.Default != nil {
+			record[f.Name] = normalizeValue(f.Default)
+		}
+	}
+
+	key := st.keyFor(record)
+	for _, f := range st.table.Fields {
+		v := record[f.Name]
+		if !f.Unique || f.Primary || v == nil {
+			continue
+		}
+		for otherKey, other := range st.rows {
+			if otherKey != key && valuesEqual(other.data[f.Name], v) {
+				return nil, statusError(http.StatusConflict, "duplicate", "%s.%s value %v already exists", st.table.Name, f.Name, v)
+			}
+		}
+	}
+
+	r, existed := st.rows[key]
+	if !existed {
+		c.seq++
+		r = &row{seq: c.seq}
+		st.rows[key] = r
+	}
+	r.data = record
+	r.partition = key.partition
+	action := ActionCreate
+	if existed {
+		action = ActionUpdate
+	}
+	c.emit(action, st, r)
+	return record, nil
+}
+
+// Delete removes the record with id. When the id is stored in several partitions Delete fails;
+// delete through a query with InPartition instead.
+func (c *Client) Delete(ctx context.Context, table, id string) error {
+	return c.deleteRecord(ctx, table, id, "")
+}
+
+func (c *Client) deleteRecord(ctx context.Context, table, id, partition string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	st, err := c.store(table)
+	if err != nil {
+		return err
+	}
+	r, err := st.find(id, partition)
+	if err != nil {
+		return err
+	}
+	if r == nil {
+		return statusError(http.StatusNotFound, "not_found", "%s %s not found", table, id)
+	}
+	c.remove(st, st.keyFor(r.data))
+	return nil
+}
+
+func (c *Client) BatchSave(ctx context.Context, table string, entities []any, batchSize int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, e := range entities {
+		if _, err := c.save(table, e, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Client) Patch(ctx context.Context, table, id string, updates map[string]any) (contract.PatchResult, error) {
+	res := c.patchOne(ctx, table, contract.PatchOp{ID: id, Updates: updates})
+	return res, res.Err
+}
+
+func (c *Client) PatchMany(ctx context.Context, table string, ops []contract.PatchOp) ([]contract.PatchResult, error) {
+	if len(ops) == 0 {
+		return nil, nil
+	}
+	results := make([]contract.PatchResult, len(ops))
+	for i, op := range ops {
+		results[i] = c.patchOne(ctx, table, op)
+	}
+	return results, ctx.Err()
+}
+
+func (c *Client) patchOne(ctx context.Context, table string, op contract.PatchOp) contract.PatchResult {
+	res := contract.PatchResult{ID: op.ID}
+	switch {
+	case ctx.Err() != nil:
+		res.Status, res.Err = contract.PatchFailed, ctx.Err()
+		return res
+	case strings.TrimSpace(op.ID) == "":
+		res.Status, res.Err = contract.PatchFailed, fmt.Errorf("patch id is required")
+		return res
+	case len(op.Updates) == 0:
+		res.Status, res.Err = contract.PatchFailed, fmt.Errorf("patch updates are required")
+		return res
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	st, err := c.store(table)
+	if err != nil {
+		res.Status, res.Err = contract.PatchFailed, err
+		return res
+	}
+	r, err := st.find(op.ID, "")
+	if err != nil {
+		res.Status, res.Err = contract.PatchFailed, err
+		return res
+	}
+	if r == nil {
+		res.Status = contract.PatchMissing
+		return res
+	}
+	if err := c.update(st, r, op.Updates); err != nil {
+		res.Status, res.Err = contract.PatchFailed, err
+		return res
+	}
+	res.Status = contract.PatchUpdated
+	return res
+}
+
+// update applies partial updates to a row. Dotted keys set values inside embedded objects.
+func (c *Client) update(st *tableStore, r *row, updates map[string]any) error {
+	record := cloneRecord(r.data)
+	for k, v := range updates {
+		setPath(record, k, normalizeValue(v))
+	}
+	oldKey, newKey := st.keyFor(r.data), st.keyFor(record)
+	if newKey.id != oldKey.id {
+		return statusError(http.StatusBadRequest, "bad_request", "primary key %s cannot be updated", st.pk)
+	}
+	if newKey.partition != oldKey.partition {
+		return statusError(http.StatusBadRequest, "bad_request", "partition field %s cannot be updated", st.table.Partition)
+	}
+	_, err := c.put(st, record)
+	return err
+}
+
+// Stats always reports zero counters: the fake answers every query directly and has no query
+// cache.
+func (c *Client) Stats() contract.ClientStats {
+	return contract.ClientStats{}
+}
+
+func (c *Client) Schema(ctx context.Context) (contract.Schema, error) {
+	return c.GetSchema(ctx, nil)
+}
+
+// GetSchema returns the schema, limited to the named tables when any are given.
+func (c *Client) GetSchema(ctx context.Context, tables []string) (contract.Schema, error) {
+	if err := ctx.Err(); err != nil {
+		return contract.Schema{}, err
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if len(tables) == 0 {
+		return cloneSchema(c.schema), nil
+	}
+	out := contract.Schema{}
+	for _, name := range tables {
+		if t, ok := c.schema.Table(name); ok {
+			out.Tables = append(out.Tables, t)
+		}
+	}
+	return cloneSchema(out), nil
+}
+
+// PublishSchema validates and installs schema, keeping the rows of tables that remain.
+func (c *Client) PublishSchema(ctx context.Context, schema contract.Schema) error {
+	if err := c.ValidateSchema(ctx, schema); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.setSchema(schema)
+	return nil
+}
+
+// UpdateSchema behaves as PublishSchema; the fake has no separate draft state.
+func (c *Client) UpdateSchema(ctx context.Context, schema contract.Schema, publish bool) error {
+	return c.PublishSchema(ctx, schema)
+}
+
+// ValidateSchema reports tables without a single primary key and duplicate table or field names.
+func (c *Client) ValidateSchema(ctx context.Context, schema contract.Schema) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var problems []string
+	tables := map[string]bool{}
+	for _, t := range schema.Tables {
+		if tables[t.Name] {
+			problems = append(problems, fmt.Sprintf("duplicate table %s", t.Name))
+		}
+		tables[t.Name] = true
+		fields := map[string]bool{}
+		primaries := 0
+		for _, f := range t.Fields {
+			if fields[f.Name] {
+				problems = append(problems, fmt.Sprintf("table %s: duplicate field %s", t.Name, f.Name))
+			}
+			fields[f.Name] = true
+			if f.Primary {
+				primaries++
+			}
+		}
+		if primaries != 1 {
+			problems = append(problems, fmt.Sprintf("table %s: expected one primary key, found %d", t.Name, primaries))
+		}
+	}
+	if len(problems) > 0 {
+		return statusError(http.StatusBadRequest, "invalid_schema", "%s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func (c *Client) GetSchemaHistory(ctx context.Context) ([]contract.Schema, error) {
+	revisions, err := c.GetSchemaRevisions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	history := make([]contract.Schema, 0, len(revisions))
+	for _, rev := range revisions {
+		history = append(history, rev.Schema)
+	}
+	return history, nil
+}
+
+// GetSchemaRevisions lists the initial schema and every published one, oldest first.
+func (c *Client) GetSchemaRevisions(ctx context.Context) ([]contract.SchemaRevision, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	out := make([]contract.SchemaRevision, len(c.revisions))
+	for i, rev := range c.revisions {
+		rev.Schema = cloneSchema(rev.Schema)
+		out[i] = rev
+	}
+	return out, nil
+}
+
+func (c *Client) ListSecrets(ctx context.Context) ([]contract.OnyxSecret, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	out := make([]contract.OnyxSecret, 0, len(c.secrets))
+	for _, s := range c.secrets {
+		out = append(out, s)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out, nil
+}
+
+func (c *Client) GetSecret(ctx context.Context, key string) (contract.OnyxSecret, error) {
+	if err := ctx.Err(); err != nil {
+		return contract.OnyxSecret{}, err
+	}
+	if key == "" {
+		return contract.OnyxSecret{}, fmt.Errorf("secret key is required")
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	s, ok := c.secrets[key]
+	if !ok {
+		return contract.OnyxSecret{}, statusError(http.StatusNotFound, "not_found", "secret %s not found", key)
+	}
+	return s, nil
+}
+
+// PutSecret stores the secret, stamping CreatedAt on first write and UpdatedAt on every write.
+func (c *Client) PutSecret(ctx context.Context, secret contract.OnyxSecret) (contract.OnyxSecret, error) {
+	if err := ctx.Err(); err != nil {
+		return contract.OnyxSecret{}, err
+	}
+	if secret.Key == "" {
+		return contract.OnyxSecret{}, fmt.Errorf("secret key is required")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stamp := c.now().UTC().Format(time.RFC3339)
+	secret.CreatedAt, secret.UpdatedAt = stamp, stamp
+	if existing, ok := c.secrets[secret.Key]; ok {
+		secret.CreatedAt = existing.CreatedAt
+	}
+	c.secrets[secret.Key] = secret
+	return secret, nil
+}
+
+func (c *Client) DeleteSecret(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if key == "" {
+		return fmt.Errorf("secret key is required")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.secrets[key]; !ok {
+		return statusError(http.StatusNotFound, "not_found", "secret %s not found", key)
+	}
+	delete(c.secrets, key)
+	return nil
+}
+
+func primaryField(t contract.Table) string {
+	for _, f := range t.Fields {
+		if f.Primary {
+			return f.Name
+		}
+	}
+	return ""
+}
+
+// toRecord converts an entity to its JSON object form, the shape records are stored in.
+func toRecord(entity any) (map[string]any, error) {
+	if m, ok := entity.(map[string]any); ok {
+		return normalizeValue(m).(map[string]any), nil
+	}
+	raw, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+	var record map[string]any
+	if err := json.Unmarshal(raw, &record); err != nil {
+		return nil, fmt.Errorf("entity must encode as a JSON object: %w", err)
+	}
+	if record == nil {
+		return nil, fmt.Errorf("entity must encode as a JSON object")
+	}
+	return record, nil
+}
+
+// normalizeValue converts v to the types encoding/json decodes into, so stored values and
+// condition operands compare alike. Values that fail to encode are returned unchanged.
+func normalizeValue(v any) any {
+	switch v.(type) {
+	case nil, string, bool, float64:
+		return v
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var out any
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return v
+	}
+	return out
+}
+
+func cloneRecord(m map[string]any) map[string]any {
+	if m == nil {
+		return nil
+	}
+	return cloneValue(m).(map[string]any)
+}
+
+func cloneValue(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(t))
+		for k, val := range t {
+			out[k] = cloneValue(val)
+		}
+		return out
+	case []any:
+		out := make([]any, len(t))
+		for i, val := range t {
+			out[i] = cloneValue(val)
+		}
+		return out
+	}
+	return v
+}
+
+func cloneSchema(s contract.Schema) contract.Schema {
+	raw, err := json.Marshal(s)
+	if err != nil {
+		return s
+	}
+	var out contract.Schema
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return s
+	}
+	return out
+}
+
+// idString renders a primary key the way it appears in a URL path.
+func idString(v any) string {
+	if f, ok := v.(float64); ok {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
+
+func newUUID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
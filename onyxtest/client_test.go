@@ -0,0 +1,333 @@
+package onyxtest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+func TestSaveGeneratesKeysAndDefaults(t *testing.T) {
+	c := NewClient(testSchema())
+	ctx := context.Background()
+
+	type user struct {
+		ID     string `json:"id,omitempty"`
+		Email  string `json:"email"`
+		Tenant string `json:"tenant"`
+	}
+	saved, err := c.Save(ctx, "User", user{Email: "ada@example.com", Tenant: "a"}, nil)
+	if err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if id, _ := saved["id"].(string); len(id) != 36 || saved["active"] != true {
+		t.Fatalf("expected generated uuid and default, got %v", saved)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.Save(ctx, "Role", map[string]any{"name": "r"}, nil); err != nil {
+			t.Fatalf("save role: %v", err)
+		}
+	}
+	if _, err := c.Save(ctx, "Role", map[string]any{"id": 10, "name": "explicit"}, nil); err != nil {
+		t.Fatalf("save role: %v", err)
+	}
+	next, _ := c.Save(ctx, "Role", map[string]any{"name": "after"}, nil)
+	if next["id"] != 11.0 {
+		t.Fatalf("sequence should continue after explicit ids, got %v", next["id"])
+	}
+
+	_, err = c.Save(ctx, "User", map[string]any{"email": "ada@example.com", "tenant": "b"}, nil)
+	var cerr *contract.Error
+	if !errors.As(err, &cerr) || cerr.Code != "duplicate" || cerr.Meta["status"] != http.StatusConflict {
+		t.Fatalf("expected duplicate error, got %v", err)
+	}
+	if _, err := c.Save(ctx, "Permission", map[string]any{"roleId": 1}, nil); err == nil {
+		t.Fatalf("expected missing primary key error")
+	}
+	if _, err := c.Save(ctx, "Missing", map[string]any{}, nil); !errors.As(err, &cerr) || cerr.Meta["status"] != http.StatusNotFound {
+		t.Fatalf("expected unknown table error, got %v", err)
+	}
+
+	saved["email"] = "mutated"
+	if rows := c.Records("User"); rows[0]["email"] != "ada@example.com" {
+		t.Fatalf("saved record should be a copy, got %v", rows[0])
+	}
+}
+
+func TestSaveCascadeAndDelete(t *testing.T) {
+	c := NewClient(testSchema())
+	ctx := context.Background()
+
+	user := map[string]any{
+		"id": "u1", "email": "ada@example.com", "tenant": "a",
+		"roles": []any{
+			map[string]any{"name": "admin", "permissions": []any{map[string]any{"id": "p1"}, map[string]any{"id": "p2"}}},
+			map[string]any{"name": "ops"},
+		},
+	}
+	if _, err := c.Save(ctx, "User", user, []string{"roles:Role(userId,id)", "roles.permissions:Permission(roleId,id)"}); err != nil {
+		t.Fatalf("cascade save: %v", err)
+	}
+	if roles := c.Records("Role"); len(roles) != 2 || roles[0]["userId"] != "u1" {
+		t.Fatalf("unexpected roles %v", roles)
+	}
+	if perms := c.Records("Permission"); len(perms) != 2 || perms[0]["roleId"] != 1.0 {
+		t.Fatalf("unexpected permissions %v", perms)
+	}
+	if _, ok := c.Records("User")[0]["roles"]; ok {
+		t.Fatalf("relationship values should not be stored on the parent")
+	}
+
+	plan, err := c.Cascade(contract.Cascade("")).DryRunDelete(ctx, "User", "u1")
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	var order []string
+	for _, r := range plan {
+		order = append(order, r.Table+":"+r.ID+":"+r.Path)
+	}
+	want := []string{"Permission:p1:roles.permissions", "Permission:p2:roles.permissions", "Role:1:roles", "Role:2:roles", "User:u1:"}
+	if !reflect.DeepEqual(order, want) {
+		t.Fatalf("unexpected plan %v", order)
+	}
+	if err := c.Cascade(contract.Cascade("roles:Role")).Delete(ctx, "User", "u1"); err != nil {
+		t.Fatalf("cascade delete: %v", err)
+	}
+	if len(c.Records("User")) != 0 || len(c.Records("Role")) != 0 || len(c.Records("Permission")) != 2 {
+		t.Fatalf("spec cascade should stop at roles: %v %v %v", c.Records("User"), c.Records("Role"), c.Records("Permission"))
+	}
+
+	if err := c.Delete(ctx, "Permission", "p1"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if err := c.Delete(ctx, "Permission", "p1"); err == nil {
+		t.Fatalf("expected not found")
+	}
+}
+
+func TestSamePrimaryKeyInTwoPartitions(t *testing.T) {
+	c := NewClient(testSchema())
+	ctx := context.Background()
+	if err := c.Seed("User",
+		map[string]any{"id": "u1", "email": "ada@a.example", "tenant": "a"},
+		map[string]any{"id": "u1", "email": "ada@b.example", "tenant": "b"},
+	); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	if n := len(c.Records("User")); n != 2 {
+		t.Fatalf("each partition should keep its own row, got %d", n)
+	}
+	for tenant, email := range map[string]string{"a": "ada@a.example", "b": "ada@b.example"} {
+		rows, err := c.From("User").InPartition(tenant).Where(contract.Eq("id", "u1")).List(ctx)
+		if err != nil || len(rows) != 1 || rows[0]["email"] != email {
+			t.Fatalf("partition %s: %v %v", tenant, rows, err)
+		}
+	}
+
+	var cerr *contract.Error
+	if err := c.Delete(ctx, "User", "u1"); !errors.As(err, &cerr) || cerr.Code != "bad_request" {
+		t.Fatalf("deleting an id stored in two partitions should need a partition, got %v", err)
+	}
+	if n, err := c.From("User").InPartition("a").Where(contract.Eq("id", "u1")).Delete(ctx); err != nil || n != 1 {
+		t.Fatalf("partition delete: %d %v", n, err)
+	}
+	if rows := c.Records("User"); len(rows) != 1 || rows[0]["tenant"] != "b" {
+		t.Fatalf("only partition a should be deleted, got %v", rows)
+	}
+	if err := c.Delete(ctx, "User", "u1"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+}
+
+func TestPatchAndBatchSave(t *testing.T) {
+	c := seededClient(t)
+	ctx := context.Background()
+
+	if err := c.BatchSave(ctx, "Permission", []any{map[string]any{"id": "p3"}, map[string]any{"id": "p4"}}, 1); err != nil {
+		t.Fatalf("batch save: %v", err)
+	}
+	if n := len(c.Records("Permission")); n != 4 {
+		t.Fatalf("expected 4 permissions, got %d", n)
+	}
+
+	res, err := c.Patch(ctx, "User", "u1", map[string]any{"profile.city": "Oslo"})
+	if err != nil || res.Status != contract.PatchUpdated {
+		t.Fatalf("patch: %+v %v", res, err)
+	}
+	if got, _ := lookupPath(c.Records("User")[0], "profile.city"); got != "Oslo" {
+		t.Fatalf("patch not applied: %v", got)
+	}
+
+	results, err := c.PatchMany(ctx, "User", []contract.PatchOp{
+		{ID: "u2", Updates: map[string]any{"age": 26}},
+		{ID: "missing", Updates: map[string]any{"age": 1}},
+		{ID: "u3", Updates: map[string]any{"id": "u9"}},
+		{ID: "u3"},
+	})
+	if err != nil {
+		t.Fatalf("patch many: %v", err)
+	}
+	statuses := []contract.PatchStatus{results[0].Status, results[1].Status, results[2].Status, results[3].Status}
+	if !reflect.DeepEqual(statuses, []contract.PatchStatus{contract.PatchUpdated, contract.PatchMissing, contract.PatchFailed, contract.PatchFailed}) {
+		t.Fatalf("unexpected statuses %v", statuses)
+	}
+	if results[3].Err == nil || results[3].Err.Error() != "patch updates are required" {
+		t.Fatalf("unexpected validation error %v", results[3].Err)
+	}
+}
+
+func TestUnitOfWorkRollsBack(t *testing.T) {
+	c := seededClient(t)
+	ctx := context.Background()
+
+	report, err := c.UnitOfWork().
+		Save("Role", map[string]any{"userId": "u3", "name": "new"}).
+		Update("User", "u3", map[string]any{"age": 50}).
+		Delete("Permission", "p1").
+		Delete("Permission", "missing").
+		Commit(ctx)
+	if err == nil || report.Committed {
+		t.Fatalf("expected failed commit, got %+v", report)
+	}
+	var statuses []contract.UnitOfWorkStatus
+	for _, s := range report.Steps {
+		statuses = append(statuses, s.Status)
+	}
+	want := []contract.UnitOfWorkStatus{contract.UnitOfWorkRolledBack, contract.UnitOfWorkRolledBack, contract.UnitOfWorkRolledBack, contract.UnitOfWorkFailed}
+	if !reflect.DeepEqual(statuses, want) {
+		t.Fatalf("unexpected statuses %v", statuses)
+	}
+	if len(c.Records("Role")) != 3 || len(c.Records("Permission")) != 2 || c.Records("User")[2]["age"] != nil {
+		t.Fatalf("rollback did not restore data")
+	}
+	if next, _ := c.Save(ctx, "Role", map[string]any{"name": "x"}, nil); next["id"] != 4.0 {
+		t.Fatalf("rollback should restore the sequence, got %v", next["id"])
+	}
+
+	uow := c.UnitOfWork().Save("Permission", map[string]any{"id": "p1"}).Delete("Permission", "p2")
+	report, err = uow.Commit(ctx)
+	if err != nil || !report.Committed {
+		t.Fatalf("commit: %+v %v", report, err)
+	}
+	if report.Steps[0].Compensation != contract.CompensateRestore || report.Steps[1].Status != contract.UnitOfWorkApplied {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	if _, err := uow.Commit(ctx); err == nil {
+		t.Fatalf("expected second commit to fail")
+	}
+}
+
+func TestSchemaHistory(t *testing.T) {
+	clock := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	c := NewClient(testSchema())
+	c.Clock = func() time.Time { return clock }
+	ctx := context.Background()
+	if err := c.Seed("Permission", map[string]any{"id": "p1"}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	bad := contract.Schema{Tables: []contract.Table{{Name: "Empty"}}}
+	var cerr *contract.Error
+	if err := c.PublishSchema(ctx, bad); !errors.As(err, &cerr) || cerr.Code != "invalid_schema" {
+		t.Fatalf("expected invalid schema, got %v", err)
+	}
+
+	next := testSchema()
+	next.Tables = next.Tables[1:]
+	if err := c.PublishSchema(ctx, next); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	got, err := c.GetSchema(ctx, []string{"Permission"})
+	if err != nil || len(got.Tables) != 1 || got.Tables[0].Name != "Permission" {
+		t.Fatalf("get schema: %v %v", got, err)
+	}
+	if len(c.Records("Permission")) != 1 {
+		t.Fatalf("publishing should keep rows of surviving tables")
+	}
+	if _, err := c.From("User").List(ctx); err == nil {
+		t.Fatalf("dropped table should be unknown")
+	}
+
+	revisions, err := c.GetSchemaRevisions(ctx)
+	if err != nil || len(revisions) != 2 || revisions[1].Revision != "2" {
+		t.Fatalf("unexpected revisions %+v %v", revisions, err)
+	}
+	history, _ := c.GetSchemaHistory(ctx)
+	if len(history) != 2 || len(history[0].Tables) != 3 || len(history[1].Tables) != 2 {
+		t.Fatalf("history should list oldest first: %+v", history)
+	}
+}
+
+func TestSecretsDocumentsAndAI(t *testing.T) {
+	c := NewClient(testSchema())
+	c.Clock = func() time.Time { return time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC) }
+	ctx := context.Background()
+
+	if _, err := c.PutSecret(ctx, contract.OnyxSecret{Key: "api", Value: "s3cret"}); err != nil {
+		t.Fatalf("put secret: %v", err)
+	}
+	secret, err := c.GetSecret(ctx, "api")
+	if err != nil || secret.Value != "s3cret" || secret.CreatedAt != "2024-03-01T00:00:00Z" {
+		t.Fatalf("get secret: %+v %v", secret, err)
+	}
+	if list, _ := c.ListSecrets(ctx); len(list) != 1 || list[0].Key != "api" {
+		t.Fatalf("unexpected secrets %+v", list)
+	}
+	if err := c.DeleteSecret(ctx, "api"); err != nil {
+		t.Fatalf("delete secret: %v", err)
+	}
+	if _, err := c.GetSecret(ctx, "api"); err == nil {
+		t.Fatalf("expected missing secret")
+	}
+
+	docs := c.Documents()
+	doc, err := docs.Save(ctx, contract.OnyxDocument{DocumentID: "readme", Path: "/a.txt", Content: "hi"})
+	if err != nil || doc.DocumentID == "" || doc.ID != doc.DocumentID || doc.CreatedAt == "" {
+		t.Fatalf("save document: %+v %v", doc, err)
+	}
+	if got, err := docs.Get(ctx, doc.DocumentID); err != nil || got.Content != "hi" {
+		t.Fatalf("get document: %+v %v", got, err)
+	}
+	if err := docs.Delete(ctx, doc.DocumentID); err != nil {
+		t.Fatalf("delete document: %v", err)
+	}
+	if list, _ := docs.List(ctx); len(list) != 0 {
+		t.Fatalf("expected no documents, got %v", list)
+	}
+
+	resp, err := c.Chat(ctx, contract.AIChatCompletionRequest{Messages: []contract.AIChatMessage{{Role: "user", Content: "hello there"}}})
+	if err != nil || len(resp.Choices) != 1 || resp.Choices[0].Message.Content != "hello there" {
+		t.Fatalf("chat: %+v %v", resp, err)
+	}
+	stream, err := c.ChatStream(ctx, contract.AIChatCompletionRequest{Messages: []contract.AIChatMessage{{Role: "user", Content: "hello there"}}})
+	if err != nil {
+		t.Fatalf("chat stream: %v", err)
+	}
+	var text string
+	for stream.Next() {
+		for _, choice := range stream.Chunk().Choices {
+			text += choice.Delta.Content
+		}
+	}
+	if text != "hello there" {
+		t.Fatalf("unexpected stream text %q", text)
+	}
+	if _, err := c.GetModel(ctx, "onyx-test"); err != nil {
+		t.Fatalf("get model: %v", err)
+	}
+	approval, err := c.RequestScriptApproval(ctx, contract.AIScriptApprovalRequest{Script: `db.delete("User", "u1")`})
+	if err != nil || !approval.RequiresApproval {
+		t.Fatalf("script approval: %+v %v", approval, err)
+	}
+
+	c.Seed("Permission", map[string]any{"id": "p1"})
+	c.Reset()
+	if len(c.Records("Permission")) != 0 {
+		t.Fatalf("reset should clear rows")
+	}
+}
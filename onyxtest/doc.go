@@ -0,0 +1,10 @@
+// Package onyxtest provides an in-memory contract.Client for unit tests.
+//
+// NewClient seeds the fake from a schema. Records are kept per table and partition and every
+// query feature the SDK exposes is evaluated locally: each Condition operator including nested
+// Within queries, sorting, limits, cursor paging, Select/GroupBy aggregates, resolvers, updates,
+// deletes and cascades. Documents, secrets and schema revisions are stored in memory as well.
+//
+// The fake aims for the behavior tests depend on rather than for bit-for-bit fidelity with the
+// service; full-text MATCHES, for example, is approximated by term matching.
+package onyxtest
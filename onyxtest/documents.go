@@ -0,0 +1,96 @@
+package onyxtest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+type documentClient struct {
+	client *Client
+}
+
+func (c *Client) Documents() contract.OnyxDocumentsClient {
+	return &documentClient{client: c}
+}
+
+func (d *documentClient) List(ctx context.Context) ([]contract.OnyxDocument, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	d.client.mu.RLock()
+	defer d.client.mu.RUnlock()
+	docs := make([]contract.OnyxDocument, 0, len(d.client.documents))
+	for _, doc := range d.client.documents {
+		docs = append(docs, doc)
+	}
+	sort.Slice(docs, func(i, j int) bool { return docs[i].DocumentID < docs[j].DocumentID })
+	return docs, nil
+}
+
+func (d *documentClient) Get(ctx context.Context, id string) (contract.OnyxDocument, error) {
+	if err := ctx.Err(); err != nil {
+		return contract.OnyxDocument{}, err
+	}
+	docID := strings.TrimSpace(id)
+	if docID == "" {
+		return contract.OnyxDocument{}, fmt.Errorf("document id is required")
+	}
+	d.client.mu.RLock()
+	defer d.client.mu.RUnlock()
+	doc, ok := d.client.documents[docID]
+	if !ok {
+		return contract.OnyxDocument{}, statusError(http.StatusNotFound, "not_found", "document %s not found", docID)
+	}
+	return doc, nil
+}
+
+// Save stores the document under DocumentID (or the legacy ID), filling both identifiers and
+// stamping the created and updated times.
+func (d *documentClient) Save(ctx context.Context, doc contract.OnyxDocument) (contract.OnyxDocument, error) {
+	if err := ctx.Err(); err != nil {
+		return contract.OnyxDocument{}, err
+	}
+	docID := strings.TrimSpace(doc.DocumentID)
+	if docID == "" {
+		docID = strings.TrimSpace(doc.ID)
+	}
+	if docID == "" {
+		return contract.OnyxDocument{}, fmt.Errorf("document id is required")
+	}
+	d.client.mu.Lock()
+	defer d.client.mu.Unlock()
+	doc.DocumentID, doc.ID = docID, docID
+	stamp := d.client.now().UTC().Format(time.RFC3339)
+	doc.CreatedAt, doc.UpdatedAt = stamp, stamp
+	if existing, ok := d.client.documents[docID]; ok {
+		doc.CreatedAt = existing.CreatedAt
+	}
+	if doc.Data != nil {
+		doc.Data = cloneRecord(doc.Data)
+	}
+	d.client.documents[docID] = doc
+	return doc, nil
+}
+
+func (d *documentClient) Delete(ctx context.Context, id string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	docID := strings.TrimSpace(id)
+	if docID == "" {
+		return fmt.Errorf("document id is required")
+	}
+	d.client.mu.Lock()
+	defer d.client.mu.Unlock()
+	if _, ok := d.client.documents[docID]; !ok {
+		return statusError(http.StatusNotFound, "not_found", "document %s not found", docID)
+	}
+	delete(d.client.documents, docID)
+	return nil
+}
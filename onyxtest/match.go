@@ -0,0 +1,268 @@
+package onyxtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+// fullTextField is the field contract.Search conditions target.
+const fullTextField = "__full_text__"
+
+// matcher evaluates the JSON form of conditions against records. Nested Within queries are run
+// through subquery, which returns the values selected by the inner query.
+type matcher struct {
+	subquery func(payload map[string]any) ([]any, error)
+	// cache holds subquery results by payload so a Within clause runs once per query.
+	cache map[string][]any
+}
+
+// decodeCondition turns a marshaled condition tree into plain JSON values.
+func decodeCondition(raw []byte) (map[string]any, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	var cond map[string]any
+	if err := json.Unmarshal(raw, &cond); err != nil {
+		return nil, fmt.Errorf("decode condition: %w", err)
+	}
+	return cond, nil
+}
+
+// matches reports whether record satisfies cond. A nil condition matches every record. The
+// score is the number of full-text terms the record matched, summed across MATCHES criteria.
+func (m *matcher) matches(cond map[string]any, record map[string]any) (bool, float64, error) {
+	if cond == nil {
+		return true, 0, nil
+	}
+	switch cond["conditionType"] {
+	case "CompoundCondition":
+		op, _ := cond["operator"].(string)
+		children, _ := cond["conditions"].([]any)
+		var score float64
+		for _, child := range children {
+			childCond, _ := child.(map[string]any)
+			ok, s, err := m.matches(childCond, record)
+			if err != nil {
+				return false, 0, err
+			}
+			score += s
+			switch {
+			case strings.EqualFold(op, "OR") && ok:
+				return true, score, nil
+			case !strings.EqualFold(op, "OR") && !ok:
+				return false, 0, nil
+			}
+		}
+		return !strings.EqualFold(op, "OR") || len(children) == 0, score, nil
+	case "SingleCondition", nil:
+		criteria, _ := cond["criteria"].(map[string]any)
+		if criteria == nil {
+			return false, 0, fmt.Errorf("condition has no criteria")
+		}
+		return m.criterion(criteria, record)
+	}
+	return false, 0, fmt.Errorf("unsupported condition type %v", cond["conditionType"])
+}
+
+// criterion evaluates one SingleCondition. Full-text and nested-query criteria are handled here;
+// every other operator goes through contract.Matches so the fake and client-side filtering agree.
+func (m *matcher) criterion(criteria map[string]any, record map[string]any) (bool, float64, error) {
+	field, _ := criteria["field"].(string)
+	op, _ := criteria["operator"].(string)
+
+	if op == "MATCHES" && field == fullTextField {
+		return fullTextMatch(criteria["value"], record)
+	}
+	if nested, ok := criteria["value"].(map[string]any); ok && (op == "IN" || op == "NOT_IN") {
+		values, err := m.operandList(nested)
+		if err != nil {
+			return false, 0, err
+		}
+		criteria = map[string]any{"field": field, "operator": op, "value": values}
+	}
+
+	raw, err := json.Marshal(map[string]any{"conditionType": "SingleCondition", "criteria": criteria})
+	if err != nil {
+		return false, 0, err
+	}
+	ok, err := contract.Matches(json.RawMessage(raw), record)
+	return ok, 0, err
+}
+
+// operandList returns the values of an IN operand: a literal list or the rows of a nested query.
+func (m *matcher) operandList(v any) ([]any, error) {
+	switch t := v.(type) {
+	case []any:
+		return t, nil
+	case nil:
+		return nil, nil
+	case map[string]any:
+		if m.subquery == nil {
+			return nil, fmt.Errorf("nested queries are not supported here")
+		}
+		raw, _ := json.Marshal(t)
+		if values, ok := m.cache[string(raw)]; ok {
+			return values, nil
+		}
+		values, err := m.subquery(t)
+		if err != nil {
+			return nil, err
+		}
+		if m.cache == nil {
+			m.cache = map[string][]any{}
+		}
+		m.cache[string(raw)] = values
+		return values, nil
+	}
+	return []any{v}, nil
+}
+
+// lookupPath resolves a dotted path through embedded objects.
+func lookupPath(record map[string]any, path string) (any, bool) {
+	if v, ok := record[path]; ok {
+		return v, true
+	}
+	cur := any(record)
+	for _, part := range strings.Split(path, ".") {
+		obj, ok := cur.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if cur, ok = obj[part]; !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// setPath assigns a dotted path, creating embedded objects as needed.
+func setPath(record map[string]any, path string, value any) {
+	parts := strings.Split(path, ".")
+	cur := record
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := cur[part].(map[string]any)
+		if !ok {
+			next = map[string]any{}
+			cur[part] = next
+		}
+		cur = next
+	}
+	cur[parts[len(parts)-1]] = value
+}
+
+// valuesEqual compares JSON values; numbers compare numerically and timestamps by instant.
+func valuesEqual(a, b any) bool {
+	if cmp, ok := compareValues(a, b); ok {
+		return cmp == 0
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// compareValues orders two JSON scalars of the same kind: numbers, strings, booleans, or
+// strings that both parse as RFC 3339 timestamps. ok is false for anything else.
+func compareValues(a, b any) (int, bool) {
+	switch x := a.(type) {
+	case float64:
+		if y, ok := b.(float64); ok {
+			return compareOrdered(x, y), true
+		}
+	case string:
+		y, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+		if tx, err := time.Parse(time.RFC3339Nano, x); err == nil {
+			if ty, err := time.Parse(time.RFC3339Nano, y); err == nil {
+				return tx.Compare(ty), true
+			}
+		}
+		return strings.Compare(x, y), true
+	case bool:
+		if y, ok := b.(bool); ok {
+			switch {
+			case x == y:
+				return 0, true
+			case y:
+				return -1, true
+			default:
+				return 1, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func compareOrdered(x, y float64) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+// fullTextMatch approximates a Lucene query: the record matches when any query term occurs in
+// one of its string values, and the score is the number of distinct terms found. A "field:term"
+// term only looks at that field. A minScore requires at least that many matching terms.
+func fullTextMatch(value any, record map[string]any) (bool, float64, error) {
+	q, _ := value.(map[string]any)
+	text, _ := q["queryText"].(string)
+	var score float64
+	for _, term := range strings.Fields(text) {
+		field := ""
+		if f, t, ok := strings.Cut(term, ":"); ok && f != "" {
+			field, term = f, t
+		}
+		term = strings.ToLower(strings.TrimFunc(term, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }))
+		if term == "" || term == "and" || term == "or" || term == "not" {
+			continue
+		}
+		if field != "" {
+			v, _ := lookupPath(record, field)
+			if textContains(v, term) {
+				score++
+			}
+			continue
+		}
+		if textContains(record, term) {
+			score++
+		}
+	}
+	if score == 0 {
+		return false, 0, nil
+	}
+	if minScore, ok := q["minScore"].(float64); ok && score < minScore {
+		return false, 0, nil
+	}
+	return true, score, nil
+}
+
+// textContains reports whether term occurs, case-insensitively, in any string or number within v.
+func textContains(v any, term string) bool {
+	switch t := v.(type) {
+	case string:
+		return strings.Contains(strings.ToLower(t), term)
+	case float64:
+		return strings.Contains(idString(t), term)
+	case map[string]any:
+		for _, item := range t {
+			if textContains(item, term) {
+				return true
+			}
+		}
+	case []any:
+		for _, item := range t {
+			if textContains(item, term) {
+				return true
+			}
+		}
+	}
+	return false
+}
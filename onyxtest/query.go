@@ -0,0 +1,735 @@
+package onyxtest
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+// allTables is the table name Client.Search queries; it spans every table.
+const allTables = "ALL"
+
+// defaultPageSize is the page size Page uses when the query has no limit.
+const defaultPageSize = 100
+
+type clause struct {
+	Type      string
+	Condition contract.Condition
+}
+
+type query struct {
+	client    *Client
+	table     string
+	clauses   []clause
+	fields    []string
+	groupBy   []string
+	resolvers []string
+	sorts     []contract.Sort
+	limit     *int
+	updates   map[string]any
+	partition *string
+}
+
+var _ contract.Query = (*query)(nil)
+
+func (q *query) clone() *query {
+	nq := *q
+	nq.clauses = append([]clause{}, q.clauses...)
+	nq.fields = append([]string{}, q.fields...)
+	nq.groupBy = append([]string{}, q.groupBy...)
+	nq.resolvers = append([]string{}, q.resolvers...)
+	nq.sorts = append([]contract.Sort{}, q.sorts...)
+	if q.updates != nil {
+		nq.updates = map[string]any{}
+		for k, v := range q.updates {
+			nq.updates[k] = v
+		}
+	}
+	return &nq
+}
+
+func (q *query) Where(condition contract.Condition) contract.Query {
+	nq := q.clone()
+	nq.clauses = append(nq.clauses, clause{Type: "and", Condition: condition})
+	return nq
+}
+
+func (q *query) And(condition contract.Condition) contract.Query { return q.Where(condition) }
+
+func (q *query) Or(condition contract.Condition) contract.Query {
+	nq := q.clone()
+	nq.clauses = append(nq.clauses, clause{Type: "or", Condition: condition})
+	return nq
+}
+
+func (q *query) Search(queryText string, minScore ...float64) contract.Query {
+	return q.Where(contract.Search(queryText, minScore...))
+}
+
+func (q *query) Select(fields ...string) contract.Query {
+	nq := q.clone()
+	nq.fields = append(nq.fields, fields...)
+	return nq
+}
+
+func (q *query) GroupBy(fields ...string) contract.Query {
+	nq := q.clone()
+	nq.groupBy = append(nq.groupBy, fields...)
+	return nq
+}
+
+func (q *query) Resolve(paths ...string) contract.Query {
+	nq := q.clone()
+	nq.resolvers = append(nq.resolvers, paths...)
+	return nq
+}
+
+func (q *query) OrderBy(sorts ...contract.Sort) contract.Query {
+	nq := q.clone()
+	nq.sorts = append(nq.sorts, sorts...)
+	return nq
+}
+
+func (q *query) Limit(limit int) contract.Query {
+	nq := q.clone()
+	nq.limit = &limit
+	return nq
+}
+
+func (q *query) SetUpdates(updates map[string]any) contract.Query {
+	nq := q.clone()
+	nq.updates = map[string]any{}
+	for k, v := range updates {
+		nq.updates[k] = v
+	}
+	return nq
+}
+
+// Fresh returns a copy of the query; the fake has no cache or coalescing to skip.
+func (q *query) Fresh() contract.Query { return q.clone() }
+
+func (q *query) InPartition(partition string) contract.Query {
+	nq := q.clone()
+	if trimmed := strings.TrimSpace(partition); trimmed != "" {
+		nq.partition = &trimmed
+	} else {
+		nq.partition = nil
+	}
+	return nq
+}
+
+// Filter evaluates the query's conditions against records with contract.FilterRecords, the
+// same way the HTTP client's queries do.
+func (q *query) Filter(records []map[string]any) (contract.QueryResults, error) {
+	var cond contract.Condition
+	if raw := buildConditions(q.clauses); raw != nil {
+		cond = raw
+	}
+	return contract.FilterRecords(cond, records)
+}
+
+// MarshalJSON renders the SelectQuery payload the HTTP client sends, so Within can nest fake
+// queries and tests can assert on payloads.
+func (q *query) MarshalJSON() ([]byte, error) {
+	payload := map[string]any{"type": "SelectQuery", "table": q.table}
+	if conditions := buildConditions(q.clauses); conditions != nil {
+		payload["conditions"] = conditions
+	}
+	if len(q.fields) > 0 {
+		payload["fields"] = q.fields
+	}
+	if len(q.groupBy) > 0 {
+		payload["groupBy"] = q.groupBy
+	}
+	if len(q.resolvers) > 0 {
+		payload["resolvers"] = q.resolvers
+	}
+	if len(q.sorts) > 0 {
+		payload["sort"] = q.sorts
+	}
+	if q.limit != nil {
+		payload["limit"] = *q.limit
+	}
+	if q.partition != nil {
+		payload["partition"] = *q.partition
+	}
+	return json.Marshal(payload)
+}
+
+// buildConditions folds the clauses left to right into compound conditions, as the SDK does.
+func buildConditions(clauses []clause) json.RawMessage {
+	if len(clauses) == 0 {
+		return nil
+	}
+	single := func(c clause) any {
+		raw, _ := json.Marshal(c.Condition)
+		return json.RawMessage(raw)
+	}
+	cur := single(clauses[0])
+	for _, c := range clauses[1:] {
+		cur = map[string]any{
+			"conditionType": "CompoundCondition",
+			"operator":      strings.ToUpper(c.Type),
+			"conditions":    []any{cur, single(c)},
+		}
+	}
+	out, _ := json.Marshal(cur)
+	return out
+}
+
+// selectSpec is a decoded SelectQuery, built from a query or from a nested Within payload.
+type selectSpec struct {
+	table      string
+	conditions map[string]any
+	fields     []string
+	groupBy    []string
+	resolvers  []string
+	sorts      []sortKey
+	limit      int
+	partition  *string
+}
+
+type sortKey struct {
+	Field     string `json:"field"`
+	Direction string `json:"direction"`
+}
+
+func (q *query) spec(includeLimit bool) (selectSpec, error) {
+	raw, err := q.MarshalJSON()
+	if err != nil {
+		return selectSpec{}, err
+	}
+	var payload map[string]any
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return selectSpec{}, err
+	}
+	spec := specFromPayload(payload)
+	if !includeLimit {
+		spec.limit = 0
+	}
+	return spec, nil
+}
+
+func specFromPayload(payload map[string]any) selectSpec {
+	strs := func(v any) []string {
+		items, _ := v.([]any)
+		out := make([]string, 0, len(items))
+		for _, item := range items {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	spec := selectSpec{
+		fields:    strs(payload["fields"]),
+		groupBy:   strs(payload["groupBy"]),
+		resolvers: strs(payload["resolvers"]),
+	}
+	spec.table, _ = payload["table"].(string)
+	spec.conditions, _ = payload["conditions"].(map[string]any)
+	if sorts, ok := payload["sort"].([]any); ok {
+		for _, s := range sorts {
+			m, _ := s.(map[string]any)
+			field, _ := m["field"].(string)
+			dir, _ := m["direction"].(string)
+			spec.sorts = append(spec.sorts, sortKey{Field: field, Direction: dir})
+		}
+	}
+	if limit, ok := payload["limit"].(float64); ok {
+		spec.limit = int(limit)
+	}
+	if p, ok := payload["partition"].(string); ok {
+		spec.partition = &p
+	}
+	return spec
+}
+
+// hit is a row that matched a query, with its full-text score.
+type hit struct {
+	store *tableStore
+	row   *row
+	score float64
+}
+
+// match returns the rows satisfying spec in result order, before projection. The caller holds
+// the lock.
+func (c *Client) match(spec selectSpec) ([]hit, error) {
+	var stores []*tableStore
+	if spec.table == allTables {
+		for _, st := range c.tables {
+			stores = append(stores, st)
+		}
+	} else {
+		st, err := c.store(spec.table)
+		if err != nil {
+			return nil, err
+		}
+		stores = []*tableStore{st}
+	}
+
+	m := &matcher{subquery: c.subquery}
+	var hits []hit
+	scored := false
+	for _, st := range stores {
+		for _, r := range st.rows {
+			if spec.partition != nil && r.partition != *spec.partition {
+				continue
+			}
+			ok, score, err := m.matches(spec.conditions, r.data)
+			if err != nil {
+				return nil, statusError(http.StatusBadRequest, "bad_request", "%v", err)
+			}
+			if ok {
+				hits = append(hits, hit{store: st, row: r, score: score})
+				scored = scored || score > 0
+			}
+		}
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		if scored && len(spec.sorts) == 0 && hits[i].score != hits[j].score {
+			return hits[i].score > hits[j].score
+		}
+		return hits[i].row.seq < hits[j].row.seq
+	})
+	if !isAggregate(spec) {
+		sortRecords(hits, spec.sorts, func(h hit) map[string]any { return h.row.data })
+		if spec.limit > 0 && len(hits) > spec.limit {
+			hits = hits[:spec.limit]
+		}
+	}
+	return hits, nil
+}
+
+// subquery runs a nested Within query and returns the values of its first selected field, or
+// of the primary key when it selects none.
+func (c *Client) subquery(payload map[string]any) ([]any, error) {
+	spec := specFromPayload(payload)
+	rows, err := c.selectRecords(spec)
+	if err != nil {
+		return nil, err
+	}
+	field := ""
+	if len(spec.fields) > 0 {
+		field = spec.fields[0]
+	} else if st, ok := c.tables[spec.table]; ok {
+		field = st.pk
+	}
+	values := make([]any, 0, len(rows))
+	for _, r := range rows {
+		if v, ok := lookupPath(r, field); ok {
+			values = append(values, v)
+		}
+	}
+	return values, nil
+}
+
+// selectRecords evaluates a select query: filtering, resolvers, aggregation or projection,
+// sorting and limit. The caller holds the lock.
+func (c *Client) selectRecords(spec selectSpec) ([]map[string]any, error) {
+	hits, err := c.match(spec)
+	if err != nil {
+		return nil, err
+	}
+	records := make([]map[string]any, 0, len(hits))
+	for _, h := range hits {
+		record := cloneRecord(h.row.data)
+		paths := append([]string{}, spec.resolvers...)
+		for _, f := range spec.fields {
+			if tableHasResolver(h.store.table, f) {
+				paths = append(paths, f)
+			}
+		}
+		if err := c.resolve(h.store, record, paths); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	if !isAggregate(spec) {
+		if len(spec.fields) == 0 {
+			return records, nil
+		}
+		for i, r := range records {
+			records[i] = project(r, spec.fields)
+		}
+		return records, nil
+	}
+
+	rows := aggregate(records, spec.fields, spec.groupBy)
+	sortRecords(rows, spec.sorts, func(r map[string]any) map[string]any { return r })
+	if spec.limit > 0 && len(rows) > spec.limit {
+		rows = rows[:spec.limit]
+	}
+	return rows, nil
+}
+
+func (q *query) List(ctx context.Context) (contract.QueryResults, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	spec, err := q.spec(true)
+	if err != nil {
+		return nil, err
+	}
+	q.client.mu.RLock()
+	defer q.client.mu.RUnlock()
+	rows, err := q.client.selectRecords(spec)
+	if err != nil {
+		return nil, err
+	}
+	return contract.QueryResults(rows), nil
+}
+
+// Page returns up to Limit rows (100 without a limit) starting at cursor. Cursors are opaque
+// offsets into the result order, so pages are stable while the data is.
+func (q *query) Page(ctx context.Context, cursor string) (contract.PageResult, error) {
+	if err := ctx.Err(); err != nil {
+		return contract.PageResult{}, err
+	}
+	size := defaultPageSize
+	if q.limit != nil && *q.limit > 0 {
+		size = *q.limit
+	}
+	spec, err := q.spec(false)
+	if err != nil {
+		return contract.PageResult{}, err
+	}
+	q.client.mu.RLock()
+	rows, err := q.client.selectRecords(spec)
+	q.client.mu.RUnlock()
+	if err != nil {
+		return contract.PageResult{}, err
+	}
+	return pageOf(rows, cursor, size)
+}
+
+// pageOf slices one page of size rows out of rows, starting at the offset cursor encodes.
+func pageOf(rows []map[string]any, cursor string, size int) (contract.PageResult, error) {
+	offset := 0
+	if cursor != "" {
+		raw, err := base64.RawURLEncoding.DecodeString(cursor)
+		if err == nil {
+			offset, err = strconv.Atoi(string(raw))
+		}
+		if err != nil || offset < 0 {
+			return contract.PageResult{}, statusError(http.StatusBadRequest, "bad_request", "invalid page cursor %q", cursor)
+		}
+	}
+	page := contract.PageResult{Items: contract.QueryResults{}}
+	if offset < len(rows) {
+		end := offset + size
+		if end > len(rows) {
+			end = len(rows)
+		}
+		page.Items = rows[offset:end]
+		if end < len(rows) {
+			page.NextCursor = base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(end)))
+		}
+	}
+	return page, nil
+}
+
+// Stream iterates over the current results. Unlike the service it does not follow later
+// changes; it ends after the last matching row.
+func (q *query) Stream(ctx context.Context) (contract.Iterator, error) {
+	rows, err := q.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &sliceIterator{ctx: ctx, rows: rows, pos: -1}, nil
+}
+
+func (q *query) Update(ctx context.Context) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	spec, err := q.spec(true)
+	if err != nil {
+		return 0, err
+	}
+	q.client.mu.Lock()
+	defer q.client.mu.Unlock()
+	return q.client.updateWhere(spec, q.updates)
+}
+
+func (q *query) Delete(ctx context.Context) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	spec, err := q.spec(true)
+	if err != nil {
+		return 0, err
+	}
+	q.client.mu.Lock()
+	defer q.client.mu.Unlock()
+	return q.client.deleteWhere(spec)
+}
+
+// updateWhere applies updates to every row spec matches and returns how many it changed. The
+// caller holds the lock.
+func (c *Client) updateWhere(spec selectSpec, updates map[string]any) (int, error) {
+	if len(updates) == 0 {
+		return 0, fmt.Errorf("updates are required")
+	}
+	if spec.table == allTables {
+		return 0, statusError(http.StatusBadRequest, "bad_request", "updates need a table")
+	}
+	hits, err := c.match(spec)
+	if err != nil {
+		return 0, err
+	}
+	for _, h := range hits {
+		if err := c.update(h.store, h.row, updates); err != nil {
+			return 0, err
+		}
+	}
+	return len(hits), nil
+}
+
+// deleteWhere removes every row spec matches and returns how many it removed. The caller holds
+// the lock.
+func (c *Client) deleteWhere(spec selectSpec) (int, error) {
+	if spec.table == allTables {
+		return 0, statusError(http.StatusBadRequest, "bad_request", "deletes need a table")
+	}
+	hits, err := c.match(spec)
+	if err != nil {
+		return 0, err
+	}
+	for _, h := range hits {
+		c.remove(h.store, h.store.keyFor(h.row.data))
+	}
+	return len(hits), nil
+}
+
+type sliceIterator struct {
+	ctx  context.Context
+	rows contract.QueryResults
+	pos  int
+	err  error
+}
+
+func (s *sliceIterator) Next() bool {
+	if s.err != nil {
+		return false
+	}
+	if err := s.ctx.Err(); err != nil {
+		s.err = err
+		return false
+	}
+	s.pos++
+	return s.pos < len(s.rows)
+}
+
+func (s *sliceIterator) Value() map[string]any {
+	if s.pos < 0 || s.pos >= len(s.rows) {
+		return nil
+	}
+	return s.rows[s.pos]
+}
+
+func (s *sliceIterator) Err() error   { return s.err }
+func (s *sliceIterator) Close() error { return nil }
+
+// sortRecords orders items by the sort keys; missing values sort first in ascending order.
+func sortRecords[T any](items []T, sorts []sortKey, record func(T) map[string]any) {
+	if len(sorts) == 0 {
+		return
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := record(items[i]), record(items[j])
+		for _, s := range sorts {
+			av, _ := lookupPath(a, s.Field)
+			bv, _ := lookupPath(b, s.Field)
+			cmp, ok := compareValues(av, bv)
+			if !ok {
+				switch {
+				case av == nil && bv != nil:
+					cmp = -1
+				case av != nil && bv == nil:
+					cmp = 1
+				}
+			}
+			if cmp == 0 {
+				continue
+			}
+			if strings.EqualFold(s.Direction, "desc") {
+				return cmp > 0
+			}
+			return cmp < 0
+		}
+		return false
+	})
+}
+
+func project(record map[string]any, fields []string) map[string]any {
+	out := make(map[string]any, len(fields))
+	for _, f := range fields {
+		if v, ok := lookupPath(record, f); ok {
+			out[f] = v
+		}
+	}
+	return out
+}
+
+var aggregatePattern = regexp.MustCompile(`^(?i)(count|sum|avg|min|max|median|std|variance)\(\s*([^)]*?)\s*\)$`)
+
+func isAggregate(spec selectSpec) bool {
+	if len(spec.groupBy) > 0 {
+		return true
+	}
+	for _, f := range spec.fields {
+		if aggregatePattern.MatchString(f) {
+			return true
+		}
+	}
+	return false
+}
+
+// aggregate groups records by the groupBy fields, in order of first appearance, and evaluates
+// the selected aggregates per group. Plain selected fields take the group's first value.
+// Without groupBy every record forms a single group.
+func aggregate(records []map[string]any, fields, groupBy []string) []map[string]any {
+	type group struct {
+		rows []map[string]any
+	}
+	var order []string
+	groups := map[string]*group{}
+	for _, r := range records {
+		keyParts := make([]any, len(groupBy))
+		for i, f := range groupBy {
+			keyParts[i], _ = lookupPath(r, f)
+		}
+		raw, _ := json.Marshal(keyParts)
+		key := string(raw)
+		g, ok := groups[key]
+		if !ok {
+			g = &group{}
+			groups[key] = g
+			order = append(order, key)
+		}
+		g.rows = append(g.rows, r)
+	}
+	if len(groupBy) == 0 && len(order) == 0 {
+		groups[""] = &group{}
+		order = append(order, "")
+	}
+
+	columns := append([]string{}, fields...)
+	for _, f := range groupBy {
+		if !containsString(columns, f) {
+			columns = append(columns, f)
+		}
+	}
+	out := make([]map[string]any, 0, len(order))
+	for _, key := range order {
+		g := groups[key]
+		row := map[string]any{}
+		for _, col := range columns {
+			if m := aggregatePattern.FindStringSubmatch(col); m != nil {
+				row[col] = aggregateValue(strings.ToLower(m[1]), m[2], g.rows)
+				continue
+			}
+			if len(g.rows) > 0 {
+				if v, ok := lookupPath(g.rows[0], col); ok {
+					row[col] = v
+				}
+			}
+		}
+		out = append(out, row)
+	}
+	return out
+}
+
+func aggregateValue(fn, field string, rows []map[string]any) any {
+	if fn == "count" {
+		if field == "" || field == "*" {
+			return float64(len(rows))
+		}
+		n := 0
+		for _, r := range rows {
+			if v, ok := lookupPath(r, field); ok && v != nil {
+				n++
+			}
+		}
+		return float64(n)
+	}
+
+	var values []any
+	var numbers []float64
+	for _, r := range rows {
+		v, ok := lookupPath(r, field)
+		if !ok || v == nil {
+			continue
+		}
+		values = append(values, v)
+		if n, ok := v.(float64); ok {
+			numbers = append(numbers, n)
+		}
+	}
+	switch fn {
+	case "min", "max":
+		var best any
+		for _, v := range values {
+			cmp, ok := compareValues(v, best)
+			if best == nil || (ok && ((fn == "min" && cmp < 0) || (fn == "max" && cmp > 0))) {
+				best = v
+			}
+		}
+		return best
+	}
+	if len(numbers) == 0 {
+		if fn == "sum" {
+			return float64(0)
+		}
+		return nil
+	}
+	var sum float64
+	for _, n := range numbers {
+		sum += n
+	}
+	mean := sum / float64(len(numbers))
+	switch fn {
+	case "sum":
+		return sum
+	case "avg":
+		return mean
+	case "median":
+		sorted := append([]float64{}, numbers...)
+		sort.Float64s(sorted)
+		mid := len(sorted) / 2
+		if len(sorted)%2 == 0 {
+			return (sorted[mid-1] + sorted[mid]) / 2
+		}
+		return sorted[mid]
+	}
+	var variance float64
+	for _, n := range numbers {
+		variance += (n - mean) * (n - mean)
+	}
+	variance /= float64(len(numbers))
+	if fn == "std" {
+		return math.Sqrt(variance)
+	}
+	return variance
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
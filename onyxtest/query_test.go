@@ -0,0 +1,307 @@
+package onyxtest
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+func testSchema() contract.Schema {
+	return contract.Schema{Tables: []contract.Table{
+		{
+			Name: "User",
+			Fields: []contract.Field{
+				{Name: "id", Type: "String", Primary: true, Generator: "UUID"},
+				{Name: "email", Type: "String", Unique: true},
+				{Name: "age", Type: "Int", Nullable: true},
+				{Name: "tenant", Type: "String"},
+				{Name: "active", Type: "Boolean", Default: true},
+				{Name: "createdAt", Type: "Timestamp"},
+				{Name: "tags", Type: "EmbeddedList", Nullable: true},
+				{Name: "profile", Type: "EmbeddedObject", Nullable: true},
+			},
+			Resolvers: []contract.Resolver{
+				{Name: "roles", Resolver: `db.from("Role").where(eq("userId", this.id)).list()`},
+				{Name: "latestRole", Resolver: `db.from("Role").where(eq("userId", this.id)).firstOrNull()`},
+			},
+			Partition: "tenant",
+		},
+		{
+			Name: "Role",
+			Fields: []contract.Field{
+				{Name: "id", Type: "Long", Primary: true, Generator: "Sequence"},
+				{Name: "userId", Type: "String"},
+				{Name: "name", Type: "String"},
+			},
+			Resolvers: []contract.Resolver{
+				{Name: "permissions", Resolver: `db.from("Permission").where(eq("roleId", this.id)).list()`},
+			},
+		},
+		{
+			Name: "Permission",
+			Fields: []contract.Field{
+				{Name: "id", Type: "String", Primary: true},
+				{Name: "roleId", Type: "Long"},
+			},
+		},
+	}}
+}
+
+func seededClient(t *testing.T) *Client {
+	t.Helper()
+	c := NewClient(testSchema())
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	err := c.Seed("User",
+		map[string]any{"id": "u1", "email": "ada@example.com", "age": 36, "tenant": "a", "createdAt": day(1), "tags": []string{"admin", "ops"}, "profile": map[string]any{"city": "London"}},
+		map[string]any{"id": "u2", "email": "bob@example.com", "age": 25, "tenant": "a", "createdAt": day(5), "active": false, "tags": []string{"ops"}},
+		map[string]any{"id": "u3", "email": "cy@test.org", "tenant": "b", "createdAt": day(9), "profile": map[string]any{"city": "Paris"}},
+	)
+	if err != nil {
+		t.Fatalf("seed users: %v", err)
+	}
+	if err := c.Seed("Role", map[string]any{"userId": "u1", "name": "admin"}, map[string]any{"userId": "u1", "name": "ops"}, map[string]any{"userId": "u2", "name": "ops"}); err != nil {
+		t.Fatalf("seed roles: %v", err)
+	}
+	if err := c.Seed("Permission", map[string]any{"id": "p1", "roleId": 1}, map[string]any{"id": "p2", "roleId": 1}); err != nil {
+		t.Fatalf("seed permissions: %v", err)
+	}
+	return c
+}
+
+func ids(t *testing.T, rows contract.QueryResults) []string {
+	t.Helper()
+	out := []string{}
+	for _, r := range rows {
+		out = append(out, idString(r["id"]))
+	}
+	return out
+}
+
+func TestQueryOperators(t *testing.T) {
+	c := seededClient(t)
+	ctx := context.Background()
+	users := c.From("User")
+
+	cases := []struct {
+		name string
+		cond contract.Condition
+		want []string
+	}{
+		{"eq", contract.Eq("email", "bob@example.com"), []string{"u2"}},
+		{"neq", contract.Neq("tenant", "a"), []string{"u3"}},
+		{"in", contract.In("id", []any{"u1", "u3"}), []string{"u1", "u3"}},
+		{"not_in", contract.NotIn("id", []any{"u1"}), []string{"u2", "u3"}},
+		{"between numbers", contract.Between("age", 20, 30), []string{"u2"}},
+		{"between timestamps", contract.Between("createdAt", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC)), []string{"u2", "u3"}},
+		{"gt", contract.Gt("age", 25), []string{"u1"}},
+		{"gte", contract.Gte("age", 25), []string{"u1", "u2"}},
+		{"lt", contract.Lt("age", 36), []string{"u2"}},
+		{"lte", contract.Lte("age", 36), []string{"u1", "u2"}},
+		{"like", contract.Like("email", "%@example.com"), []string{"u1", "u2"}},
+		{"like single char", contract.Like("email", "c_@test.org"), []string{"u3"}},
+		{"contains array", contract.Contains("tags", "admin"), []string{"u1"}},
+		{"contains string", contract.Contains("email", "test"), []string{"u3"}},
+		{"starts_with", contract.StartsWith("email", "bo"), []string{"u2"}},
+		{"is_null", contract.IsNull("age"), []string{"u3"}},
+		{"not_null", contract.NotNull("tags"), []string{"u1", "u2"}},
+		{"dotted path", contract.Eq("profile.city", "Paris"), []string{"u3"}},
+		{"default applied", contract.Eq("active", true), []string{"u1", "u3"}},
+		{"within", contract.Within("id", c.From("Role").Where(contract.Eq("name", "admin")).Select("userId")), []string{"u1"}},
+		{"not_within", contract.NotWithin("id", c.From("Role").Select("userId")), []string{"u3"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rows, err := users.Where(tc.cond).List(ctx)
+			if err != nil {
+				t.Fatalf("list: %v", err)
+			}
+			if got := ids(t, rows); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestQueryCompoundSortLimit(t *testing.T) {
+	c := seededClient(t)
+	ctx := context.Background()
+
+	rows, err := c.From("User").Where(contract.Eq("tenant", "b")).Or(contract.Gt("age", 30)).OrderBy(contract.Desc("createdAt")).List(ctx)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if got := ids(t, rows); !reflect.DeepEqual(got, []string{"u3", "u1"}) {
+		t.Fatalf("unexpected order %v", got)
+	}
+
+	rows, err = c.From("User").OrderBy(contract.Asc("age")).Limit(2).List(ctx)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if got := ids(t, rows); !reflect.DeepEqual(got, []string{"u3", "u2"}) {
+		t.Fatalf("nulls should sort first ascending, got %v", got)
+	}
+
+	rows, err = c.From("User").InPartition("a").Where(contract.Eq("active", true)).List(ctx)
+	if err != nil || !reflect.DeepEqual(ids(t, rows), []string{"u1"}) {
+		t.Fatalf("partition query: %v %v", ids(t, rows), err)
+	}
+}
+
+func TestQuerySelectResolveAggregate(t *testing.T) {
+	c := seededClient(t)
+	ctx := context.Background()
+
+	rows, err := c.From("User").Where(contract.Eq("id", "u1")).Select("email", "profile.city").List(ctx)
+	if err != nil {
+		t.Fatalf("select: %v", err)
+	}
+	if want := (contract.QueryResults{{"email": "ada@example.com", "profile.city": "London"}}); !reflect.DeepEqual(rows, want) {
+		t.Fatalf("unexpected projection %v", rows)
+	}
+
+	rows, err = c.From("User").Where(contract.Eq("id", "u1")).Resolve("roles.permissions", "latestRole").List(ctx)
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	roles := rows[0]["roles"].([]any)
+	if len(roles) != 2 || len(roles[0].(map[string]any)["permissions"].([]any)) != 2 {
+		t.Fatalf("unexpected roles %v", roles)
+	}
+	if latest := rows[0]["latestRole"].(map[string]any); latest["name"] != "admin" {
+		t.Fatalf("unexpected latestRole %v", latest)
+	}
+
+	rows, err = c.From("User").Select("tenant", "count(id)", "avg(age)", "max(createdAt)").GroupBy("tenant").OrderBy(contract.Desc("count(id)")).List(ctx)
+	if err != nil {
+		t.Fatalf("aggregate: %v", err)
+	}
+	want := contract.QueryResults{
+		{"tenant": "a", "count(id)": 2.0, "avg(age)": 30.5, "max(createdAt)": "2024-01-05T00:00:00Z"},
+		{"tenant": "b", "count(id)": 1.0, "avg(age)": nil, "max(createdAt)": "2024-01-09T00:00:00Z"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Fatalf("unexpected aggregates %v", rows)
+	}
+
+	rows, err = c.From("Role").Select("count(*)").Where(contract.Eq("name", "missing")).List(ctx)
+	if err != nil || !reflect.DeepEqual(rows, contract.QueryResults{{"count(*)": 0.0}}) {
+		t.Fatalf("empty aggregate: %v %v", rows, err)
+	}
+}
+
+func TestQueryPageAndStream(t *testing.T) {
+	c := seededClient(t)
+	ctx := context.Background()
+	q := c.From("User").OrderBy(contract.Asc("email")).Limit(2)
+
+	first, err := q.Page(ctx, "")
+	if err != nil {
+		t.Fatalf("page: %v", err)
+	}
+	if got := ids(t, first.Items); !reflect.DeepEqual(got, []string{"u1", "u2"}) || first.NextCursor == "" {
+		t.Fatalf("unexpected first page %v %q", got, first.NextCursor)
+	}
+	second, err := q.Page(ctx, first.NextCursor)
+	if err != nil {
+		t.Fatalf("page: %v", err)
+	}
+	if got := ids(t, second.Items); !reflect.DeepEqual(got, []string{"u3"}) || second.NextCursor != "" {
+		t.Fatalf("unexpected second page %v %q", got, second.NextCursor)
+	}
+	if _, err := q.Page(ctx, "not-a-cursor"); err == nil {
+		t.Fatalf("expected invalid cursor error")
+	}
+
+	it, err := c.From("User").Where(contract.Eq("tenant", "a")).Stream(ctx)
+	if err != nil {
+		t.Fatalf("stream: %v", err)
+	}
+	defer it.Close()
+	var streamed []string
+	for it.Next() {
+		streamed = append(streamed, it.Value()["id"].(string))
+	}
+	if it.Err() != nil || !reflect.DeepEqual(streamed, []string{"u1", "u2"}) {
+		t.Fatalf("unexpected stream %v %v", streamed, it.Err())
+	}
+}
+
+func TestQueryUpdateDeleteSearch(t *testing.T) {
+	c := seededClient(t)
+	ctx := context.Background()
+
+	n, err := c.From("User").Where(contract.Eq("tenant", "a")).SetUpdates(map[string]any{"active": false, "profile.city": "Berlin"}).Update(ctx)
+	if err != nil || n != 2 {
+		t.Fatalf("update: %d %v", n, err)
+	}
+	rows, _ := c.From("User").Where(contract.Eq("profile.city", "Berlin")).List(ctx)
+	if got := ids(t, rows); !reflect.DeepEqual(got, []string{"u1", "u2"}) {
+		t.Fatalf("unexpected updated rows %v", got)
+	}
+	if _, err := c.From("User").Where(contract.Eq("id", "u2")).SetUpdates(map[string]any{"email": "ada@example.com"}).Update(ctx); err == nil {
+		t.Fatalf("expected unique violation")
+	}
+
+	rows, err = c.Search("london ops").List(ctx)
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if len(rows) == 0 {
+		t.Fatalf("expected search hits")
+	}
+	rows, err = c.From("User").Search("paris").List(ctx)
+	if err != nil || !reflect.DeepEqual(ids(t, rows), []string{"u3"}) {
+		t.Fatalf("table search: %v %v", ids(t, rows), err)
+	}
+	rows, _ = c.From("User").Search("ops admin", 2).List(ctx)
+	if !reflect.DeepEqual(ids(t, rows), []string{"u1"}) {
+		t.Fatalf("minScore search: %v", ids(t, rows))
+	}
+
+	n, err = c.From("Role").Where(contract.Eq("userId", "u1")).Delete(ctx)
+	if err != nil || n != 2 || len(c.Records("Role")) != 1 {
+		t.Fatalf("delete: %d %v %v", n, err, c.Records("Role"))
+	}
+
+	if _, err := c.From("Missing").List(ctx); err == nil {
+		t.Fatalf("expected unknown table error")
+	}
+	if _, err := c.From("User").Resolve("nope").List(ctx); err == nil {
+		t.Fatalf("expected unknown resolver error")
+	}
+}
+
+func TestQueryMarshalMatchesSDKPayload(t *testing.T) {
+	c := NewClient(testSchema())
+	raw, err := json.Marshal(c.From("User").Where(contract.Eq("id", "u1")).And(contract.Gt("age", 3)).Select("id").Limit(5))
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var payload map[string]any
+	_ = json.Unmarshal(raw, &payload)
+	cond := payload["conditions"].(map[string]any)
+	if payload["type"] != "SelectQuery" || payload["table"] != "User" || cond["operator"] != "AND" || payload["limit"] != 5.0 {
+		t.Fatalf("unexpected payload %s", raw)
+	}
+}
+
+func TestQueryFilterMatchesList(t *testing.T) {
+	c := seededClient(t)
+	q := c.From("User").Where(contract.Eq("tenant", "a")).Or(contract.Contains("tags", "ops"))
+	rows, err := q.List(context.Background())
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	filtered, err := q.Filter(c.Records("User"))
+	if err != nil {
+		t.Fatalf("filter: %v", err)
+	}
+	if !reflect.DeepEqual(ids(t, filtered), ids(t, rows)) {
+		t.Fatalf("filter %v differs from list %v", ids(t, filtered), ids(t, rows))
+	}
+}
@@ -0,0 +1,138 @@
+package onyxtest
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+var (
+	resolverFromRe   = regexp.MustCompile(`db\.from\(\s*["']([^"']+)["']\s*\)`)
+	resolverEqRe     = regexp.MustCompile(`eq\(\s*["']([^"']+)["']\s*,\s*this\.([A-Za-z_][A-Za-z0-9_]*)\s*\)`)
+	resolverSingleRe = regexp.MustCompile(`\.\s*(firstOrNull|first|one)\s*\(\s*\)\s*;?\s*$`)
+)
+
+// resolverLink is a join extracted from a resolver script: rows of Target whose TargetField
+// equals the owning record's SourceField.
+type resolverLink struct {
+	Resolver    string
+	Target      string
+	TargetField string
+	SourceField string
+}
+
+// resolverLinks extracts joins from a table's resolvers. Each eq(field, this.x) is attributed to
+// the nearest preceding db.from(...).
+func resolverLinks(t contract.Table) []resolverLink {
+	var links []resolverLink
+	for _, r := range t.Resolvers {
+		froms := resolverFromRe.FindAllStringSubmatchIndex(r.Resolver, -1)
+		for _, eq := range resolverEqRe.FindAllStringSubmatchIndex(r.Resolver, -1) {
+			target := ""
+			for _, f := range froms {
+				if f[0] > eq[0] {
+					break
+				}
+				target = r.Resolver[f[2]:f[3]]
+			}
+			if target == "" {
+				continue
+			}
+			links = append(links, resolverLink{
+				Resolver:    r.Name,
+				Target:      target,
+				TargetField: r.Resolver[eq[2]:eq[3]],
+				SourceField: r.Resolver[eq[4]:eq[5]],
+			})
+		}
+	}
+	return links
+}
+
+func tableHasResolver(t contract.Table, name string) bool {
+	for _, r := range t.Resolvers {
+		if r.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// resolve attaches resolver values to record. Paths such as "roles.permissions" resolve roles and
+// then permissions on each role. Only scripts of the form
+// db.from("T").where(eq("field", this.x)...) are understood: rows of T matching every eq are
+// returned as a list, or as the first row when the script ends in firstOrNull(), first() or one().
+// The caller holds the lock.
+func (c *Client) resolve(st *tableStore, record map[string]any, paths []string) error {
+	nested := map[string][]string{}
+	var names []string
+	for _, p := range paths {
+		name, rest, _ := strings.Cut(p, ".")
+		if _, ok := nested[name]; !ok {
+			names = append(names, name)
+			nested[name] = nil
+		}
+		if rest != "" {
+			nested[name] = append(nested[name], rest)
+		}
+	}
+
+	for _, name := range names {
+		var script string
+		for _, r := range st.table.Resolvers {
+			if r.Name == name {
+				script = r.Resolver
+			}
+		}
+		if script == "" {
+			return statusError(http.StatusBadRequest, "bad_request", "table %s has no resolver %s", st.table.Name, name)
+		}
+		if len(resolverFromRe.FindAllString(script, -1)) != 1 {
+			return statusError(http.StatusBadRequest, "bad_request", "resolver %s.%s is not supported by onyxtest: %s", st.table.Name, name, script)
+		}
+		var links []resolverLink
+		for _, link := range resolverLinks(st.table) {
+			if link.Resolver == name {
+				links = append(links, link)
+			}
+		}
+		target, err := c.store(resolverFromRe.FindStringSubmatch(script)[1])
+		if err != nil {
+			return err
+		}
+
+		var matches []any
+		for _, r := range target.sorted() {
+			ok := true
+			for _, link := range links {
+				if !valuesEqual(r.data[link.TargetField], record[link.SourceField]) {
+					ok = false
+					break
+				}
+			}
+			if !ok {
+				continue
+			}
+			child := cloneRecord(r.data)
+			if err := c.resolve(target, child, nested[name]); err != nil {
+				return err
+			}
+			matches = append(matches, child)
+		}
+
+		switch {
+		case !resolverSingleRe.MatchString(strings.TrimSpace(script)):
+			if matches == nil {
+				matches = []any{}
+			}
+			record[name] = matches
+		case len(matches) > 0:
+			record[name] = matches[0]
+		default:
+			record[name] = nil
+		}
+	}
+	return nil
+}
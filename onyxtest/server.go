@@ -0,0 +1,704 @@
+package onyxtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+)
+
+// Credentials and database ID a Server accepts unless changed before its first request.
+const (
+	DefaultDatabaseID = "onyxtest"
+	DefaultAPIKey     = "onyxtest-key"
+	DefaultAPISecret  = "onyxtest-secret"
+)
+
+// Server is an httptest.Server implementing the Onyx REST API on top of an in-memory Client, so
+// tests can drive the real SDK over HTTP. Every request must carry the x-onyx-key and
+// x-onyx-secret headers and address DatabaseID.
+//
+// Query streams write the current results as NDJSON, one record per line. With live streams
+// enabled the connection then stays open and each later write matching the query is sent as
+// {"action": "CREATE"|"UPDATE"|"DELETE", "entity": record}.
+type Server struct {
+	*httptest.Server
+	// DB holds the server's data. Tests may seed and inspect it directly.
+	DB *Client
+
+	DatabaseID string
+	APIKey     string
+	APISecret  string
+
+	mu       sync.Mutex
+	failures []*failureRule
+	requests []Request
+	live     bool
+	done     chan struct{}
+	closed   sync.Once
+}
+
+// Request is a request the server received.
+type Request struct {
+	Method string
+	// Path is the unescaped URL path and Query its raw query string.
+	Path  string
+	Query string
+	Body  []byte
+}
+
+// Failure scripts an error response for matching requests.
+type Failure struct {
+	// Method matches any method when empty.
+	Method string
+	// Path is matched against the request path; a trailing "*" matches any suffix.
+	Path string
+	// Status defaults to 500.
+	Status  int
+	Code    string
+	Message string
+	// Times is how many matching requests fail. Zero fails every matching request until
+	// ClearFailures is called.
+	Times int
+}
+
+type failureRule struct {
+	Failure
+	used int
+}
+
+// NewServer starts a server whose data is an empty Client with the given schema. Close it when
+// the test ends.
+func NewServer(schema contract.Schema) *Server {
+	s := &Server{
+		DB:         NewClient(schema),
+		DatabaseID: DefaultDatabaseID,
+		APIKey:     DefaultAPIKey,
+		APISecret:  DefaultAPISecret,
+		done:       make(chan struct{}),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Config returns a client configuration pointing at the server with valid credentials.
+func (s *Server) Config() contract.Config {
+	return contract.Config{
+		DatabaseID:      s.DatabaseID,
+		DatabaseBaseURL: s.URL,
+		AIBaseURL:       s.URL,
+		APIKey:          s.APIKey,
+		APISecret:       s.APISecret,
+		HTTPClient:      s.Server.Client(),
+	}
+}
+
+// Close ends live streams and shuts the server down.
+func (s *Server) Close() {
+	s.closed.Do(func() { close(s.done) })
+	s.Server.Close()
+}
+
+// SetLiveStreams controls whether query streams stay open and report later changes.
+func (s *Server) SetLiveStreams(live bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.live = live
+}
+
+// Fail makes matching requests return an error response instead of being served.
+func (s *Server) Fail(f Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, &failureRule{Failure: f})
+}
+
+// ClearFailures removes every scripted failure.
+func (s *Server) ClearFailures() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = nil
+}
+
+// Requests returns the authenticated requests received so far, oldest first.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// scriptedFailure records the request and returns the failure it should get, if any.
+func (s *Server) scriptedFailure(r *http.Request, body []byte) *Failure {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery, Body: body})
+	for i, rule := range s.failures {
+		if rule.Method != "" && !strings.EqualFold(rule.Method, r.Method) {
+			continue
+		}
+		if prefix, ok := strings.CutSuffix(rule.Path, "*"); ok {
+			if !strings.HasPrefix(r.URL.Path, prefix) {
+				continue
+			}
+		} else if rule.Path != r.URL.Path {
+			continue
+		}
+		rule.used++
+		if rule.Times > 0 && rule.used >= rule.Times {
+			s.failures = append(s.failures[:i:i], s.failures[i+1:]...)
+		}
+		f := rule.Failure
+		return &f
+	}
+	return nil
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, statusError(http.StatusBadRequest, "bad_request", "read body: %v", err))
+		return
+	}
+	if r.Header.Get("x-onyx-key") != s.APIKey || r.Header.Get("x-onyx-secret") != s.APISecret {
+		writeError(w, statusError(http.StatusUnauthorized, "unauthorized", "invalid API key or secret"))
+		return
+	}
+	if f := s.scriptedFailure(r, body); f != nil {
+		status := f.Status
+		if status == 0 {
+			status = http.StatusInternalServerError
+		}
+		message := f.Message
+		if message == "" {
+			message = http.StatusText(status)
+		}
+		writeError(w, statusError(status, f.Code, "%s", message))
+		return
+	}
+
+	parts, err := pathSegments(r.URL)
+	if err != nil {
+		writeError(w, statusError(http.StatusBadRequest, "bad_request", "invalid path %s: %v", r.URL.Path, err))
+		return
+	}
+	switch {
+	case len(parts) >= 2 && parts[0] == "v1" && parts[1] == "chat":
+		s.serveChat(w, r, body)
+	case len(parts) >= 2 && parts[0] == "v1" && parts[1] == "models":
+		s.serveModels(w, r, parts[2:])
+	case len(parts) == 2 && parts[0] == "api" && parts[1] == "script-approvals":
+		s.serveScriptApproval(w, r, body)
+	case len(parts) >= 2 && (parts[0] == "data" || parts[0] == "database" || parts[0] == "schemas"):
+		if parts[1] != s.DatabaseID {
+			writeError(w, statusError(http.StatusNotFound, "not_found", "database %s not found", parts[1]))
+			return
+		}
+		s.serveDatabase(w, r, parts[0], parts[2:], body)
+	default:
+		writeError(w, statusError(http.StatusNotFound, "not_found", "no route for %s %s", r.Method, r.URL.Path))
+	}
+}
+
+func (s *Server) serveDatabase(w http.ResponseWriter, r *http.Request, root string, rest []string, body []byte) {
+	route := root + "/" + strings.Join(rest, "/")
+	switch {
+	case root == "data" && len(rest) >= 1 && rest[0] == "document":
+		s.serveDocuments(w, r, rest[1:], body)
+	case root == "data" && len(rest) == 2 && rest[0] == "query":
+		s.serveQuery(w, r, rest[1], body)
+	case root == "data" && len(rest) == 3 && rest[0] == "query" && rest[1] == "stream":
+		s.serveStream(w, r, rest[2], body)
+	case root == "data" && len(rest) == 3 && rest[0] == "query" && rest[1] == "update":
+		s.serveUpdate(w, r, rest[2], body)
+	case root == "data" && len(rest) == 3 && rest[0] == "query" && rest[1] == "delete":
+		s.serveDelete(w, r, rest[2], body)
+	case root == "data" && len(rest) == 1:
+		s.serveSave(w, r, rest[0], body)
+	case root == "data" && len(rest) == 2:
+		s.serveRecord(w, r, rest[0], rest[1])
+	case root == "database" && len(rest) == 1 && rest[0] == "schema" && r.Method == http.MethodGet:
+		s.serveSchema(w, r)
+	case root == "database" && len(rest) >= 1 && rest[0] == "secret":
+		s.serveSecrets(w, r, rest[1:], body)
+	case root == "schemas" && len(rest) == 0 && r.Method == http.MethodGet:
+		s.serveSchema(w, r)
+	case root == "schemas" && len(rest) == 0 && r.Method == http.MethodPut:
+		schema, err := contract.ParseSchemaJSON(body)
+		if err == nil {
+			err = s.DB.UpdateSchema(r.Context(), schema, r.URL.Query().Get("publish") == "true")
+		}
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		s.serveSchema(w, r)
+	case root == "schemas" && route == "schemas/validate" && r.Method == http.MethodPost:
+		schema, err := contract.ParseSchemaJSON(body)
+		if err == nil {
+			err = s.DB.ValidateSchema(r.Context(), schema)
+		}
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"valid": true})
+	case root == "schemas" && route == "schemas/history" && r.Method == http.MethodGet:
+		revisions, err := s.DB.GetSchemaRevisions(r.Context())
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		history := make([]map[string]any, 0, len(revisions))
+		for _, rev := range revisions {
+			entry := s.schemaDocument(rev.Schema)
+			entry["revisionId"] = rev.Revision
+			entry["createdAt"] = rev.CreatedAt.UTC().Format(time.RFC3339)
+			if rev.Author != "" {
+				entry["author"] = rev.Author
+			}
+			history = append(history, entry)
+		}
+		writeJSON(w, http.StatusOK, history)
+	default:
+		writeError(w, statusError(http.StatusNotFound, "not_found", "no route for %s %s", r.Method, r.URL.Path))
+	}
+}
+
+// serveSave handles PUT /data/{db}/{table} with a single entity or an array of them.
+func (s *Server) serveSave(w http.ResponseWriter, r *http.Request, table string, body []byte) {
+	if r.Method != http.MethodPut {
+		writeError(w, statusError(http.StatusMethodNotAllowed, "method_not_allowed", "%s not allowed on %s", r.Method, r.URL.Path))
+		return
+	}
+	var relationships []string
+	if rel := r.URL.Query().Get("relationships"); rel != "" {
+		relationships = []string{rel}
+	}
+	var payload any
+	if err := json.Unmarshal(body, &payload); err != nil {
+		writeError(w, statusError(http.StatusBadRequest, "bad_request", "decode entity: %v", err))
+		return
+	}
+	if _, batch := payload.([]any); !batch {
+		saved, err := s.DB.Save(r.Context(), table, payload, relationships)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, saved)
+		return
+	}
+
+	s.DB.mu.Lock()
+	defer s.DB.mu.Unlock()
+	var saved []map[string]any
+	for _, entity := range payload.([]any) {
+		record, err := s.DB.save(table, entity, relationships)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		saved = append(saved, cloneRecord(record))
+	}
+	writeJSON(w, http.StatusOK, saved)
+}
+
+// serveRecord handles GET and DELETE /data/{db}/{table}/{id}, in the row's partition when a
+// partition parameter is given.
+func (s *Server) serveRecord(w http.ResponseWriter, r *http.Request, table, id string) {
+	switch r.Method {
+	case http.MethodGet:
+		s.DB.mu.RLock()
+		defer s.DB.mu.RUnlock()
+		st, err := s.DB.store(table)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		row, err := st.find(id, r.URL.Query().Get("partition"))
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		if row == nil {
+			writeError(w, statusError(http.StatusNotFound, "not_found", "%s %s not found", table, id))
+			return
+		}
+		writeJSON(w, http.StatusOK, row.data)
+	case http.MethodDelete:
+		if err := s.DB.deleteRecord(r.Context(), table, id, r.URL.Query().Get("partition")); err != nil {
+			writeError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, statusError(http.StatusMethodNotAllowed, "method_not_allowed", "%s not allowed on %s", r.Method, r.URL.Path))
+	}
+}
+
+// decodeSpec reads a query payload for table from a request body.
+func decodeSpec(table string, body []byte) (selectSpec, map[string]any, error) {
+	var payload map[string]any
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return selectSpec{}, nil, statusError(http.StatusBadRequest, "bad_request", "decode query: %v", err)
+	}
+	spec := specFromPayload(payload)
+	spec.table = table
+	return spec, payload, nil
+}
+
+// serveQuery handles PUT /data/{db}/query/{table}. A pageSize or nextPage parameter returns one
+// page; otherwise every result is returned.
+func (s *Server) serveQuery(w http.ResponseWriter, r *http.Request, table string, body []byte) {
+	spec, _, err := decodeSpec(table, body)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	s.DB.mu.RLock()
+	rows, err := s.DB.selectRecords(spec)
+	s.DB.mu.RUnlock()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	params := r.URL.Query()
+	size := len(rows)
+	if n, err := strconv.Atoi(params.Get("pageSize")); err == nil && n > 0 {
+		size = n
+	} else if params.Get("nextPage") != "" {
+		size = defaultPageSize
+	}
+	page, err := pageOf(rows, params.Get("nextPage"), size)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := map[string]any{"records": page.Items}
+	if page.NextCursor != "" {
+		resp["nextPage"] = page.NextCursor
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) serveUpdate(w http.ResponseWriter, r *http.Request, table string, body []byte) {
+	spec, payload, err := decodeSpec(table, body)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	updates, _ := payload["updates"].(map[string]any)
+	s.DB.mu.Lock()
+	n, err := s.DB.updateWhere(spec, updates)
+	s.DB.mu.Unlock()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, n)
+}
+
+func (s *Server) serveDelete(w http.ResponseWriter, r *http.Request, table string, body []byte) {
+	spec, _, err := decodeSpec(table, body)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	s.DB.mu.Lock()
+	n, err := s.DB.deleteWhere(spec)
+	s.DB.mu.Unlock()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, n)
+}
+
+// serveStream handles PUT /data/{db}/query/stream/{table}. The watcher is registered under the
+// same lock as the initial results, so no change falls between the two. keepAlive=true makes
+// the stream live regardless of SetLiveStreams, and includeQueryResults=false skips the
+// initial results.
+func (s *Server) serveStream(w http.ResponseWriter, r *http.Request, table string, body []byte) {
+	spec, _, err := decodeSpec(table, body)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	s.mu.Lock()
+	live := s.live || r.URL.Query().Get("keepAlive") == "true"
+	s.mu.Unlock()
+
+	s.DB.mu.Lock()
+	rows, err := s.DB.selectRecords(spec)
+	var watch *watcher
+	var unwatch func()
+	if err == nil && live {
+		watch, unwatch = s.DB.watch()
+	}
+	s.DB.mu.Unlock()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if r.URL.Query().Get("includeQueryResults") == "false" {
+		rows = nil
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	enc := json.NewEncoder(w)
+	for _, row := range rows {
+		if err := enc.Encode(row); err != nil {
+			return
+		}
+	}
+	flush(w)
+	if !live {
+		return
+	}
+	defer unwatch()
+
+	m := &matcher{subquery: s.DB.subquery}
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-s.done:
+			return
+		case <-watch.ready:
+		}
+		for _, ch := range watch.drain() {
+			if ch.table != spec.table && spec.table != allTables {
+				continue
+			}
+			if spec.partition != nil && ch.partition != *spec.partition {
+				continue
+			}
+			s.DB.mu.RLock()
+			ok, _, err := m.matches(spec.conditions, ch.record)
+			s.DB.mu.RUnlock()
+			if err != nil || !ok {
+				continue
+			}
+			entity := ch.record
+			if len(spec.fields) > 0 && !isAggregate(spec) {
+				entity = project(entity, spec.fields)
+			}
+			if err := enc.Encode(map[string]any{"action": ch.action, "entity": entity}); err != nil {
+				return
+			}
+		}
+		flush(w)
+	}
+}
+
+// schemaDocument renders a schema in the API's entities format.
+func (s *Server) schemaDocument(schema contract.Schema) map[string]any {
+	return map[string]any{"databaseId": s.DatabaseID, "entities": contract.SchemaToEntities(schema)}
+}
+
+func (s *Server) serveSchema(w http.ResponseWriter, r *http.Request) {
+	var tables []string
+	if list := r.URL.Query().Get("tables"); list != "" {
+		tables = strings.Split(list, ",")
+	}
+	schema, err := s.DB.GetSchema(r.Context(), tables)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.schemaDocument(schema))
+}
+
+func (s *Server) serveDocuments(w http.ResponseWriter, r *http.Request, rest []string, body []byte) {
+	docs := s.DB.Documents()
+	switch {
+	case len(rest) == 0 && r.Method == http.MethodGet:
+		list, err := docs.List(r.Context())
+		respond(w, list, err)
+	case len(rest) == 0 && r.Method == http.MethodPut:
+		var doc contract.OnyxDocument
+		if err := json.Unmarshal(body, &doc); err != nil {
+			writeError(w, statusError(http.StatusBadRequest, "bad_request", "decode document: %v", err))
+			return
+		}
+		saved, err := docs.Save(r.Context(), doc)
+		respond(w, saved, err)
+	case len(rest) == 1 && r.Method == http.MethodGet:
+		doc, err := docs.Get(r.Context(), rest[0])
+		respond(w, doc, err)
+	case len(rest) == 1 && r.Method == http.MethodDelete:
+		if err := docs.Delete(r.Context(), rest[0]); err != nil {
+			writeError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, statusError(http.StatusNotFound, "not_found", "no route for %s %s", r.Method, r.URL.Path))
+	}
+}
+
+func (s *Server) serveSecrets(w http.ResponseWriter, r *http.Request, rest []string, body []byte) {
+	switch {
+	case len(rest) == 0 && r.Method == http.MethodGet:
+		list, err := s.DB.ListSecrets(r.Context())
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"records": list})
+	case len(rest) == 1 && r.Method == http.MethodGet:
+		secret, err := s.DB.GetSecret(r.Context(), rest[0])
+		respond(w, secret, err)
+	case len(rest) == 1 && r.Method == http.MethodPut:
+		var secret contract.OnyxSecret
+		if err := json.Unmarshal(body, &secret); err != nil {
+			writeError(w, statusError(http.StatusBadRequest, "bad_request", "decode secret: %v", err))
+			return
+		}
+		secret.Key = rest[0]
+		saved, err := s.DB.PutSecret(r.Context(), secret)
+		respond(w, saved, err)
+	case len(rest) == 1 && r.Method == http.MethodDelete:
+		if err := s.DB.DeleteSecret(r.Context(), rest[0]); err != nil {
+			writeError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, statusError(http.StatusNotFound, "not_found", "no route for %s %s", r.Method, r.URL.Path))
+	}
+}
+
+// serveChat answers POST /v1/chat/completions, as server-sent events when the request streams.
+func (s *Server) serveChat(w http.ResponseWriter, r *http.Request, body []byte) {
+	var req contract.AIChatCompletionRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeError(w, statusError(http.StatusBadRequest, "bad_request", "decode chat request: %v", err))
+		return
+	}
+	req.DatabaseID = r.URL.Query().Get("databaseId")
+	if !req.Stream {
+		resp, err := s.DB.Chat(r.Context(), req)
+		respond(w, resp, err)
+		return
+	}
+	stream, err := s.DB.ChatStream(r.Context(), req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	defer stream.Close()
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.WriteHeader(http.StatusOK)
+	for stream.Next() {
+		raw, err := json.Marshal(stream.Chunk())
+		if err != nil {
+			return
+		}
+		fmt.Fprintf(w, "data: %s\n\n", raw)
+		flush(w)
+	}
+	io.WriteString(w, "data: [DONE]\n\n")
+	flush(w)
+}
+
+func (s *Server) serveModels(w http.ResponseWriter, r *http.Request, rest []string) {
+	switch len(rest) {
+	case 0:
+		models, err := s.DB.GetModels(r.Context())
+		respond(w, models, err)
+	case 1:
+		model, err := s.DB.GetModel(r.Context(), rest[0])
+		respond(w, model, err)
+	default:
+		writeError(w, statusError(http.StatusNotFound, "not_found", "no route for %s %s", r.Method, r.URL.Path))
+	}
+}
+
+func (s *Server) serveScriptApproval(w http.ResponseWriter, r *http.Request, body []byte) {
+	var req contract.AIScriptApprovalRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeError(w, statusError(http.StatusBadRequest, "bad_request", "decode script approval: %v", err))
+		return
+	}
+	req.DatabaseID = r.URL.Query().Get("databaseId")
+	resp, err := s.DB.RequestScriptApproval(r.Context(), req)
+	respond(w, resp, err)
+}
+
+// pathSegments splits the escaped path and unescapes each segment, so IDs may contain slashes.
+func pathSegments(u *url.URL) ([]string, error) {
+	parts := strings.Split(strings.Trim(u.EscapedPath(), "/"), "/")
+	for i, p := range parts {
+		unescaped, err := url.PathUnescape(p)
+		if err != nil {
+			return nil, err
+		}
+		parts[i] = unescaped
+	}
+	return parts, nil
+}
+
+func respond(w http.ResponseWriter, v any, err error) {
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, v)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(v); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(buf.Bytes())
+}
+
+// writeError renders err the way the service does: {code, message, meta}. Errors without a
+// status are reported as 400 Bad Request.
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusBadRequest
+	payload := map[string]any{"code": "bad_request", "message": err.Error()}
+	var cerr *contract.Error
+	if errors.As(err, &cerr) {
+		if s, ok := cerr.Meta["status"].(int); ok {
+			status = s
+		}
+		payload = map[string]any{"code": cerr.Code, "message": cerr.Message}
+		meta := map[string]any{}
+		for k, v := range cerr.Meta {
+			if k != "status" {
+				meta[k] = v
+			}
+		}
+		if len(meta) > 0 {
+			payload["meta"] = meta
+		}
+	}
+	raw, _ := json.Marshal(payload)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(raw)
+}
+
+func flush(w http.ResponseWriter) {
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
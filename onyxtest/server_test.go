@@ -0,0 +1,327 @@
+package onyxtest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/OnyxDevTools/onyx-database-go/contract"
+	"github.com/OnyxDevTools/onyx-database-go/impl"
+)
+
+func newTestServer(t *testing.T) (*Server, contract.Client) {
+	t.Helper()
+	srv := NewServer(testSchema())
+	t.Cleanup(srv.Close)
+	db, err := impl.Init(context.Background(), srv.Config())
+	if err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	return srv, db
+}
+
+func TestServerDataRoundTrip(t *testing.T) {
+	srv, db := newTestServer(t)
+	ctx := context.Background()
+
+	saved, err := db.Save(ctx, "User", map[string]any{"email": "ada@example.com", "tenant": "a", "age": 36}, nil)
+	if err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	id, _ := saved["id"].(string)
+	if id == "" || saved["active"] != true {
+		t.Fatalf("unexpected saved record %v", saved)
+	}
+	if err := db.BatchSave(ctx, "User", []any{
+		map[string]any{"id": "u2", "email": "bob@example.com", "tenant": "a", "age": 25},
+		map[string]any{"id": "u3", "email": "cy@test.org", "tenant": "b"},
+	}, 1); err != nil {
+		t.Fatalf("batch save: %v", err)
+	}
+
+	rows, err := db.From("User").Where(contract.Like("email", "%@example.com")).OrderBy(contract.Asc("age")).List(ctx)
+	if err != nil || !reflect.DeepEqual(ids(t, rows), []string{"u2", id}) {
+		t.Fatalf("list: %v %v", ids(t, rows), err)
+	}
+	page, err := db.From("User").OrderBy(contract.Asc("email")).Limit(2).Page(ctx, "")
+	if err != nil || len(page.Items) != 2 || page.NextCursor == "" {
+		t.Fatalf("page: %+v %v", page, err)
+	}
+	page, err = db.From("User").OrderBy(contract.Asc("email")).Limit(2).Page(ctx, page.NextCursor)
+	if err != nil || !reflect.DeepEqual(ids(t, page.Items), []string{"u3"}) || page.NextCursor != "" {
+		t.Fatalf("second page: %+v %v", page, err)
+	}
+
+	res, err := db.Patch(ctx, "User", "u2", map[string]any{"age": 26})
+	if err != nil || res.Status != contract.PatchUpdated {
+		t.Fatalf("patch: %+v %v", res, err)
+	}
+	n, err := db.From("User").Where(contract.Eq("tenant", "a")).SetUpdates(map[string]any{"active": false}).Update(ctx)
+	if err != nil || n != 2 {
+		t.Fatalf("update: %d %v", n, err)
+	}
+	n, err = db.From("User").Where(contract.Eq("active", false)).Delete(ctx)
+	if err != nil || n != 2 {
+		t.Fatalf("delete by query: %d %v", n, err)
+	}
+	if err := db.Delete(ctx, "User", "u3"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if len(srv.DB.Records("User")) != 0 {
+		t.Fatalf("expected no users, got %v", srv.DB.Records("User"))
+	}
+
+	err = db.Delete(ctx, "User", "u3")
+	var cerr *contract.Error
+	if !errors.As(err, &cerr) || cerr.Code != "not_found" || cerr.Meta["status"] != http.StatusNotFound {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
+
+func TestServerDeleteHonorsPartition(t *testing.T) {
+	srv := NewServer(testSchema())
+	t.Cleanup(srv.Close)
+	cfg := srv.Config()
+	cfg.Partition = "b"
+	db, err := impl.Init(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	if err := srv.DB.Seed("User",
+		map[string]any{"id": "u1", "email": "ada@a.example", "tenant": "a"},
+		map[string]any{"id": "u1", "email": "ada@b.example", "tenant": "b"},
+	); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	if err := db.Delete(context.Background(), "User", "u1"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if rows := srv.DB.Records("User"); len(rows) != 1 || rows[0]["tenant"] != "a" {
+		t.Fatalf("only the configured partition should be deleted, got %v", rows)
+	}
+}
+
+func TestServerCascadeAndUnitOfWork(t *testing.T) {
+	srv, db := newTestServer(t)
+	ctx := context.Background()
+	if err := srv.DB.Seed("User", map[string]any{"id": "u1", "email": "ada@example.com", "tenant": "a"}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	if _, err := db.Save(ctx, "User", map[string]any{"id": "u1", "email": "ada@example.com", "tenant": "a", "roles": []any{map[string]any{"name": "admin"}}}, []string{"roles:Role(userId,id)"}); err != nil {
+		t.Fatalf("cascade save: %v", err)
+	}
+	if roles := srv.DB.Records("Role"); len(roles) != 1 || roles[0]["userId"] != "u1" {
+		t.Fatalf("unexpected roles %v", roles)
+	}
+	if err := db.Cascade(contract.Cascade("")).Delete(ctx, "User", "u1"); err != nil {
+		t.Fatalf("cascade delete: %v", err)
+	}
+	if len(srv.DB.Records("User")) != 0 || len(srv.DB.Records("Role")) != 0 {
+		t.Fatalf("cascade delete left rows")
+	}
+
+	report, err := db.UnitOfWork().
+		Save("Permission", map[string]any{"id": "p1"}).
+		Update("Permission", "missing", map[string]any{"roleId": 1}).
+		Commit(ctx)
+	if err == nil || report.Committed || len(srv.DB.Records("Permission")) != 0 {
+		t.Fatalf("expected rolled back unit of work, got %+v %v %v", report, err, srv.DB.Records("Permission"))
+	}
+}
+
+func TestServerAuthAndScriptedFailures(t *testing.T) {
+	srv, db := newTestServer(t)
+	ctx := context.Background()
+
+	cfg := srv.Config()
+	cfg.APISecret = "wrong"
+	bad, err := impl.Init(ctx, cfg)
+	if err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	_, err = bad.From("User").List(ctx)
+	var cerr *contract.Error
+	if !errors.As(err, &cerr) || cerr.Meta["status"] != http.StatusUnauthorized {
+		t.Fatalf("expected unauthorized, got %v", err)
+	}
+
+	srv.Fail(Failure{Method: http.MethodPut, Path: "/data/onyxtest/Permission", Status: http.StatusServiceUnavailable, Times: 1})
+	if err := db.BatchSave(ctx, "Permission", []any{map[string]any{"id": "p1"}}, 10); err != nil {
+		t.Fatalf("batch save should retry once: %v", err)
+	}
+	var puts int
+	for _, r := range srv.Requests() {
+		if r.Method == http.MethodPut && r.Path == "/data/onyxtest/Permission" {
+			puts++
+		}
+	}
+	if puts != 2 || len(srv.DB.Records("Permission")) != 1 {
+		t.Fatalf("expected a failed and a retried request, got %d", puts)
+	}
+
+	srv.Fail(Failure{Path: "/data/onyxtest/query/*", Status: http.StatusConflict, Code: "conflict", Message: "boom"})
+	for i := 0; i < 2; i++ {
+		_, err = db.From("Permission").List(ctx)
+		if !errors.As(err, &cerr) || cerr.Code != "conflict" || cerr.Message != "boom" || cerr.Meta["status"] != http.StatusConflict {
+			t.Fatalf("expected scripted failure, got %v", err)
+		}
+	}
+	srv.ClearFailures()
+	if _, err := db.From("Permission").List(ctx); err != nil {
+		t.Fatalf("list after clearing failures: %v", err)
+	}
+}
+
+func TestServerLiveStream(t *testing.T) {
+	srv, db := newTestServer(t)
+	ctx := context.Background()
+	srv.SetLiveStreams(true)
+	if err := srv.DB.Seed("Role", map[string]any{"userId": "u1", "name": "admin"}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	it, err := db.From("Role").Where(contract.Eq("userId", "u1")).Stream(ctx)
+	if err != nil {
+		t.Fatalf("stream: %v", err)
+	}
+	defer it.Close()
+	if !it.Next() || it.Value()["name"] != "admin" {
+		t.Fatalf("expected initial row, got %v %v", it.Value(), it.Err())
+	}
+
+	if _, err := db.Save(ctx, "Role", map[string]any{"userId": "u2", "name": "other"}, nil); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if _, err := db.Save(ctx, "Role", map[string]any{"userId": "u1", "name": "ops"}, nil); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if err := db.Delete(ctx, "Role", "1"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	var events []string
+	for len(events) < 2 && it.Next() {
+		entity, _ := it.Value()["entity"].(map[string]any)
+		events = append(events, it.Value()["action"].(string)+":"+entity["name"].(string))
+	}
+	if want := []string{"CREATE:ops", "DELETE:admin"}; !reflect.DeepEqual(events, want) {
+		t.Fatalf("unexpected events %v (%v)", events, it.Err())
+	}
+}
+
+func TestServerSchemaDocumentsSecretsAndAI(t *testing.T) {
+	srv, db := newTestServer(t)
+	ctx := context.Background()
+
+	schema, err := db.GetSchema(ctx, []string{"Role"})
+	if err != nil || len(schema.Tables) != 1 || schema.Tables[0].Name != "Role" {
+		t.Fatalf("get schema: %+v %v", schema, err)
+	}
+	next := testSchema()
+	next.Tables = next.Tables[1:]
+	if err := db.ValidateSchema(ctx, contract.Schema{Tables: []contract.Table{{Name: "Empty"}}}); err == nil {
+		t.Fatalf("expected validation error")
+	}
+	if err := db.UpdateSchema(ctx, next, true); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	revisions, err := db.GetSchemaRevisions(ctx)
+	if err != nil || len(revisions) != 2 || revisions[1].Revision != "2" || len(revisions[1].Schema.Tables) != 2 {
+		t.Fatalf("revisions: %+v %v", revisions, err)
+	}
+	if _, ok := srv.DB.schema.Table("User"); ok {
+		t.Fatalf("published schema should drop User")
+	}
+
+	if _, err := db.Documents().Save(ctx, contract.OnyxDocument{DocumentID: "readme", Content: "hi"}); err != nil {
+		t.Fatalf("save document: %v", err)
+	}
+	docs, err := db.Documents().List(ctx)
+	if err != nil || len(docs) != 1 || docs[0].Content != "hi" {
+		t.Fatalf("list documents: %+v %v", docs, err)
+	}
+
+	if _, err := db.PutSecret(ctx, contract.OnyxSecret{Key: "api", Value: "s3cret"}); err != nil {
+		t.Fatalf("put secret: %v", err)
+	}
+	secrets, err := db.ListSecrets(ctx)
+	if err != nil || len(secrets) != 1 || secrets[0].Key != "api" {
+		t.Fatalf("list secrets: %+v %v", secrets, err)
+	}
+	if err := db.DeleteSecret(ctx, "api"); err != nil {
+		t.Fatalf("delete secret: %v", err)
+	}
+
+	req := contract.AIChatCompletionRequest{Messages: []contract.AIChatMessage{{Role: "user", Content: "hello over http"}}}
+	resp, err := db.Chat(ctx, req)
+	if err != nil || resp.Choices[0].Message.Content != "hello over http" {
+		t.Fatalf("chat: %+v %v", resp, err)
+	}
+	stream, err := db.ChatStream(ctx, req)
+	if err != nil {
+		t.Fatalf("chat stream: %v", err)
+	}
+	defer stream.Close()
+	var text string
+	for stream.Next() {
+		text += stream.Chunk().Choices[0].Delta.Content
+	}
+	if text != "hello over http" || stream.Err() != nil {
+		t.Fatalf("unexpected stream %q %v", text, stream.Err())
+	}
+	models, err := db.GetModels(ctx)
+	if err != nil || len(models.Data) != 1 {
+		t.Fatalf("models: %+v %v", models, err)
+	}
+}
+
+func TestServerQueryCacheWatch(t *testing.T) {
+	srv := NewServer(testSchema())
+	t.Cleanup(srv.Close)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	cfg := srv.Config()
+	cfg.QueryCache = &contract.QueryCacheConfig{TTL: time.Hour, WatchTables: []string{"Role"}}
+	db, err := impl.Init(ctx, cfg)
+	if err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	waitFor := func(cond func() bool) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for !cond() {
+			if time.Now().After(deadline) {
+				t.Fatalf("timed out; stats %+v", db.Stats().QueryCache)
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+	waitFor(func() bool {
+		for _, r := range srv.Requests() {
+			if r.Path == "/data/onyxtest/query/stream/Role" && r.Query == "includeQueryResults=false&keepAlive=true" {
+				return true
+			}
+		}
+		return false
+	})
+
+	// The watch also invalidates as it connects, so list until a read is served from the cache.
+	waitFor(func() bool {
+		if rows, err := db.From("Role").List(ctx); err != nil || len(rows) != 0 {
+			t.Fatalf("list: %v %v", rows, err)
+		}
+		return db.Stats().QueryCache.Hits > 0
+	})
+
+	// Another client writes straight to the server; only the watch stream can tell us.
+	if err := srv.DB.Seed("Role", map[string]any{"userId": "u1", "name": "admin"}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	waitFor(func() bool { return db.Stats().QueryCache.Entries == 0 })
+	if rows, err := db.From("Role").List(ctx); err != nil || len(rows) != 1 {
+		t.Fatalf("expected the remote change after eviction, got %v %v", rows, err)
+	}
+}
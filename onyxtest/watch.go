@@ -0,0 +1,83 @@
+package onyxtest
+
+import "sync"
+
+// Change actions, as reported in live stream events.
+const (
+	ActionCreate = "CREATE"
+	ActionUpdate = "UPDATE"
+	ActionDelete = "DELETE"
+)
+
+// change is one write to a table.
+type change struct {
+	action    string
+	table     string
+	partition string
+	record    map[string]any
+}
+
+// watcher queues the changes made after it was registered. The queue is unbounded so writers
+// never block on slow readers.
+type watcher struct {
+	mu    sync.Mutex
+	queue []change
+	ready chan struct{}
+}
+
+// watch registers a watcher; the returned function unregisters it. The caller holds the lock,
+// so a snapshot taken under the same lock is followed by exactly the changes made after it.
+func (c *Client) watch() (*watcher, func()) {
+	w := &watcher{ready: make(chan struct{}, 1)}
+	if c.watchers == nil {
+		c.watchers = map[*watcher]struct{}{}
+	}
+	c.watchers[w] = struct{}{}
+	return w, func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		delete(c.watchers, w)
+	}
+}
+
+// drain returns and clears the queued changes.
+func (w *watcher) drain() []change {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	out := w.queue
+	w.queue = nil
+	return out
+}
+
+// emit delivers a change to every watcher, or holds it back while a unit of work is in progress
+// so rolled back writes are never observed. The caller holds the lock.
+func (c *Client) emit(action string, st *tableStore, r *row) {
+	ch := change{action: action, table: st.table.Name, partition: r.partition, record: cloneRecord(r.data)}
+	if c.pending != nil {
+		*c.pending = append(*c.pending, ch)
+		return
+	}
+	c.deliver(ch)
+}
+
+func (c *Client) deliver(changes ...change) {
+	for w := range c.watchers {
+		w.mu.Lock()
+		w.queue = append(w.queue, changes...)
+		w.mu.Unlock()
+		select {
+		case w.ready <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// remove deletes a row and reports the deletion. The caller holds the lock.
+func (c *Client) remove(st *tableStore, key rowKey) {
+	r, ok := st.rows[key]
+	if !ok {
+		return
+	}
+	delete(st.rows, key)
+	c.emit(ActionDelete, st, r)
+}